@@ -0,0 +1,221 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package baseline
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/afero/mem"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+// Dir is the directory in which the baseline of each generated file is recorded.  The baseline is
+// the content of a file as it was last generated, prior to any edits by the user.
+var Dir = filepath.Join(".operator-builder", "baseline")
+
+// Path returns the path of the baseline of a generated file.
+func Path(name string) string {
+	return filepath.Join(Dir, name)
+}
+
+// Exists returns whether a baseline has been recorded for a generated file within a filesystem.
+func Exists(fs afero.Fs, name string) bool {
+	exists, err := afero.Exists(fs, Path(name))
+
+	return err == nil && exists
+}
+
+// IfExistsAction returns the action for a file which is owned by the user once it has been
+// generated, such as a mutate function.  The file is skipped if it exists without a baseline, as
+// it was generated before baselines were recorded, otherwise it is merged with the newly
+// generated content.
+func IfExistsAction(fs afero.Fs, name string) machinery.IfExistsAction {
+	if Exists(fs, name) {
+		return machinery.OverwriteFile
+	}
+
+	return machinery.SkipFile
+}
+
+// Filesystem is a filesystem which records the baseline of each generated file which is written
+// to it.  When a file which already has a baseline is written, the edits which the user has made
+// to the file since it was last generated are merged with the newly generated content.
+type Filesystem struct {
+	afero.Fs
+
+	mutex     sync.Mutex
+	conflicts map[string]bool
+	errs      []error
+}
+
+// NewFilesystem returns a new filesystem which records the baseline of the generated files which
+// are written to an underlying filesystem.
+func NewFilesystem(fs afero.Fs) *Filesystem {
+	return &Filesystem{Fs: fs, conflicts: map[string]bool{}}
+}
+
+// Name returns the name of the filesystem.
+func (fs *Filesystem) Name() string {
+	return "BaselineFs"
+}
+
+// Create creates a generated file, which is written once it is closed.
+func (fs *Filesystem) Create(name string) (afero.File, error) {
+	return fs.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o666)
+}
+
+// OpenFile opens a file.  When a generated file is opened for writing, its content is buffered
+// and merged with the existing file once it is closed.
+func (fs *Filesystem) OpenFile(name string, flag int, perm os.FileMode) (afero.File, error) {
+	if flag&(os.O_WRONLY|os.O_RDWR) == 0 || !isGenerated(name) {
+		return fs.Fs.OpenFile(name, flag, perm) //nolint:wrapcheck
+	}
+
+	content := mem.CreateFile(name)
+	handle := mem.NewFileHandle(content)
+
+	// files which are opened to append to or update must contain their existing content
+	if flag&os.O_TRUNC == 0 {
+		existing, err := afero.ReadFile(fs.Fs, name)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%w; unable to read file %s", err, name)
+		}
+
+		if _, err := handle.Write(existing); err != nil {
+			return nil, fmt.Errorf("%w; unable to buffer file %s", err, name)
+		}
+
+		if flag&os.O_APPEND == 0 {
+			if _, err := handle.Seek(0, io.SeekStart); err != nil {
+				return nil, fmt.Errorf("%w; unable to buffer file %s", err, name)
+			}
+		}
+	}
+
+	return &file{File: handle, content: content, fs: fs, name: name, perm: perm}, nil
+}
+
+// Conflicts returns the generated files which were written with conflict markers, as both the
+// user and the newly generated content changed the same lines differently.
+func (fs *Filesystem) Conflicts() []string {
+	fs.mutex.Lock()
+	defer fs.mutex.Unlock()
+
+	conflicts := make([]string, 0, len(fs.conflicts))
+	for name := range fs.conflicts {
+		conflicts = append(conflicts, name)
+	}
+
+	sort.Strings(conflicts)
+
+	return conflicts
+}
+
+// Err returns the errors which occurred while writing generated files.  The files are written
+// when they are closed, and the errors which are returned when closing a file are not always
+// checked by the scaffold which writes them.
+func (fs *Filesystem) Err() error {
+	fs.mutex.Lock()
+	defer fs.mutex.Unlock()
+
+	return errors.Join(fs.errs...)
+}
+
+// write writes the generated content of a file, merging it with the existing content of the file
+// if a baseline was recorded when it was last generated, and records the generated content as the
+// new baseline for the file.
+func (fs *Filesystem) write(name string, generated []byte, perm os.FileMode) error {
+	content := generated
+
+	base, err := afero.ReadFile(fs.Fs, Path(name))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%w; unable to read baseline for file %s", err, name)
+	}
+
+	if err == nil {
+		current, err := afero.ReadFile(fs.Fs, name)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("%w; unable to read file %s", err, name)
+		}
+
+		// a file which has been removed by the user is generated again
+		if err == nil && !bytes.Equal(current, generated) {
+			merged, conflicted := Merge(string(base), string(current), string(generated))
+
+			content = []byte(merged)
+
+			if conflicted {
+				fs.mutex.Lock()
+				fs.conflicts[name] = true
+				fs.mutex.Unlock()
+			}
+		}
+	}
+
+	if err := afero.WriteFile(fs.Fs, name, content, perm); err != nil {
+		return fmt.Errorf("%w; unable to write file %s", err, name)
+	}
+
+	if err := fs.Fs.MkdirAll(filepath.Dir(Path(name)), 0o755); err != nil {
+		return fmt.Errorf("%w; unable to create baseline directory for file %s", err, name)
+	}
+
+	if err := afero.WriteFile(fs.Fs, Path(name), generated, 0o644); err != nil {
+		return fmt.Errorf("%w; unable to write baseline for file %s", err, name)
+	}
+
+	return nil
+}
+
+// isGenerated returns whether a file is a generated file within the project, for which a baseline
+// is recorded.  The project config is updated in place rather than generated.
+func isGenerated(name string) bool {
+	clean := filepath.Clean(name)
+
+	return !filepath.IsAbs(clean) &&
+		clean != "PROJECT" &&
+		!strings.HasPrefix(clean, "..") &&
+		!strings.HasPrefix(clean, ".operator-builder"+string(filepath.Separator))
+}
+
+// file is a generated file which is buffered in memory until it is closed.
+type file struct {
+	afero.File
+
+	content *mem.FileData
+	fs      *Filesystem
+	name    string
+	perm    os.FileMode
+}
+
+// Close writes the buffered content of the file.
+func (f *file) Close() error {
+	if err := f.File.Close(); err != nil {
+		return fmt.Errorf("%w; unable to close file %s", err, f.name)
+	}
+
+	generated, err := afero.ReadAll(mem.NewReadOnlyFileHandle(f.content))
+	if err != nil {
+		return fmt.Errorf("%w; unable to read buffered file %s", err, f.name)
+	}
+
+	if err := f.fs.write(f.name, generated, f.perm); err != nil {
+		f.fs.mutex.Lock()
+		f.fs.errs = append(f.fs.errs, err)
+		f.fs.mutex.Unlock()
+
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package baseline
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+func TestFilesystem_write(t *testing.T) {
+	t.Parallel()
+
+	underlying := afero.NewMemMapFs()
+	fs := NewFilesystem(underlying)
+
+	// the first generation records the baseline
+	require.NoError(t, afero.WriteFile(fs, "main.go", []byte("package main\n\nfunc a() {}\n"), 0o644))
+
+	baseline, err := afero.ReadFile(underlying, Path("main.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "package main\n\nfunc a() {}\n", string(baseline))
+
+	// the edits of the user are merged with the next generation
+	require.NoError(t, afero.WriteFile(underlying, "main.go", []byte("// edited\npackage main\n\nfunc a() {}\n"), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "main.go", []byte("package main\n\nfunc b() {}\n"), 0o644))
+
+	content, err := afero.ReadFile(underlying, "main.go")
+	require.NoError(t, err)
+	assert.Equal(t, "// edited\npackage main\n\nfunc b() {}\n", string(content))
+
+	baseline, err = afero.ReadFile(underlying, Path("main.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "package main\n\nfunc b() {}\n", string(baseline))
+
+	// conflicting edits are written with conflict markers
+	require.NoError(t, afero.WriteFile(underlying, "main.go", []byte("package main\n\nfunc edited() {}\n"), 0o644))
+	require.NoError(t, afero.WriteFile(fs, "main.go", []byte("package main\n\nfunc c() {}\n"), 0o644))
+
+	content, err = afero.ReadFile(underlying, "main.go")
+	require.NoError(t, err)
+	assert.Contains(t, string(content), conflictCurrent)
+	assert.Equal(t, []string{"main.go"}, fs.Conflicts())
+	assert.NoError(t, fs.Err())
+
+	// the project config does not have a baseline
+	require.NoError(t, afero.WriteFile(fs, "PROJECT", []byte("version: \"3\"\n"), 0o644))
+
+	exists, err := afero.Exists(underlying, Path("PROJECT"))
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestIfExistsAction(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	// a file without a baseline was generated before baselines were recorded
+	assert.False(t, Exists(fs, "main.go"))
+	assert.Equal(t, machinery.SkipFile, IfExistsAction(fs, "main.go"))
+
+	require.NoError(t, afero.WriteFile(fs, Path("main.go"), []byte("package main\n"), 0o644))
+
+	assert.True(t, Exists(fs, "main.go"))
+	assert.Equal(t, machinery.OverwriteFile, IfExistsAction(fs, "main.go"))
+}
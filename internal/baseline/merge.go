@@ -0,0 +1,172 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package baseline
+
+import (
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+const (
+	conflictCurrent   = "<<<<<<< current"
+	conflictBaseline  = "||||||| baseline"
+	conflictSplit     = "======="
+	conflictGenerated = ">>>>>>> generated"
+)
+
+// Merge performs a three-way merge of the current content of a file, which may contain edits made
+// by the user, and the newly generated content of a file, relative to the baseline content which
+// was generated previously.  Where both have changed the same lines differently, the lines are
+// surrounded by conflict markers and the returned bool is true.
+func Merge(base, current, generated string) (string, bool) {
+	switch {
+	case current == base, current == generated:
+		return generated, false
+	case generated == base:
+		return current, false
+	}
+
+	baseLines, currentLines, generatedLines := splitLines(base), splitLines(current), splitLines(generated)
+	currentMatches := matches(baseLines, currentLines)
+	generatedMatches := matches(baseLines, generatedLines)
+
+	var merged strings.Builder
+
+	conflicted := false
+
+	baseIndex, currentIndex, generatedIndex := 0, 0, 0
+
+	for {
+		// copy the lines which are unchanged on both sides
+		for baseIndex < len(baseLines) &&
+			isMatch(currentMatches, baseIndex, currentIndex) &&
+			isMatch(generatedMatches, baseIndex, generatedIndex) {
+			merged.WriteString(baseLines[baseIndex])
+
+			baseIndex++
+			currentIndex++
+			generatedIndex++
+		}
+
+		// find the next line of the baseline which is unchanged on both sides, the lines prior to
+		// which are a chunk that has changed on at least one side
+		next := baseIndex
+		for next < len(baseLines) && !(hasMatch(currentMatches, next) && hasMatch(generatedMatches, next)) {
+			next++
+		}
+
+		currentEnd, generatedEnd := len(currentLines), len(generatedLines)
+		if next < len(baseLines) {
+			currentEnd, generatedEnd = currentMatches[next], generatedMatches[next]
+		}
+
+		if mergeChunk(
+			&merged,
+			baseLines[baseIndex:next],
+			currentLines[currentIndex:currentEnd],
+			generatedLines[generatedIndex:generatedEnd],
+		) {
+			conflicted = true
+		}
+
+		if next == len(baseLines) {
+			break
+		}
+
+		baseIndex, currentIndex, generatedIndex = next, currentEnd, generatedEnd
+	}
+
+	return merged.String(), conflicted
+}
+
+// mergeChunk writes the merge of a chunk which has changed on at least one side.  It returns true
+// if both sides have changed the chunk differently, in which case the chunk is written with
+// conflict markers.
+func mergeChunk(merged *strings.Builder, base, current, generated []string) bool {
+	switch {
+	case equal(current, base):
+		writeLines(merged, generated)
+	case equal(generated, base), equal(current, generated):
+		writeLines(merged, current)
+	default:
+		merged.WriteString(conflictCurrent + "\n")
+		writeConflictLines(merged, current)
+		merged.WriteString(conflictBaseline + "\n")
+		writeConflictLines(merged, base)
+		merged.WriteString(conflictSplit + "\n")
+		writeConflictLines(merged, generated)
+		merged.WriteString(conflictGenerated + "\n")
+
+		return true
+	}
+
+	return false
+}
+
+// matches returns the index of the line of b which each line of a is matched with, for the lines
+// of a which are unchanged in b.
+func matches(a, b []string) map[int]int {
+	matched := map[int]int{}
+
+	for _, block := range difflib.NewMatcherWithJunk(a, b, false, nil).GetMatchingBlocks() {
+		for i := 0; i < block.Size; i++ {
+			matched[block.A+i] = block.B + i
+		}
+	}
+
+	return matched
+}
+
+func hasMatch(matched map[int]int, index int) bool {
+	_, ok := matched[index]
+
+	return ok
+}
+
+func isMatch(matched map[int]int, index, matchIndex int) bool {
+	i, ok := matched[index]
+
+	return ok && i == matchIndex
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// splitLines splits content into lines which include their line endings.
+func splitLines(content string) []string {
+	lines := strings.SplitAfter(content, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
+
+func writeLines(merged *strings.Builder, lines []string) {
+	for _, line := range lines {
+		merged.WriteString(line)
+	}
+}
+
+// writeConflictLines writes the lines of one side of a conflict, ensuring that the last of them
+// ends with a line ending so that the conflict marker which follows is on its own line.
+func writeConflictLines(merged *strings.Builder, lines []string) {
+	writeLines(merged, lines)
+
+	if len(lines) > 0 && !strings.HasSuffix(lines[len(lines)-1], "\n") {
+		merged.WriteString("\n")
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package baseline
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+
+	base := "package test\n\nfunc a() {}\n\nfunc b() {}\n"
+
+	tests := []struct {
+		name         string
+		current      string
+		generated    string
+		want         string
+		wantConflict bool
+	}{
+		{
+			name:      "unedited file takes generated content",
+			current:   base,
+			generated: "package test\n\nfunc a() {}\n\nfunc c() {}\n",
+			want:      "package test\n\nfunc a() {}\n\nfunc c() {}\n",
+		},
+		{
+			name:      "unchanged template keeps edits",
+			current:   "package test\n\n// edited\nfunc a() {}\n\nfunc b() {}\n",
+			generated: base,
+			want:      "package test\n\n// edited\nfunc a() {}\n\nfunc b() {}\n",
+		},
+		{
+			name:      "edits and template changes to different lines are both kept",
+			current:   "// edited\npackage test\n\nfunc a() {}\n\nfunc b() {}\n",
+			generated: "package test\n\nfunc a() {}\n\nfunc c() {}\n",
+			want:      "// edited\npackage test\n\nfunc a() {}\n\nfunc c() {}\n",
+		},
+		{
+			name:      "identical changes on both sides are not a conflict",
+			current:   "// edited\npackage test\n\nfunc a() {}\n\nfunc c() {}\n",
+			generated: "package test\n\nfunc a() {}\n\nfunc c() {}\n",
+			want:      "// edited\npackage test\n\nfunc a() {}\n\nfunc c() {}\n",
+		},
+		{
+			name:      "different changes to the same lines are a conflict",
+			current:   "package test\n\nfunc a() {}\n\nfunc edited() {}\n",
+			generated: "package test\n\nfunc a() {}\n\nfunc c() {}\n",
+			want: "package test\n\nfunc a() {}\n\n" +
+				"<<<<<<< current\nfunc edited() {}\n" +
+				"||||||| baseline\nfunc b() {}\n" +
+				"=======\nfunc c() {}\n" +
+				">>>>>>> generated\n",
+			wantConflict: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, conflict := Merge(base, tt.current, tt.generated)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantConflict, conflict)
+		})
+	}
+}
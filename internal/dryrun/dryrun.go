@@ -0,0 +1,234 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package dryrun
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/afero"
+)
+
+// Flag is the name of the flag which requests that a command is run without writing any files.
+const Flag = "dry-run"
+
+// Requested returns whether the dry run flag is set within a set of command line arguments.
+func Requested(args []string) bool {
+	requested := false
+
+	for _, arg := range args {
+		switch arg {
+		case "--":
+			return requested
+		case "--" + Flag, "--" + Flag + "=true":
+			requested = true
+		case "--" + Flag + "=false":
+			requested = false
+		}
+	}
+
+	return requested
+}
+
+// Filesystem is a filesystem which reads from the underlying operating system filesystem and
+// writes to an in-memory filesystem, so that a scaffold may be run without changing any files.
+// It records the files which are written and the existing files which are left untouched after
+// being checked for, which are the files that a scaffold skips because they already exist.
+type Filesystem struct {
+	afero.Fs
+
+	base afero.Fs
+
+	mutex   sync.Mutex
+	written map[string]bool
+	checked map[string]bool
+}
+
+// NewFilesystem returns a new filesystem for a dry run.
+func NewFilesystem() *Filesystem {
+	base := afero.NewOsFs()
+
+	return &Filesystem{
+		Fs:      afero.NewCopyOnWriteFs(afero.NewReadOnlyFs(base), afero.NewMemMapFs()),
+		base:    base,
+		written: map[string]bool{},
+		checked: map[string]bool{},
+	}
+}
+
+// Name returns the name of the filesystem.
+func (fs *Filesystem) Name() string {
+	return "DryRunFs"
+}
+
+// Create creates a file in the in-memory filesystem.
+func (fs *Filesystem) Create(name string) (afero.File, error) {
+	fs.record(fs.written, name)
+
+	return fs.Fs.Create(name)
+}
+
+// OpenFile opens a file, which is created or copied to the in-memory filesystem if it is opened
+// for writing.
+func (fs *Filesystem) OpenFile(name string, flag int, perm os.FileMode) (afero.File, error) {
+	if flag&(os.O_WRONLY|os.O_RDWR|os.O_APPEND|os.O_CREATE|os.O_TRUNC) != 0 {
+		fs.record(fs.written, name)
+	}
+
+	return fs.Fs.OpenFile(name, flag, perm)
+}
+
+// Stat returns the info of a file.
+func (fs *Filesystem) Stat(name string) (os.FileInfo, error) {
+	info, err := fs.Fs.Stat(name)
+	if err == nil && !info.IsDir() {
+		fs.record(fs.checked, name)
+	}
+
+	//nolint:wrapcheck
+	return info, err
+}
+
+func (fs *Filesystem) record(files map[string]bool, name string) {
+	fs.mutex.Lock()
+	defer fs.mutex.Unlock()
+
+	files[name] = true
+}
+
+// Report is the set of files which would have been changed by a dry run.  Files which would have
+// been overwritten with their existing content are unchanged.  The diffs contain a unified diff
+// for each of the created and overwritten files.
+type Report struct {
+	Created     []string
+	Overwritten []string
+	Unchanged   []string
+	Skipped     []string
+	Diffs       map[string]string
+}
+
+// Report returns the report of the files which would have been changed by a dry run.
+func (fs *Filesystem) Report() (*Report, error) {
+	fs.mutex.Lock()
+	defer fs.mutex.Unlock()
+
+	report := &Report{Diffs: map[string]string{}}
+
+	for _, name := range sortedKeys(fs.written) {
+		previous, existed, err := readIfExists(fs.base, name)
+		if err != nil {
+			return nil, err
+		}
+
+		current, err := afero.ReadFile(fs.Fs, name)
+		if err != nil {
+			return nil, fmt.Errorf("%w; unable to read file %s from dry run", err, name)
+		}
+
+		switch {
+		case !existed:
+			report.Created = append(report.Created, name)
+		case bytes.Equal(previous, current):
+			report.Unchanged = append(report.Unchanged, name)
+
+			continue
+		default:
+			report.Overwritten = append(report.Overwritten, name)
+		}
+
+		diff := difflib.UnifiedDiff{
+			B:        difflib.SplitLines(string(current)),
+			FromFile: "/dev/null",
+			ToFile:   "b/" + name,
+			Context:  3,
+		}
+
+		if existed {
+			diff.A = difflib.SplitLines(string(previous))
+			diff.FromFile = "a/" + name
+		}
+
+		unified, err := difflib.GetUnifiedDiffString(diff)
+		if err != nil {
+			return nil, fmt.Errorf("%w; unable to diff file %s", err, name)
+		}
+
+		report.Diffs[name] = unified
+	}
+
+	for _, name := range sortedKeys(fs.checked) {
+		if !fs.written[name] {
+			report.Skipped = append(report.Skipped, name)
+		}
+	}
+
+	return report, nil
+}
+
+// Write writes the lists of created, overwritten, unchanged and skipped files to a writer,
+// followed by the diff of each of the created and overwritten files.
+func (report *Report) Write(w io.Writer) error {
+	var buffer bytes.Buffer
+
+	for _, section := range []struct {
+		title string
+		files []string
+	}{
+		{title: "created", files: report.Created},
+		{title: "overwritten", files: report.Overwritten},
+		{title: "unchanged", files: report.Unchanged},
+		{title: "skipped", files: report.Skipped},
+	} {
+		fmt.Fprintf(&buffer, "%s files (%d):\n", section.title, len(section.files))
+
+		for _, name := range section.files {
+			fmt.Fprintf(&buffer, "  %s\n", name)
+		}
+	}
+
+	for _, name := range append(append([]string{}, report.Created...), report.Overwritten...) {
+		fmt.Fprintf(&buffer, "\n%s", report.Diffs[name])
+	}
+
+	if _, err := io.Copy(w, &buffer); err != nil {
+		return fmt.Errorf("%w; unable to write dry run report", err)
+	}
+
+	return nil
+}
+
+func readIfExists(fs afero.Fs, name string) ([]byte, bool, error) {
+	exists, err := afero.Exists(fs, name)
+	if err != nil {
+		return nil, false, fmt.Errorf("%w; unable to check for file %s", err, name)
+	}
+
+	if !exists {
+		return nil, false, nil
+	}
+
+	content, err := afero.ReadFile(fs, name)
+	if err != nil {
+		return nil, false, fmt.Errorf("%w; unable to read file %s", err, name)
+	}
+
+	return content, true, nil
+}
+
+func sortedKeys(files map[string]bool) []string {
+	keys := make([]string, 0, len(files))
+
+	for key := range files {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,109 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package dryrun
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequested(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		args []string
+		want bool
+	}{
+		{
+			name: "missing flag",
+			args: []string{"create", "api", "--force"},
+			want: false,
+		},
+		{
+			name: "flag without value",
+			args: []string{"create", "api", "--dry-run"},
+			want: true,
+		},
+		{
+			name: "flag with true value",
+			args: []string{"init", "--dry-run=true"},
+			want: true,
+		},
+		{
+			name: "flag with false value",
+			args: []string{"init", "--dry-run", "--dry-run=false"},
+			want: false,
+		},
+		{
+			name: "flag after end of flags",
+			args: []string{"init", "--", "--dry-run"},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, Requested(tt.args))
+		})
+	}
+}
+
+func TestFilesystem_Report(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	for name, content := range map[string]string{
+		"overwritten.txt": "one\ntwo\n",
+		"unchanged.txt":   "same\n",
+		"skipped.txt":     "skipped\n",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+
+	fs := NewFilesystem()
+
+	for name, content := range map[string]string{
+		"created.txt":     "new\n",
+		"overwritten.txt": "one\nthree\n",
+		"unchanged.txt":   "same\n",
+	} {
+		require.NoError(t, afero.WriteFile(fs, filepath.Join(dir, name), []byte(content), 0o600))
+	}
+
+	exists, err := afero.Exists(fs, filepath.Join(dir, "skipped.txt"))
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	// the files on disk are left untouched
+	content, err := os.ReadFile(filepath.Join(dir, "overwritten.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "one\ntwo\n", string(content))
+	assert.NoFileExists(t, filepath.Join(dir, "created.txt"))
+
+	report, err := fs.Report()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{filepath.Join(dir, "created.txt")}, report.Created)
+	assert.Equal(t, []string{filepath.Join(dir, "overwritten.txt")}, report.Overwritten)
+	assert.Equal(t, []string{filepath.Join(dir, "unchanged.txt")}, report.Unchanged)
+	assert.Equal(t, []string{filepath.Join(dir, "skipped.txt")}, report.Skipped)
+	assert.Contains(t, report.Diffs[filepath.Join(dir, "overwritten.txt")], "-two\n+three\n")
+
+	var buffer bytes.Buffer
+
+	require.NoError(t, report.Write(&buffer))
+	assert.Contains(t, buffer.String(), "created files (1):\n")
+	assert.Contains(t, buffer.String(), "+new\n")
+}
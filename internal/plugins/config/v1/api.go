@@ -19,6 +19,7 @@ import (
 
 type createAPISubcommand struct {
 	workloadConfigPath string
+	configVars         []string
 	workload           kinds.WorkloadBuilder
 	enableOlm          bool
 }
@@ -26,11 +27,23 @@ type createAPISubcommand struct {
 var _ plugin.CreateAPISubcommand = &createAPISubcommand{}
 
 func (p *createAPISubcommand) BindFlags(fs *pflag.FlagSet) {
-	workload.AddFlags(fs, &p.workloadConfigPath, &p.enableOlm)
+	workload.AddFlags(fs, &p.workloadConfigPath, &p.configVars, &p.enableOlm)
 }
 
 func (p *createAPISubcommand) InjectConfig(c config.Config) error {
-	processor, err := workloadconfig.Parse(p.workloadConfigPath)
+	// variables which were given to a previous command are used when none are given.  the
+	// project config does not contain the plugin config prior to the first command.
+	var storedConfig workloadconfig.Plugin
+	if err := c.DecodePluginConfig(workloadconfig.PluginKey, &storedConfig); err != nil {
+		storedConfig = workloadconfig.Plugin{}
+	}
+
+	variables, err := workload.ParseConfigVars(p.configVars, storedConfig.ConfigVars)
+	if err != nil {
+		return fmt.Errorf("unable to inject config into %s, %w", p.workloadConfigPath, err)
+	}
+
+	processor, err := workloadconfig.Parse(p.workloadConfigPath, variables)
 	if err != nil {
 		return fmt.Errorf("unable to inject config into %s, %w", p.workloadConfigPath, err)
 	}
@@ -40,6 +53,7 @@ func (p *createAPISubcommand) InjectConfig(c config.Config) error {
 	pluginConfig := workloadconfig.Plugin{
 		WorkloadConfigPath: p.workloadConfigPath,
 		CliRootCommandName: processor.Workload.GetRootCommand().Name,
+		ConfigVars:         variables,
 	}
 
 	if err := c.EncodePluginConfig(workloadconfig.PluginKey, pluginConfig); err != nil {
@@ -17,6 +17,7 @@ import (
 
 type initSubcommand struct {
 	workloadConfigPath string
+	configVars         []string
 	controllerImage    string
 	enableOlm          bool
 }
@@ -24,13 +25,18 @@ type initSubcommand struct {
 var _ plugin.InitSubcommand = &initSubcommand{}
 
 func (p *initSubcommand) BindFlags(fs *pflag.FlagSet) {
-	workload.AddFlags(fs, &p.workloadConfigPath, &p.enableOlm)
+	workload.AddFlags(fs, &p.workloadConfigPath, &p.configVars, &p.enableOlm)
 
 	fs.StringVar(&p.controllerImage, "controller-image", "controller:latest", "controller image")
 }
 
 func (p *initSubcommand) InjectConfig(c config.Config) error {
-	processor, err := workloadconfig.Parse(p.workloadConfigPath)
+	variables, err := workload.ParseConfigVars(p.configVars, nil)
+	if err != nil {
+		return fmt.Errorf("unable to inject config into %s, %w", p.workloadConfigPath, err)
+	}
+
+	processor, err := workloadconfig.Parse(p.workloadConfigPath, variables)
 	if err != nil {
 		return fmt.Errorf("unable to inject config into %s, %w", p.workloadConfigPath, err)
 	}
@@ -40,6 +46,7 @@ func (p *initSubcommand) InjectConfig(c config.Config) error {
 		CliRootCommandName: processor.Workload.GetRootCommand().Name,
 		ControllerImg:      p.controllerImage,
 		EnableOLM:          p.enableOlm,
+		ConfigVars:         variables,
 	}
 
 	if err := c.EncodePluginConfig(workloadconfig.PluginKey, pluginConfig); err != nil {
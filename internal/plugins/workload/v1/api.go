@@ -24,6 +24,7 @@ type createAPISubcommand struct {
 	resource *resource.Resource
 
 	workloadConfigPath string
+	configVars         workloadconfig.Variables
 	cliRootCommandName string
 	workload           kinds.WorkloadBuilder
 	enableOlm          bool
@@ -50,6 +51,7 @@ func (p *createAPISubcommand) InjectConfig(c config.Config) error {
 	}
 
 	p.workloadConfigPath = pluginConfig.WorkloadConfigPath
+	p.configVars = pluginConfig.ConfigVars
 	p.cliRootCommandName = pluginConfig.CliRootCommandName
 	p.enableOlm = pluginConfig.EnableOLM
 
@@ -63,11 +65,37 @@ func (p *createAPISubcommand) InjectResource(res *resource.Resource) error {
 }
 
 func (p *createAPISubcommand) PreScaffold(machinery.Filesystem) error {
-	processor, err := workloadconfig.Parse(p.workloadConfigPath)
+	processor, err := workloadconfig.Parse(p.workloadConfigPath, p.configVars)
 	if err != nil {
 		return fmt.Errorf("%s for %s, %w", ErrScaffoldCreateAPI.Error(), p.workloadConfigPath, err)
 	}
 
+	if len(processor.Peers) > 0 {
+		return fmt.Errorf("%s for %s, %w", ErrScaffoldCreateAPI.Error(), p.workloadConfigPath, ErrMultipleWorkloads)
+	}
+
+	for _, builder := range processor.GetWorkloads() {
+		if len(builder.GetExternalDependencies()) > 0 {
+			return fmt.Errorf("%s for %s, %w", ErrScaffoldCreateAPI.Error(), p.workloadConfigPath, ErrExternalDependencies)
+		}
+
+		if builder.GetControllerOptions().IsSet() {
+			return fmt.Errorf("%s for %s, %w", ErrScaffoldCreateAPI.Error(), p.workloadConfigPath, ErrControllerOptions)
+		}
+
+		if len(builder.GetPhases()) > 0 {
+			return fmt.Errorf("%s for %s, %w", ErrScaffoldCreateAPI.Error(), p.workloadConfigPath, ErrCustomPhases)
+		}
+
+		if builder.GetCommonMetadata().IsSet() {
+			return fmt.Errorf("%s for %s, %w", ErrScaffoldCreateAPI.Error(), p.workloadConfigPath, ErrCommonMetadata)
+		}
+
+		if builder.GetRBACOptions().IsNamespaced() {
+			return fmt.Errorf("%s for %s, %w", ErrScaffoldCreateAPI.Error(), p.workloadConfigPath, ErrNamespaceScopedRBAC)
+		}
+	}
+
 	if err := subcommand.CreateAPI(processor); err != nil {
 		return fmt.Errorf("%s for %s, %w", ErrScaffoldCreateAPI.Error(), p.workloadConfigPath, err)
 	}
@@ -11,6 +11,7 @@ import (
 	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
 	"sigs.k8s.io/kubebuilder/v3/pkg/plugin"
 
+	"github.com/nukleros/operator-builder/internal/plugins/workload"
 	"github.com/nukleros/operator-builder/internal/plugins/workload/v1/scaffolds"
 	"github.com/nukleros/operator-builder/internal/workload/v1/commands/subcommand"
 	workloadconfig "github.com/nukleros/operator-builder/internal/workload/v1/config"
@@ -21,6 +22,7 @@ type initSubcommand struct {
 	config config.Config
 
 	workloadConfigPath string
+	configVars         workloadconfig.Variables
 	cliRootCommandName string
 	controllerImg      string
 	enableOlm          bool
@@ -28,7 +30,21 @@ type initSubcommand struct {
 	workload kinds.WorkloadBuilder
 }
 
-var ErrScaffoldInit = errors.New("unable to scaffold initial config")
+var (
+	ErrScaffoldInit      = errors.New("unable to scaffold initial config")
+	ErrMultipleWorkloads = errors.New("multiple standalone or collection workloads require plugin version " +
+		workload.EnvPluginVersionV2)
+	ErrExternalDependencies = errors.New("external dependencies require plugin version " +
+		workload.EnvPluginVersionV2)
+	ErrControllerOptions = errors.New("controller options require plugin version " +
+		workload.EnvPluginVersionV2)
+	ErrCustomPhases = errors.New("custom phases require plugin version " +
+		workload.EnvPluginVersionV2)
+	ErrCommonMetadata = errors.New("common labels, annotations and name prefixes require plugin version " +
+		workload.EnvPluginVersionV2)
+	ErrNamespaceScopedRBAC = errors.New("namespace scoped rbac requires plugin version " +
+		workload.EnvPluginVersionV2)
+)
 
 var _ plugin.InitSubcommand = &initSubcommand{}
 
@@ -54,6 +70,7 @@ func (p *initSubcommand) InjectConfig(c config.Config) error {
 	}
 
 	p.workloadConfigPath = pluginConfig.WorkloadConfigPath
+	p.configVars = pluginConfig.ConfigVars
 	p.cliRootCommandName = pluginConfig.CliRootCommandName
 	p.controllerImg = pluginConfig.ControllerImg
 	p.enableOlm = pluginConfig.EnableOLM
@@ -62,11 +79,15 @@ func (p *initSubcommand) InjectConfig(c config.Config) error {
 }
 
 func (p *initSubcommand) PreScaffold(machinery.Filesystem) error {
-	processor, err := workloadconfig.Parse(p.workloadConfigPath)
+	processor, err := workloadconfig.Parse(p.workloadConfigPath, p.configVars)
 	if err != nil {
 		return fmt.Errorf("%s for %s, %w", ErrScaffoldInit.Error(), p.workloadConfigPath, err)
 	}
 
+	if len(processor.Peers) > 0 {
+		return fmt.Errorf("%s for %s, %w", ErrScaffoldInit.Error(), p.workloadConfigPath, ErrMultipleWorkloads)
+	}
+
 	if err := subcommand.Init(processor); err != nil {
 		return fmt.Errorf("%s for %s, %w", ErrScaffoldInit.Error(), p.workloadConfigPath, err)
 	}
@@ -210,6 +210,15 @@ func (s *apiScaffolder) scaffoldWorkload(
 		}
 	}
 
+	// scaffold the collections which are nested within a collection along with their own components.
+	if collection, ok := workload.(*kinds.WorkloadCollection); ok {
+		for _, nested := range collection.Spec.Collections {
+			if err := s.scaffoldWorkload(scaffold, nested); err != nil {
+				return fmt.Errorf("%w; %s for workload type %T", err, ErrScaffoldWorkload.Error(), nested)
+			}
+		}
+	}
+
 	return nil
 }
 
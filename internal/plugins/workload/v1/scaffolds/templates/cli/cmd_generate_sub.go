@@ -64,8 +64,9 @@ func (f *CmdGenerateSub) SetTemplateDefaults() error {
 		f.UseCollectionManifestFlag = true
 	}
 
-	// use the workload manifest flag for non-collection use cases
-	if !f.Builder.IsCollection() {
+	// use the workload manifest flag for non-collection use cases and for nested collections,
+	// which are managed as components of their parent collection
+	if !f.Builder.IsCollection() || f.Builder.IsComponent() {
 		f.UseWorkloadManifestFlag = true
 	}
 
@@ -169,8 +170,9 @@ func (f *CmdGenerateSubUpdater) GetCodeFragments() machinery.CodeFragmentsMap {
 		f.UseCollectionManifestFlag = true
 	}
 
-	// use the workload manifest flag for non-collection use cases
-	if !f.Builder.IsCollection() {
+	// use the workload manifest flag for non-collection use cases and for nested collections,
+	// which are managed as components of their parent collection
+	if !f.Builder.IsCollection() || f.Builder.IsComponent() {
 		f.UseWorkloadManifestFlag = true
 	}
 
@@ -237,10 +239,10 @@ func New{{ .Resource.Kind }}SubCommand(parentCommand *cobra.Command) {
 		GenerateFunc:          Generate{{ .Resource.Kind }},
 		{{- if .UseCollectionManifestFlag }}
 		UseCollectionManifest: true,
-		{{- if .Builder.IsCollection }}
-		CollectionKind:        "{{ .Resource.Kind }}",
-		{{- else }}
+		{{- if .Builder.IsComponent }}
 		CollectionKind:        "{{ .Collection.Spec.API.Kind }}",
+		{{- else }}
+		CollectionKind:        "{{ .Resource.Kind }}",
 		{{ end -}}
 		{{ end -}}
 		{{ if .UseWorkloadManifestFlag -}}
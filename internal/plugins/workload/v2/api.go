@@ -26,6 +26,7 @@ import (
 	"github.com/nukleros/operator-builder/internal/workload/v1/commands/subcommand"
 	workloadconfig "github.com/nukleros/operator-builder/internal/workload/v1/config"
 	"github.com/nukleros/operator-builder/internal/workload/v1/kinds"
+	"github.com/nukleros/operator-builder/internal/workload/v1/lock"
 )
 
 type createAPISubcommand struct {
@@ -42,9 +43,14 @@ type createAPISubcommand struct {
 	controllerFlag *pflag.Flag
 
 	workloadConfigPath string
+	configVars         []string
+	variables          workloadconfig.Variables
 	cliRootCommandName string
 	workload           kinds.WorkloadBuilder
+	workloads          []kinds.WorkloadBuilder
 	enableOlm          bool
+	dryRun             bool
+	lock               *lock.Lock
 }
 
 var (
@@ -64,7 +70,8 @@ func (p *createAPISubcommand) UpdateMetadata(cliMeta plugin.CLIMetadata, subcmdM
 }
 
 func (p *createAPISubcommand) BindFlags(fs *pflag.FlagSet) {
-	workload.AddFlags(fs, &p.workloadConfigPath, &p.enableOlm)
+	workload.AddFlags(fs, &p.workloadConfigPath, &p.configVars, &p.enableOlm)
+	workload.AddDryRunFlag(fs, &p.dryRun)
 
 	fs.BoolVar(&p.generateDeepCopy, "generate-deep-copy", true,
 		"if true, generate deep copy methods after scaffolding (equivalent of 'make generate')")
@@ -86,7 +93,21 @@ func (p *createAPISubcommand) BindFlags(fs *pflag.FlagSet) {
 }
 
 func (p *createAPISubcommand) InjectConfig(c config.Config) error {
-	processor, err := workloadconfig.Parse(p.workloadConfigPath)
+	// variables which were given to a previous command are used when none are given.  the
+	// project config does not contain the plugin config prior to the first command.
+	var storedConfig workloadconfig.Plugin
+	if err := c.DecodePluginConfig(workloadconfig.PluginKey, &storedConfig); err != nil {
+		storedConfig = workloadconfig.Plugin{}
+	}
+
+	variables, err := workload.ParseConfigVars(p.configVars, storedConfig.ConfigVars)
+	if err != nil {
+		return fmt.Errorf("unable to inject config into %s, %w", p.workloadConfigPath, err)
+	}
+
+	p.variables = variables
+
+	processor, err := workloadconfig.Parse(p.workloadConfigPath, p.variables)
 	if err != nil {
 		return fmt.Errorf("unable to inject config into %s, %w", p.workloadConfigPath, err)
 	}
@@ -98,6 +119,7 @@ func (p *createAPISubcommand) InjectConfig(c config.Config) error {
 		WorkloadConfigPath: p.workloadConfigPath,
 		CliRootCommandName: p.cliRootCommandName,
 		EnableOLM:          p.enableOlm,
+		ConfigVars:         p.variables,
 	}
 
 	if err := c.EncodePluginConfig(workloadconfig.PluginKey, pluginConfig); err != nil {
@@ -153,7 +175,7 @@ func (p *createAPISubcommand) InjectResource(res *resource.Resource) error {
 }
 
 func (p *createAPISubcommand) PreScaffold(machinery.Filesystem) error {
-	processor, err := workloadconfig.Parse(p.workloadConfigPath)
+	processor, err := workloadconfig.Parse(p.workloadConfigPath, p.variables)
 	if err != nil {
 		return fmt.Errorf("%s for %s, %w", ErrScaffoldCreateAPI.Error(), p.workloadConfigPath, err)
 	}
@@ -168,6 +190,12 @@ func (p *createAPISubcommand) PreScaffold(machinery.Filesystem) error {
 	}
 
 	p.workload = processor.Workload
+	p.workloads = processor.GetTopLevelWorkloads()
+
+	p.lock, err = lock.New(processor)
+	if err != nil {
+		return fmt.Errorf("%s for %s, %w", ErrScaffoldCreateAPI.Error(), p.workloadConfigPath, err)
+	}
 
 	return nil
 }
@@ -176,7 +204,7 @@ func (p *createAPISubcommand) Scaffold(fs machinery.Filesystem) error {
 	scaffolder := scaffolds.NewAPIScaffolder(
 		p.config,
 		p.resource,
-		p.workload,
+		p.workloads,
 		p.cliRootCommandName,
 		p.enableOlm,
 	)
@@ -186,10 +214,23 @@ func (p *createAPISubcommand) Scaffold(fs machinery.Filesystem) error {
 		return fmt.Errorf("%s for %s, %w", ErrScaffoldInit.Error(), p.workloadConfigPath, err)
 	}
 
+	// record the inputs from which the code was generated so that stale code may be detected
+	if err := p.lock.Write(fs.FS); err != nil {
+		return fmt.Errorf("%s for %s, %w", ErrScaffoldCreateAPI.Error(), p.workloadConfigPath, err)
+	}
+
 	return nil
 }
 
 func (p *createAPISubcommand) PostScaffold() error {
+	// the scaffolded files only exist in memory during a dry run, so there is nothing on disk
+	// for the dependencies or generated code to be updated from.
+	if p.dryRun {
+		log.Println("Skipping dependency updates and code generation for dry run...")
+
+		return nil
+	}
+
 	err := util.RunCmd("Update dependencies", "go", "mod", "tidy")
 	if err != nil {
 		return err
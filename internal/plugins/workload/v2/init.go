@@ -47,9 +47,12 @@ type initSubcommand struct {
 	skipGoVersionCheck bool
 
 	workloadConfigPath string
+	configVars         []string
+	variables          workloadconfig.Variables
 	cliRootCommandName string
 	controllerImage    string
 	enableOlm          bool
+	dryRun             bool
 
 	workload kinds.WorkloadBuilder
 }
@@ -67,7 +70,8 @@ func (p *initSubcommand) UpdateMetadata(cliMeta plugin.CLIMetadata, subcmdMeta *
 }
 
 func (p *initSubcommand) BindFlags(fs *pflag.FlagSet) {
-	workload.AddFlags(fs, &p.workloadConfigPath, &p.enableOlm)
+	workload.AddFlags(fs, &p.workloadConfigPath, &p.configVars, &p.enableOlm)
+	workload.AddDryRunFlag(fs, &p.dryRun)
 	fs.StringVar(&p.controllerImage, "controller-image", "controller:latest", "controller image")
 
 	fs.BoolVar(&p.skipGoVersionCheck, "skip-go-version-check",
@@ -87,7 +91,14 @@ func (p *initSubcommand) BindFlags(fs *pflag.FlagSet) {
 }
 
 func (p *initSubcommand) InjectConfig(c config.Config) error {
-	processor, err := workloadconfig.Parse(p.workloadConfigPath)
+	variables, err := workload.ParseConfigVars(p.configVars, nil)
+	if err != nil {
+		return fmt.Errorf("unable to inject config into %s, %w", p.workloadConfigPath, err)
+	}
+
+	p.variables = variables
+
+	processor, err := workloadconfig.Parse(p.workloadConfigPath, p.variables)
 	if err != nil {
 		return fmt.Errorf("unable to inject config into %s, %w", p.workloadConfigPath, err)
 	}
@@ -104,6 +115,7 @@ func (p *initSubcommand) InjectConfig(c config.Config) error {
 		CliRootCommandName: processor.Workload.GetRootCommand().Name,
 		ControllerImg:      p.controllerImage,
 		EnableOLM:          p.enableOlm,
+		ConfigVars:         p.variables,
 	}
 
 	if err := c.EncodePluginConfig(workloadconfig.PluginKey, pluginConfig); err != nil {
@@ -129,7 +141,7 @@ func (p *initSubcommand) InjectConfig(c config.Config) error {
 }
 
 func (p *initSubcommand) PreScaffold(machinery.Filesystem) error {
-	processor, err := workloadconfig.Parse(p.workloadConfigPath)
+	processor, err := workloadconfig.Parse(p.workloadConfigPath, p.variables)
 	if err != nil {
 		return fmt.Errorf("%s for %s, %w", ErrScaffoldInit.Error(), p.workloadConfigPath, err)
 	}
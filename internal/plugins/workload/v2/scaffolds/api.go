@@ -15,16 +15,20 @@ import (
 	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins"
 
+	"github.com/nukleros/operator-builder/internal/baseline"
 	"github.com/nukleros/operator-builder/internal/plugins/workload/v2/scaffolds/templates"
 	"github.com/nukleros/operator-builder/internal/plugins/workload/v2/scaffolds/templates/api"
 	"github.com/nukleros/operator-builder/internal/plugins/workload/v2/scaffolds/templates/api/resources"
 	"github.com/nukleros/operator-builder/internal/plugins/workload/v2/scaffolds/templates/cli"
 	"github.com/nukleros/operator-builder/internal/plugins/workload/v2/scaffolds/templates/config/crd"
+	"github.com/nukleros/operator-builder/internal/plugins/workload/v2/scaffolds/templates/config/rbac"
 	"github.com/nukleros/operator-builder/internal/plugins/workload/v2/scaffolds/templates/config/samples"
 	"github.com/nukleros/operator-builder/internal/plugins/workload/v2/scaffolds/templates/controller"
 	"github.com/nukleros/operator-builder/internal/plugins/workload/v2/scaffolds/templates/int/dependencies"
+	"github.com/nukleros/operator-builder/internal/plugins/workload/v2/scaffolds/templates/int/metadata"
 	"github.com/nukleros/operator-builder/internal/plugins/workload/v2/scaffolds/templates/int/mutate"
 	"github.com/nukleros/operator-builder/internal/plugins/workload/v2/scaffolds/templates/test/e2e"
+	"github.com/nukleros/operator-builder/internal/plugins/workload/v2/scaffolds/templates/webhooks"
 	"github.com/nukleros/operator-builder/internal/workload/v1/kinds"
 )
 
@@ -43,6 +47,8 @@ var (
 	ErrScaffoldAPIChildResources    = errors.New("error scaffolding api child resource definitions")
 	ErrScaffoldController           = errors.New("error scaffolding controller logic")
 	ErrScaffoldE2ETest              = errors.New("error scaffolding e2e tests")
+	ErrScaffoldWebhookMarkers       = errors.New("error scaffolding webhook markers")
+	ErrScaffoldRBAC                 = errors.New("error scaffolding rbac manifests")
 	ErrScaffoldCompanionCLI         = errors.New("error scaffolding companion CLI")
 	ErrScaffoldCompanionCLIInit     = errors.New("error scaffolding companion CLI init sub-command")
 	ErrScaffoldCompanionCLIGenerate = errors.New("error scaffolding companion CLI generate sub-command")
@@ -51,28 +57,31 @@ var (
 )
 
 type apiScaffolder struct {
-	fs machinery.Filesystem
+	fs       machinery.Filesystem
+	baseline *baseline.Filesystem
 
 	config             config.Config
 	resource           *resource.Resource
 	boilerplate        string
-	workload           kinds.WorkloadBuilder
+	workloads          []kinds.WorkloadBuilder
 	cliRootCommandName string
 	enableOlm          bool
 }
 
-// NewAPIScaffolder returns a new Scaffolder for project initialization operations.
+// NewAPIScaffolder returns a new Scaffolder for project initialization operations.  The workloads are
+// each of the top-level workloads within the workload config, the first of which is the workload for
+// the requested resource.
 func NewAPIScaffolder(
 	cfg config.Config,
 	res *resource.Resource,
-	workload kinds.WorkloadBuilder,
+	workloads []kinds.WorkloadBuilder,
 	cliRootCommandName string,
 	enableOlm bool,
 ) plugins.Scaffolder {
 	return &apiScaffolder{
 		config:             cfg,
 		resource:           res,
-		workload:           workload,
+		workloads:          workloads,
 		cliRootCommandName: cliRootCommandName,
 		enableOlm:          enableOlm,
 	}
@@ -80,7 +89,7 @@ func NewAPIScaffolder(
 
 // InjectFS implements cmdutil.Scaffolder.
 func (s *apiScaffolder) InjectFS(fs machinery.Filesystem) {
-	s.fs = fs
+	s.fs, s.baseline = withBaseline(fs)
 }
 
 // scaffold implements cmdutil.Scaffolder.
@@ -100,12 +109,14 @@ func (s *apiScaffolder) Scaffold() error {
 		machinery.WithResource(s.resource),
 	)
 
-	// scaffold the workload
-	if err := s.scaffoldWorkload(scaffold, s.workload); err != nil {
-		return fmt.Errorf("%w; %s for workload type %T", err, ErrScaffoldWorkload.Error(), s.workload)
+	// scaffold each of the workloads
+	for _, workload := range s.workloads {
+		if err := s.scaffoldWorkload(scaffold, workload, workload.GetDomain()); err != nil {
+			return fmt.Errorf("%w; %s for workload type %T", err, ErrScaffoldWorkload.Error(), workload)
+		}
 	}
 
-	return nil
+	return checkBaseline(s.baseline)
 }
 
 // scaffoldWorkload performs the execution of the scaffold for an individual workload.
@@ -114,9 +125,10 @@ func (s *apiScaffolder) Scaffold() error {
 func (s *apiScaffolder) scaffoldWorkload(
 	scaffold *machinery.Scaffold,
 	workload kinds.WorkloadBuilder,
+	domain string,
 ) error {
 	componentResource := workload.GetComponentResource(
-		s.config.GetDomain(),
+		domain,
 		s.config.GetRepository(),
 		workload.IsClusterScoped(),
 	)
@@ -131,11 +143,10 @@ func (s *apiScaffolder) scaffoldWorkload(
 		Webhooks:   componentResource.Webhooks,
 	}
 
-	// override the scaffold if we have a component.  this will allow the Resource
-	// attribute of the scaffolder to be set appropriately so that things like Group,
-	// Version, and Kind are passed from the child component and not the parent
-	// workload.
-	if workload.IsComponent() {
+	// override the scaffold if we have a component or an additional top-level workload.  this
+	// will allow the Resource attribute of the scaffolder to be set appropriately so that things
+	// like Group, Version, and Kind are passed from the workload and not the requested resource.
+	if !v4ComponentResource.IsEqualTo(s.resource.GVK) {
 		scaffold = machinery.NewScaffold(s.fs,
 			machinery.WithConfig(s.config),
 			machinery.WithBoilerplate(s.boilerplate),
@@ -165,22 +176,39 @@ func (s *apiScaffolder) scaffoldWorkload(
 	if doController {
 		if err := scaffold.Execute(
 			&controller.Controller{Builder: workload},
-			&controller.Phases{PackageName: workload.GetPackageName()},
+			&controller.Phases{Builder: workload, FS: s.fs.FS},
 			&controller.SuiteTest{},
-			&dependencies.Component{},
-			&mutate.Component{},
+			&dependencies.Component{FS: s.fs.FS},
+			&dependencies.External{},
+			&mutate.Component{FS: s.fs.FS},
 			&crd.Kustomization{},
 		); err != nil {
 			return fmt.Errorf("%w; %s", err, ErrScaffoldController.Error())
 		}
+
+		// scaffold the custom phases.  these files are owned by the user once they are
+		// generated and are not overwritten.
+		for _, phase := range workload.GetPhases() {
+			if err := scaffold.Execute(&controller.CustomPhase{Phase: phase, FS: s.fs.FS}); err != nil {
+				return fmt.Errorf("%w; %s", err, ErrScaffoldController.Error())
+			}
+		}
+	}
+
+	// regenerate the logic of the webhook markers if the defaulting or validating webhook has already
+	// been created for the api.  the webhook file itself is owned by the user and is not changed.
+	if err := s.scaffoldWebhookMarkers(scaffold, workload, v4ComponentResource.GVK); err != nil {
+		return fmt.Errorf("%w; %s", err, ErrScaffoldWebhookMarkers.Error())
 	}
 
 	// update controller main entrypoint.  this updates the main.go file with logic related to
-	// creating the new controllers.
+	// creating the new controllers, and restricts the cache of the manager to its namespace for
+	// a workload with namespace scoped rbac.
 	if err := scaffold.Execute(
 		&templates.MainUpdater{
-			WireResource:   doAPI,
-			WireController: doController,
+			WireResource:        doAPI,
+			WireController:      doController,
+			WireNamespacedCache: doController && workload.GetRBACOptions().IsNamespaced(),
 		},
 	); err != nil {
 		return fmt.Errorf("%w; %s", err, ErrScaffoldMainUpdater.Error())
@@ -203,7 +231,7 @@ func (s *apiScaffolder) scaffoldWorkload(
 
 	// scaffold the end-to-end tests.  this will generate some common end-to-end tests for
 	// the controller.
-	if err := scaffold.Execute(&e2e.WorkloadTest{Builder: workload}); err != nil {
+	if err := scaffold.Execute(&e2e.WorkloadTest{Builder: workload, FS: s.fs.FS}); err != nil {
 		return fmt.Errorf("%w; %s - error updating test/e2e/%s_%s_%s_test.go", err, ErrScaffoldController.Error(),
 			workload.GetAPIGroup(), workload.GetAPIVersion(), strings.ToLower(workload.GetAPIKind()))
 	}
@@ -219,15 +247,43 @@ func (s *apiScaffolder) scaffoldWorkload(
 	// logic for a companion CLI.
 	if workload.IsCollection() {
 		for _, component := range workload.GetComponents() {
-			if err := s.scaffoldWorkload(scaffold, component); err != nil {
+			if err := s.scaffoldWorkload(scaffold, component, domain); err != nil {
 				return fmt.Errorf("%w; %s for workload type %T", err, ErrScaffoldWorkload.Error(), component)
 			}
 		}
 	}
 
+	// scaffold the collections which are nested within a collection along with their own components.
+	if collection, ok := workload.(*kinds.WorkloadCollection); ok {
+		for _, nested := range collection.Spec.Collections {
+			if err := s.scaffoldWorkload(scaffold, nested, domain); err != nil {
+				return fmt.Errorf("%w; %s for workload type %T", err, ErrScaffoldWorkload.Error(), nested)
+			}
+		}
+	}
+
 	return nil
 }
 
+// scaffoldWebhookMarkers regenerates the logic of the webhook markers of a workload whose api has a
+// defaulting or validating webhook.
+func (s *apiScaffolder) scaffoldWebhookMarkers(
+	scaffold *machinery.Scaffold,
+	workload kinds.WorkloadBuilder,
+	gvk resource.GVK,
+) error {
+	if workload.GetWebhook().IsEmpty() {
+		return nil
+	}
+
+	existing, err := s.config.GetResource(gvk)
+	if err != nil || existing.Webhooks == nil || !(existing.Webhooks.Defaulting || existing.Webhooks.Validation) {
+		return nil
+	}
+
+	return scaffold.Execute(&webhooks.WebhookMarkers{Markers: workload.GetWebhook()})
+}
+
 // scaffoldAPI runs the specific logic to scaffold anything existing in the apis directory.
 func (s *apiScaffolder) scaffoldAPI(
 	scaffold *machinery.Scaffold,
@@ -255,6 +311,41 @@ func (s *apiScaffolder) scaffoldAPI(
 		return fmt.Errorf("%w; %s", err, ErrScaffoldAPIResources.Error())
 	}
 
+	// scaffold the logic which applies the common metadata to the child resources
+	if workload.GetCommonMetadata().IsSet() {
+		if err := scaffold.Execute(&metadata.Common{}); err != nil {
+			return fmt.Errorf("%w; %s", err, ErrScaffoldAPIResources.Error())
+		}
+	}
+
+	// scaffold the cluster roles which allow users to view and edit the custom resources of the
+	// workload
+	if err := scaffold.Execute(
+		&rbac.ViewerRole{},
+		&rbac.EditorRole{},
+		&rbac.KustomizationUpdater{
+			Resources: []string{rbac.EditorRoleFile, rbac.ViewerRoleFile},
+			FS:        s.fs.FS,
+		},
+	); err != nil {
+		return fmt.Errorf("%w; %s", err, ErrScaffoldRBAC.Error())
+	}
+
+	// scaffold the binding of the namespace scoped role which is generated from the namespaced
+	// rbac markers
+	if workload.GetRBACOptions().IsNamespaced() {
+		if err := scaffold.Execute(
+			&rbac.RoleBinding{FS: s.fs.FS},
+			&rbac.KustomizationUpdater{
+				Resources: []string{rbac.NamespaceRoleBindingFile},
+				After:     "role_binding.yaml",
+				FS:        s.fs.FS,
+			},
+		); err != nil {
+			return fmt.Errorf("%w; %s", err, ErrScaffoldRBAC.Error())
+		}
+	}
+
 	// scaffolds the child resource definition files
 	// these are the resources defined in the static yaml manifests
 	for _, manifest := range *workload.GetManifests() {
@@ -267,7 +358,7 @@ func (s *apiScaffolder) scaffoldAPI(
 		// update the child resource mutation for each child resource
 		for i := range manifest.ChildResources {
 			if err := scaffold.Execute(
-				&resources.Mutate{Builder: workload, ChildResource: manifest.ChildResources[i]},
+				&resources.Mutate{Builder: workload, ChildResource: manifest.ChildResources[i], FS: s.fs.FS},
 			); err != nil {
 				return fmt.Errorf("%w; %s", err, ErrScaffoldAPIChildResources.Error())
 			}
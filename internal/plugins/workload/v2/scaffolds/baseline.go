@@ -0,0 +1,39 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package scaffolds
+
+import (
+	"errors"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+
+	"github.com/nukleros/operator-builder/internal/baseline"
+)
+
+var ErrScaffoldBaseline = errors.New("error merging generated files with their baseline")
+
+// withBaseline returns a filesystem which merges the edits that have been made to the generated
+// files with their newly generated content, along with the underlying baseline filesystem.
+func withBaseline(fs machinery.Filesystem) (machinery.Filesystem, *baseline.Filesystem) {
+	baselineFS := baseline.NewFilesystem(fs.FS)
+
+	return machinery.Filesystem{FS: baselineFS}, baselineFS
+}
+
+// checkBaseline returns an error if any of the generated files could not be written and warns of
+// each of the generated files which were written with conflict markers.
+func checkBaseline(fs *baseline.Filesystem) error {
+	if err := fs.Err(); err != nil {
+		return fmt.Errorf("%w; %s", err, ErrScaffoldBaseline.Error())
+	}
+
+	for _, name := range fs.Conflicts() {
+		log.Warnf("file %s contains conflicts between its edits and its newly generated content, "+
+			"which must be resolved by removing the conflict markers", name)
+	}
+
+	return nil
+}
@@ -12,6 +12,7 @@ import (
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
 	"sigs.k8s.io/kubebuilder/v4/pkg/plugins"
 
+	"github.com/nukleros/operator-builder/internal/baseline"
 	"github.com/nukleros/operator-builder/internal/plugins/workload/v2/scaffolds/templates"
 	"github.com/nukleros/operator-builder/internal/plugins/workload/v2/scaffolds/templates/cli"
 	"github.com/nukleros/operator-builder/internal/plugins/workload/v2/scaffolds/templates/config/manifests"
@@ -34,7 +35,8 @@ type initScaffolder struct {
 	license            string
 	owner              string
 
-	fs machinery.Filesystem
+	fs       machinery.Filesystem
+	baseline *baseline.Filesystem
 }
 
 // NewInitScaffolder returns a new Scaffolder for project initialization operations.
@@ -61,7 +63,7 @@ func NewInitScaffolder(
 
 // InjectFS implements cmdutil.Scaffolder.
 func (s *initScaffolder) InjectFS(fs machinery.Filesystem) {
-	s.fs = fs
+	s.fs, s.baseline = withBaseline(fs)
 }
 
 // scaffold implements cmdutil.Scaffolder.
@@ -105,7 +107,7 @@ func (s *initScaffolder) Scaffold() error {
 
 	if s.workload.HasRootCmdName() {
 		if err := scaffold.Execute(
-			&cli.Main{RootCmd: *s.workload.GetRootCommand()},
+			&cli.Main{RootCmd: *s.workload.GetRootCommand(), FS: s.fs.FS},
 			&cli.CmdRoot{Initializer: s.workload},
 			&cli.CmdInit{Initializer: s.workload},
 			&cli.CmdGenerate{Initializer: s.workload},
@@ -138,7 +140,7 @@ func (s *initScaffolder) Scaffold() error {
 			EnvtestK8SVersion:        utils.EnvtestK8SVersion,
 			GolangCILintVersion:      utils.GolangCILintVersion,
 		},
-		&templates.Golangci{},
+		&templates.Golangci{FS: s.fs.FS},
 		&e2e.Test{},
 	); err != nil {
 		return fmt.Errorf("unable to scaffold initial configuration, %w", err)
@@ -156,6 +158,7 @@ func (s *initScaffolder) Scaffold() error {
 
 		if err := scaffold.Execute(
 			&manifests.Kustomization{
+				FS:                  s.fs.FS,
 				SupportsKustomizeV4: false,
 				SupportsWebhooks:    false,
 			},
@@ -164,5 +167,5 @@ func (s *initScaffolder) Scaffold() error {
 		}
 	}
 
-	return nil
+	return checkBaseline(s.baseline)
 }
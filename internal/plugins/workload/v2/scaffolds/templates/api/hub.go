@@ -25,7 +25,10 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/spf13/afero"
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+
+	"github.com/nukleros/operator-builder/internal/baseline"
 )
 
 var _ machinery.Template = &Hub{}
@@ -37,6 +40,8 @@ type Hub struct {
 	machinery.BoilerplateMixin
 	machinery.ResourceMixin
 
+	FS afero.Fs
+
 	Force bool
 }
 
@@ -57,7 +62,7 @@ func (f *Hub) SetTemplateDefaults() error {
 	if f.Force {
 		f.IfExistsAction = machinery.OverwriteFile
 	} else {
-		f.IfExistsAction = machinery.SkipFile
+		f.IfExistsAction = baseline.IfExistsAction(f.FS, f.Path)
 	}
 
 	return nil
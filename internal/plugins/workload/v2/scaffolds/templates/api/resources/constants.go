@@ -46,7 +46,7 @@ func (f *Constants) SetTemplateDefaults() error {
 		if child.NameConstant() != "" {
 			f.ConstantStrings = append(
 				f.ConstantStrings,
-				fmt.Sprintf("%s = %q", child.UniqueName, child.NameConstant()),
+				fmt.Sprintf("%s = %q", child.UniqueName, f.Builder.GetCommonMetadata().PrefixName(child.Kind, child.NameConstant())),
 			)
 		}
 	}
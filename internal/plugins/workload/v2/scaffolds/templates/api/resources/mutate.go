@@ -6,8 +6,10 @@ package resources
 import (
 	"path/filepath"
 
+	"github.com/spf13/afero"
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
 
+	"github.com/nukleros/operator-builder/internal/baseline"
 	"github.com/nukleros/operator-builder/internal/workload/v1/kinds"
 	"github.com/nukleros/operator-builder/internal/workload/v1/manifests"
 )
@@ -23,6 +25,8 @@ type Mutate struct {
 	machinery.RepositoryMixin
 	machinery.ResourceMixin
 
+	FS afero.Fs
+
 	// input variables
 	Builder       kinds.WorkloadBuilder
 	ChildResource manifests.ChildResource
@@ -45,8 +49,8 @@ func (f *Mutate) SetTemplateDefaults() error {
 }
 
 // GetIfExistsAction implements file.Builder interface.
-func (*Mutate) GetIfExistsAction() machinery.IfExistsAction {
-	return machinery.SkipFile
+func (f *Mutate) GetIfExistsAction() machinery.IfExistsAction {
+	return baseline.IfExistsAction(f.FS, f.Path)
 }
 
 //nolint:lll
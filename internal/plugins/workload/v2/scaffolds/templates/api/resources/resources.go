@@ -69,6 +69,9 @@ import (
 
 	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
 
+	{{ if .Builder.GetCommonMetadata.IsSet -}}
+	"{{ .Repo }}/internal/metadata"
+	{{ end -}}
 	{{ .Resource.ImportAlias }} "{{ .Resource.Path }}"
 	{{- if .Builder.IsComponent }}
 	{{ .Builder.GetCollection.Spec.API.Group }}{{ .Builder.GetCollection.Spec.API.Version }} "{{ .Repo }}/apis/{{ .Builder.GetCollection.Spec.API.Group }}/{{ .Builder.GetCollection.Spec.API.Version }}"
@@ -122,6 +125,24 @@ func Generate(
 
 		resourceObjects = append(resourceObjects, resources...)
 	}
+	{{- with .Builder.GetCommonMetadata }}
+	{{- if .IsSet }}
+
+	if err := metadata.Apply(resourceObjects, metadata.Common{
+		Labels:      {{ .LabelsCode }},
+		{{- if .LabelsField }}
+		ExtraLabels: {{ if and $.Builder.IsCollection (not $.Builder.IsComponent) }}collectionObj{{ else }}workloadObj{{ end }}.Spec.{{ .LabelsFieldName }},
+		{{- end }}
+		Annotations: {{ .AnnotationsCode }},
+		{{- if .AnnotationsField }}
+		ExtraAnnotations: {{ if and $.Builder.IsCollection (not $.Builder.IsComponent) }}collectionObj{{ else }}workloadObj{{ end }}.Spec.{{ .AnnotationsFieldName }},
+		{{- end }}
+		NamePrefix:  {{ printf "%%q" .NamePrefix }},
+	}); err != nil {
+		return nil, err
+	}
+	{{- end }}
+	{{- end }}
 
 	return resourceObjects, nil
 }
@@ -0,0 +1,106 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
+
+	"github.com/nukleros/operator-builder/internal/workload/v1/kinds"
+	"github.com/nukleros/operator-builder/internal/workload/v1/manifests"
+)
+
+// newTestCollection returns a workload collection with common metadata which is held by fields of
+// its own API spec.
+func newTestCollection(name, kind string) *kinds.WorkloadCollection {
+	collection := &kinds.WorkloadCollection{}
+	collection.Name = name
+	collection.PackageName = name
+	collection.Spec.API = kinds.WorkloadAPISpec{Group: "platform", Version: "v1alpha1", Kind: kind}
+	collection.Spec.APISpecFields = &kinds.APIFields{}
+	collection.Spec.Manifests = &manifests.Manifests{}
+	collection.Spec.CommonLabelsField = "extraLabels"
+	collection.Spec.CommonAnnotationsField = "extraAnnotations"
+
+	// a collection which is not nested is its own collection
+	collection.Spec.Collection = collection
+
+	return collection
+}
+
+// renderResources renders the resources template for a workload.
+func renderResources(t *testing.T, builder kinds.WorkloadBuilder) string {
+	t.Helper()
+
+	f := &Resources{Builder: builder}
+	f.Repo = "github.com/acme/platform"
+	f.Resource = &resource.Resource{
+		GVK: resource.GVK{
+			Domain:  "acme.com",
+			Group:   builder.GetAPIGroup(),
+			Version: builder.GetAPIVersion(),
+			Kind:    builder.GetAPIKind(),
+		},
+		Path: "github.com/acme/platform/apis/platform/v1alpha1",
+	}
+
+	require.NoError(t, f.SetTemplateDefaults())
+
+	tmpl, err := template.New("resources").Funcs(machinery.DefaultFuncMap()).Parse(f.TemplateBody)
+	require.NoError(t, err)
+
+	var output strings.Builder
+	require.NoError(t, tmpl.Execute(&output, f))
+
+	return output.String()
+}
+
+func TestResources_commonMetadataFields(t *testing.T) {
+	t.Parallel()
+
+	nested := newTestCollection("database", "Database")
+	nested.Spec.ParentCollection = newTestCollection("platform", "Platform")
+
+	tests := []struct {
+		name    string
+		builder kinds.WorkloadBuilder
+		want    []string
+	}{
+		{
+			name:    "collection",
+			builder: newTestCollection("platform", "Platform"),
+			want: []string{
+				"ExtraLabels: collectionObj.Spec.ExtraLabels,",
+				"ExtraAnnotations: collectionObj.Spec.ExtraAnnotations,",
+			},
+		},
+		{
+			name:    "nested collection",
+			builder: nested,
+			want: []string{
+				"ExtraLabels: workloadObj.Spec.ExtraLabels,",
+				"ExtraAnnotations: workloadObj.Spec.ExtraAnnotations,",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rendered := renderResources(t, tt.builder)
+
+			for _, want := range tt.want {
+				assert.Contains(t, rendered, want)
+			}
+		})
+	}
+}
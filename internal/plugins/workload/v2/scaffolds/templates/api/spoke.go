@@ -25,7 +25,11 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/spf13/afero"
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+
+	"github.com/nukleros/operator-builder/internal/baseline"
+	"github.com/nukleros/operator-builder/internal/workload/v1/kinds"
 )
 
 var _ machinery.Template = &Spoke{}
@@ -37,8 +41,14 @@ type Spoke struct {
 	machinery.BoilerplateMixin
 	machinery.ResourceMixin
 
+	FS afero.Fs
+
 	Force        bool
 	SpokeVersion string
+
+	// Conversion is the conversion between the API spec fields of the hub and spoke versions.
+	// If it is nil, the conversion of the fields is left to the user.
+	Conversion *kinds.Conversion
 }
 
 // SetTemplateDefaults implements file.Template.
@@ -59,7 +69,7 @@ func (f *Spoke) SetTemplateDefaults() error {
 	if f.Force {
 		f.IfExistsAction = machinery.OverwriteFile
 	} else {
-		f.IfExistsAction = machinery.SkipFile
+		f.IfExistsAction = baseline.IfExistsAction(f.FS, f.Path)
 	}
 
 	return nil
@@ -82,9 +92,24 @@ func (src *{{ .Resource.Kind }}) ConvertTo(dstRaw conversion.Hub) error {
 	log.Printf("ConvertTo: Converting {{ .Resource.Kind }} from Spoke version {{ .SpokeVersion }} to Hub version {{ .Resource.Version }};" +
 		"source: %s/%s, target: %s/%s", src.Namespace, src.Name, dst.Namespace, dst.Name)
 	
+{{- if .Conversion }}
+{{ range .Conversion.Fields }}
+	dst.{{ .Hub }} = src.{{ .Spoke }}
+	{{- end }}
+	{{- range .Conversion.HubOnly }}
+
+	// TODO(user): convert hub field {{ .Path }} ({{ .Selector }}); {{ .Reason }}
+	{{- end }}
+
+	dst.Status.Created = src.Status.Created
+	dst.Status.DependenciesSatisfied = src.Status.DependenciesSatisfied
+	dst.Status.Conditions = src.Status.Conditions
+	dst.Status.Resources = src.Status.Resources
+{{- else }}
 	// TODO(user): Implement conversion logic from {{ .SpokeVersion }} to {{ .Resource.Version }}
 	// Example: Copying Spec fields
 	// dst.Spec.Size = src.Spec.Replicas
+{{- end }}
 
 	// Copy ObjectMeta to preserve name, namespace, labels, etc.
 	dst.ObjectMeta = src.ObjectMeta
@@ -98,9 +123,24 @@ func (dst *{{ .Resource.Kind }}) ConvertFrom(srcRaw conversion.Hub) error {
 	log.Printf("ConvertFrom: Converting {{ .Resource.Kind }} from Hub version {{ .Resource.Version }} to Spoke version {{ .SpokeVersion }};" +
 		"source: %s/%s, target: %s/%s", src.Namespace, src.Name, dst.Namespace, dst.Name)
 
+{{- if .Conversion }}
+{{ range .Conversion.Fields }}
+	dst.{{ .Spoke }} = src.{{ .Hub }}
+	{{- end }}
+	{{- range .Conversion.SpokeOnly }}
+
+	// TODO(user): convert spoke field {{ .Path }} ({{ .Selector }}); {{ .Reason }}
+	{{- end }}
+
+	dst.Status.Created = src.Status.Created
+	dst.Status.DependenciesSatisfied = src.Status.DependenciesSatisfied
+	dst.Status.Conditions = src.Status.Conditions
+	dst.Status.Resources = src.Status.Resources
+{{- else }}
 	// TODO(user): Implement conversion logic from {{ .Resource.Version }} to {{ .SpokeVersion }}
 	// Example: Copying Spec fields
 	// dst.Spec.Replicas = src.Spec.Size
+{{- end }}
 
 	// Copy ObjectMeta to preserve name, namespace, labels, etc.
 	dst.ObjectMeta = src.ObjectMeta
@@ -79,7 +79,7 @@ type GenerateSubCommand struct {
 	GenerateFunc GenerateFunc
 }
 
-{{ if .Initializer.IsCollection }}
+{{ if .Initializer.HasSubCmdName }}
 // NewBaseGenerateSubCommand returns a subcommand that is meant to belong to a parent
 // subcommand but have subcommands itself.
 func NewBaseGenerateSubCommand(parentCommand *cobra.Command) *GenerateSubCommand {
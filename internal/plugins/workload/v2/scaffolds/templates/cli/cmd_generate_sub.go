@@ -52,20 +52,25 @@ func (f *CmdGenerateSub) SetTemplateDefaults() error {
 	f.SubCmd = *f.Builder.GetSubCommand()
 	f.Collection = f.Builder.GetCollection()
 
-	// if we have a standalone simply use the default command name and description
-	// for generate since the 'generate' command will be the last in the chain,
-	// otherwise we will use the requested subcommand name.
-	if f.Builder.IsStandalone() {
+	// if we have a workload without a subcommand simply use the default command name
+	// and description for generate since the 'generate' command will be the last in
+	// the chain, otherwise we will use the requested subcommand name.
+	if !f.Builder.HasSubCmdName() {
 		f.GenerateCommandName = generateCommandName
 		f.GenerateCommandDescr = generateCommandDescr
 	} else {
 		f.GenerateCommandName = f.SubCmd.Name
 		f.GenerateCommandDescr = f.SubCmd.Description
+	}
+
+	// use the collection manifest flag for non-standalone use cases
+	if !f.Builder.IsStandalone() {
 		f.UseCollectionManifestFlag = true
 	}
 
-	// use the workload manifest flag for non-collection use cases
-	if !f.Builder.IsCollection() {
+	// use the workload manifest flag for non-collection use cases and for nested collections,
+	// which are managed as components of their parent collection
+	if !f.Builder.IsCollection() || f.Builder.IsComponent() {
 		f.UseWorkloadManifestFlag = true
 	}
 
@@ -169,8 +174,9 @@ func (f *CmdGenerateSubUpdater) GetCodeFragments() machinery.CodeFragmentsMap {
 		f.UseCollectionManifestFlag = true
 	}
 
-	// use the workload manifest flag for non-collection use cases
-	if !f.Builder.IsCollection() {
+	// use the workload manifest flag for non-collection use cases and for nested collections,
+	// which are managed as components of their parent collection
+	if !f.Builder.IsCollection() || f.Builder.IsComponent() {
 		f.UseWorkloadManifestFlag = true
 	}
 
@@ -237,10 +243,10 @@ func New{{ .Resource.Kind }}SubCommand(parentCommand *cobra.Command) {
 		GenerateFunc:          Generate{{ .Resource.Kind }},
 		{{- if .UseCollectionManifestFlag }}
 		UseCollectionManifest: true,
-		{{- if .Builder.IsCollection }}
-		CollectionKind:        "{{ .Resource.Kind }}",
-		{{- else }}
+		{{- if .Builder.IsComponent }}
 		CollectionKind:        "{{ .Collection.Spec.API.Kind }}",
+		{{- else }}
+		CollectionKind:        "{{ .Resource.Kind }}",
 		{{ end -}}
 		{{ end -}}
 		{{ if .UseWorkloadManifestFlag -}}
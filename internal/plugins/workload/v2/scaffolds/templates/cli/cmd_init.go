@@ -72,7 +72,7 @@ type InitSubCommand struct {
 	InitFunc InitFunc
 }
 
-{{ if .Initializer.IsCollection }}
+{{ if .Initializer.HasSubCmdName }}
 // NewBaseInitSubCommand returns a subcommand that is meant to belong to a parent
 // subcommand but have subcommands itself.
 func NewBaseInitSubCommand(parentCommand *cobra.Command) *InitSubCommand {
@@ -28,13 +28,13 @@ type CmdRoot struct {
 	Initializer kinds.WorkloadBuilder
 
 	// template variables
-	RootCmd      companion.CLI
-	IsCollection bool
+	RootCmd        companion.CLI
+	HasSubCommands bool
 }
 
 func (f *CmdRoot) SetTemplateDefaults() error {
 	// set template variables
-	f.IsCollection = f.Initializer.IsCollection()
+	f.HasSubCommands = f.Initializer.HasSubCmdName()
 	f.RootCmd = *f.Initializer.GetRootCommand()
 
 	// set interface variables
@@ -226,7 +226,7 @@ func (c *{{ .RootCmd.VarName }}Command) Run() {
 }
 
 func (c *{{ .RootCmd.VarName }}Command) newInitSubCommand() {
-	{{- if .IsCollection }}
+	{{- if .HasSubCommands }}
 	parentCommand := cmdinit.GetParent(cmdinit.NewBaseInitSubCommand(c.Command))
 	{{ else }}
 	parentCommand := cmdinit.GetParent(c.Command)
@@ -238,7 +238,7 @@ func (c *{{ .RootCmd.VarName }}Command) newInitSubCommand() {
 }
 
 func (c *{{ .RootCmd.VarName }}Command) newGenerateSubCommand() {
-	{{- if .IsCollection }}
+	{{- if .HasSubCommands }}
 	parentCommand := cmdgenerate.GetParent(cmdgenerate.NewBaseGenerateSubCommand(c.Command))
 	{{ else }}
 	parentCommand := cmdgenerate.GetParent(c.Command)
@@ -250,7 +250,7 @@ func (c *{{ .RootCmd.VarName }}Command) newGenerateSubCommand() {
 }
 
 func (c *{{ .RootCmd.VarName }}Command) newVersionSubCommand() {
-	{{- if .IsCollection }}
+	{{- if .HasSubCommands }}
 	parentCommand := cmdversion.GetParent(cmdversion.NewBaseVersionSubCommand(c.Command))
 	{{ else }}
 	parentCommand := cmdversion.GetParent(c.Command)
@@ -77,7 +77,7 @@ type VersionSubCommand struct {
 	VersionFunc VersionFunc
 }
 
-{{ if .Initializer.IsCollection }}
+{{ if .Initializer.HasSubCmdName }}
 // NewBaseVersionSubCommand returns a subcommand that is meant to belong to a parent
 // subcommand but have subcommands itself.
 func NewBaseVersionSubCommand(parentCommand *cobra.Command) *VersionSubCommand {
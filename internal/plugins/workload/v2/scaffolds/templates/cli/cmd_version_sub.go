@@ -46,7 +46,9 @@ func (f *CmdVersionSub) SetTemplateDefaults() error {
 	f.RootCmd = *f.Builder.GetRootCommand()
 	f.SubCmd = *f.Builder.GetSubCommand()
 
-	if f.Builder.IsStandalone() {
+	// a workload without a subcommand is managed directly from the root command, otherwise
+	// the workload is managed from its requested subcommand.
+	if !f.Builder.HasSubCmdName() {
 		f.VersionCommandName = versionCommandName
 		f.VersionCommandDescr = versionCommandDescr
 	} else {
@@ -7,8 +7,10 @@ import (
 	"path/filepath"
 	"text/template"
 
+	"github.com/spf13/afero"
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
 
+	"github.com/nukleros/operator-builder/internal/baseline"
 	"github.com/nukleros/operator-builder/internal/utils"
 	"github.com/nukleros/operator-builder/internal/workload/v1/commands/companion"
 )
@@ -21,6 +23,8 @@ type Main struct {
 	machinery.BoilerplateMixin
 	machinery.RepositoryMixin
 
+	FS afero.Fs
+
 	RootCmd companion.CLI
 }
 
@@ -29,7 +33,7 @@ func (f *Main) SetTemplateDefaults() error {
 	f.Path = filepath.Join("cmd", f.RootCmd.Name, "main.go")
 	f.TemplateBody = cliMainTemplate
 
-	f.IfExistsAction = machinery.SkipFile
+	f.IfExistsAction = baseline.IfExistsAction(f.FS, f.Path)
 
 	return nil
 }
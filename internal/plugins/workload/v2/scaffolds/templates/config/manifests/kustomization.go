@@ -26,7 +26,10 @@ package manifests
 import (
 	"path/filepath"
 
+	"github.com/spf13/afero"
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+
+	"github.com/nukleros/operator-builder/internal/baseline"
 )
 
 var _ machinery.Template = &Kustomization{}
@@ -36,6 +39,8 @@ type Kustomization struct {
 	machinery.TemplateMixin
 	machinery.ProjectNameMixin
 
+	FS afero.Fs
+
 	// SupportsKustomizeV4 is true for the projects that are
 	// scaffold using the kustomize/v2-aplha plugin and
 	// the major bump for it 4x
@@ -55,7 +60,7 @@ func (f *Kustomization) SetTemplateDefaults() error {
 	// it might contain user changes (i.e to work with Kustomize 4.x
 	// the target /spec/template/spec/containers/1/volumeMounts/0
 	// needs to be replaced with /spec/template/spec/containers/0/volumeMounts/0
-	f.IfExistsAction = machinery.SkipFile
+	f.IfExistsAction = baseline.IfExistsAction(f.FS, f.Path)
 
 	f.TemplateBody = kustomizationTemplate
 
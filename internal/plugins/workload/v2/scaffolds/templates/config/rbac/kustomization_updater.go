@@ -0,0 +1,99 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package rbac
+
+import (
+	"fmt"
+	log "log/slog"
+	"path/filepath"
+	"regexp"
+
+	"github.com/spf13/afero"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+var _ machinery.Template = &KustomizationUpdater{}
+
+// resourceRegex matches each of the files within the resources of the rbac kustomization.
+var resourceRegex = regexp.MustCompile(`(?m)^- \S+\.yaml[ \t]*$`)
+
+// KustomizationUpdater updates the existing rbac kustomization, which is scaffolded by
+// kubebuilder, to include additional resources.  The files of the resources may include the
+// placeholders of the resource which is being scaffolded, such as %[kind].
+type KustomizationUpdater struct {
+	machinery.TemplateMixin
+	machinery.ResourceMixin
+
+	// input fields
+	Resources []string
+	FS        afero.Fs
+
+	// After is the resource after which the resources are added.  When it is empty, the resources
+	// are added after the last of the existing resources.
+	After string
+
+	// template fields
+	Existing string
+}
+
+func (f *KustomizationUpdater) SetTemplateDefaults() error {
+	f.Path = filepath.Join("config", "rbac", "kustomization.yaml")
+
+	content, err := afero.ReadFile(f.FS, f.Path)
+	if err != nil {
+		return fmt.Errorf("failed to read rbac kustomization: %w", err)
+	}
+
+	// the kustomization is owned by the user, so it is written as is rather than executed as a
+	// template
+	f.Existing = f.addResources(string(content))
+	f.TemplateBody = "{{ .Existing }}"
+	f.IfExistsAction = machinery.OverwriteFile
+
+	return nil
+}
+
+// addResources adds each of the resources which have not already been added to the rbac
+// kustomization.
+func (f *KustomizationUpdater) addResources(content string) string {
+	code := ""
+
+	for _, resource := range f.Resources {
+		if f.Resource != nil {
+			resource = f.Resource.Replacer().Replace(resource)
+		}
+
+		if !resourceLineRegex(resource).MatchString(content) {
+			code += "\n- " + resource
+		}
+	}
+
+	if code == "" {
+		return content
+	}
+
+	var match []int
+
+	if f.After != "" {
+		match = resourceLineRegex(f.After).FindStringIndex(content)
+	} else if matches := resourceRegex.FindAllStringIndex(content, -1); len(matches) > 0 {
+		match = matches[len(matches)-1]
+	}
+
+	if match != nil {
+		return content[:match[1]] + code + content[match[1]:]
+	}
+
+	log.Warn("Could not find the resources in the rbac kustomization",
+		"file", f.Path,
+		"suggestion", fmt.Sprintf("Manually add %v to the resources", f.Resources))
+
+	return content
+}
+
+// resourceLineRegex returns a regular expression which matches a file within the resources of the
+// rbac kustomization.
+func resourceLineRegex(resource string) *regexp.Regexp {
+	return regexp.MustCompile(`(?m)^- ` + regexp.QuoteMeta(resource) + `[ \t]*$`)
+}
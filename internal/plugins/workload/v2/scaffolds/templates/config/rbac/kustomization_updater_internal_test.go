@@ -0,0 +1,77 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package rbac
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
+)
+
+const testKustomization = `resources:
+# {{ .Resource.Kind }} and {{ docs }} are not template actions
+- service_account.yaml
+- role.yaml
+- role_binding.yaml
+- leader_election_role.yaml
+`
+
+func TestKustomizationUpdater_SetTemplateDefaults(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		resources []string
+		after     string
+		want      string
+	}{
+		{
+			name:      "add after the last resource",
+			resources: []string{EditorRoleFile, ViewerRoleFile},
+			want: testKustomization +
+				"- apps_webapp_editor_role.yaml\n" +
+				"- apps_webapp_viewer_role.yaml\n",
+		},
+		{
+			name:      "add after a resource",
+			resources: []string{NamespaceRoleBindingFile},
+			after:     "role_binding.yaml",
+			want: `resources:
+# {{ .Resource.Kind }} and {{ docs }} are not template actions
+- service_account.yaml
+- role.yaml
+- role_binding.yaml
+- namespace_role_binding.yaml
+- leader_election_role.yaml
+`,
+		},
+		{
+			name:      "resources which were already added",
+			resources: []string{"role.yaml"},
+			want:      testKustomization,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fs := afero.NewMemMapFs()
+			path := filepath.Join("config", "rbac", "kustomization.yaml")
+			require.NoError(t, afero.WriteFile(fs, path, []byte(testKustomization), 0o644))
+
+			f := &KustomizationUpdater{Resources: tt.resources, After: tt.after, FS: fs}
+			f.Resource = &resource.Resource{GVK: resource.GVK{Group: "apps", Version: "v1alpha1", Kind: "WebApp"}}
+
+			require.NoError(t, f.SetTemplateDefaults())
+			assert.Equal(t, "{{ .Existing }}", f.TemplateBody)
+			assert.Equal(t, tt.want, f.Existing)
+		})
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package rbac
+
+import (
+	"path/filepath"
+
+	"github.com/spf13/afero"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+
+	"github.com/nukleros/operator-builder/internal/baseline"
+)
+
+var _ machinery.Template = &RoleBinding{}
+
+// RoleBinding scaffolds the role binding which grants the namespace scoped role of the manager,
+// which controller-gen generates from the namespaced rbac markers, to its service account.
+type RoleBinding struct {
+	machinery.TemplateMixin
+	machinery.ProjectNameMixin
+
+	FS afero.Fs
+}
+
+func (f *RoleBinding) SetTemplateDefaults() error {
+	f.Path = filepath.Join("config", "rbac", NamespaceRoleBindingFile)
+
+	f.TemplateBody = roleBindingTemplate
+
+	f.IfExistsAction = baseline.IfExistsAction(f.FS, f.Path)
+
+	return nil
+}
+
+// NamespaceRoleBindingFile is the file of the binding of the namespace scoped role of the manager.
+const NamespaceRoleBindingFile = "namespace_role_binding.yaml"
+
+const roleBindingTemplate = `apiVersion: rbac.authorization.k8s.io/v1
+kind: RoleBinding
+metadata:
+  labels:
+    app.kubernetes.io/name: {{ .ProjectName }}
+    app.kubernetes.io/managed-by: kustomize
+  name: manager-rolebinding
+  namespace: system
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: Role
+  name: manager-role
+subjects:
+- kind: ServiceAccount
+  name: controller-manager
+  namespace: system
+`
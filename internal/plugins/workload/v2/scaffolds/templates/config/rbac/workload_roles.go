@@ -0,0 +1,141 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package rbac
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+var (
+	_ machinery.Template = &ViewerRole{}
+	_ machinery.Template = &EditorRole{}
+)
+
+// ViewerRole scaffolds the cluster role which grants read only access to the custom resources of
+// a workload.  The cluster role is aggregated to the default view, edit and admin cluster roles.
+// It replaces the viewer role which kubebuilder scaffolds, if any, so that the roles of each
+// workload are the same.
+type ViewerRole struct {
+	machinery.TemplateMixin
+	machinery.ResourceMixin
+	machinery.ProjectNameMixin
+
+	RoleName string
+}
+
+func (f *ViewerRole) SetTemplateDefaults() error {
+	f.Path = f.Resource.Replacer().Replace(filepath.Join("config", "rbac", ViewerRoleFile))
+	f.RoleName = workloadRoleName(f.Resource.Group, f.Resource.Kind, "viewer")
+
+	f.TemplateBody = viewerRoleTemplate
+
+	f.IfExistsAction = machinery.OverwriteFile
+
+	return nil
+}
+
+// EditorRole scaffolds the cluster role which grants full access to the custom resources of a
+// workload.  The cluster role is aggregated to the default edit and admin cluster roles.  It
+// replaces the editor role which kubebuilder scaffolds, if any, so that the roles of each
+// workload are the same.
+type EditorRole struct {
+	machinery.TemplateMixin
+	machinery.ResourceMixin
+	machinery.ProjectNameMixin
+
+	RoleName string
+}
+
+func (f *EditorRole) SetTemplateDefaults() error {
+	f.Path = f.Resource.Replacer().Replace(filepath.Join("config", "rbac", EditorRoleFile))
+	f.RoleName = workloadRoleName(f.Resource.Group, f.Resource.Kind, "editor")
+
+	f.TemplateBody = editorRoleTemplate
+
+	f.IfExistsAction = machinery.OverwriteFile
+
+	return nil
+}
+
+// the files of the workload roles, which match the files of the roles which kubebuilder
+// scaffolds for a multi-group project.
+const (
+	ViewerRoleFile = "%[group]_%[kind]_viewer_role.yaml"
+	EditorRoleFile = "%[group]_%[kind]_editor_role.yaml"
+)
+
+// workloadRoleName returns the name of a workload role, which matches the name of the role which
+// kubebuilder scaffolds for a multi-group project.
+func workloadRoleName(group, kind, role string) string {
+	return fmt.Sprintf("%s-%s-%s-role", strings.ToLower(group), strings.ToLower(kind), role)
+}
+
+const viewerRoleTemplate = `# This rule is not used by the project {{ .ProjectName }} itself.
+# It is aggregated to the view, edit and admin cluster roles, so that users who
+# are bound to them may view {{ .Resource.Kind }} resources.
+
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  labels:
+    app.kubernetes.io/name: {{ .ProjectName }}
+    app.kubernetes.io/managed-by: kustomize
+    rbac.authorization.k8s.io/aggregate-to-view: "true"
+    rbac.authorization.k8s.io/aggregate-to-edit: "true"
+    rbac.authorization.k8s.io/aggregate-to-admin: "true"
+  name: {{ .RoleName }}
+rules:
+- apiGroups:
+  - {{ .Resource.QualifiedGroup }}
+  resources:
+  - {{ .Resource.Plural }}
+  verbs:
+  - get
+  - list
+  - watch
+- apiGroups:
+  - {{ .Resource.QualifiedGroup }}
+  resources:
+  - {{ .Resource.Plural }}/status
+  verbs:
+  - get
+`
+
+const editorRoleTemplate = `# This rule is not used by the project {{ .ProjectName }} itself.
+# It is aggregated to the edit and admin cluster roles, so that users who are
+# bound to them may manage {{ .Resource.Kind }} resources.
+
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  labels:
+    app.kubernetes.io/name: {{ .ProjectName }}
+    app.kubernetes.io/managed-by: kustomize
+    rbac.authorization.k8s.io/aggregate-to-edit: "true"
+    rbac.authorization.k8s.io/aggregate-to-admin: "true"
+  name: {{ .RoleName }}
+rules:
+- apiGroups:
+  - {{ .Resource.QualifiedGroup }}
+  resources:
+  - {{ .Resource.Plural }}
+  verbs:
+  - create
+  - delete
+  - get
+  - list
+  - patch
+  - update
+  - watch
+- apiGroups:
+  - {{ .Resource.QualifiedGroup }}
+  resources:
+  - {{ .Resource.Plural }}/status
+  verbs:
+  - get
+`
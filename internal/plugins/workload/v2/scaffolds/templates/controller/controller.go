@@ -49,7 +49,7 @@ func (f *Controller) SetTemplateDefaults() error {
 }
 
 func (f *Controller) setBaseImports() {
-	f.BaseImports = []string{`"context"`, `"fmt"`}
+	f.BaseImports = []string{`"context"`, `"fmt"`, `"time"`}
 
 	if f.Builder.IsComponent() {
 		f.BaseImports = append(f.BaseImports, `"errors"`, `"reflect"`)
@@ -70,6 +70,18 @@ func (f *Controller) setOtherImports() {
 		`"github.com/nukleros/operator-builder-tools/pkg/controller/workload"`,
 	}
 
+	if len(f.Builder.GetExternalDependencies()) > 0 {
+		f.OtherImports = append(f.OtherImports, `"k8s.io/apimachinery/pkg/runtime/schema"`)
+	}
+
+	if f.Builder.GetControllerOptions().RateLimiter != nil {
+		f.OtherImports = append(f.OtherImports, `"k8s.io/client-go/util/workqueue"`)
+
+		if !f.Builder.IsComponent() {
+			f.OtherImports = append(f.OtherImports, `"sigs.k8s.io/controller-runtime/pkg/reconcile"`)
+		}
+	}
+
 	if f.Builder.IsComponent() {
 		f.OtherImports = append(f.OtherImports,
 			`"github.com/nukleros/operator-builder-tools/pkg/resources"`,
@@ -133,6 +145,32 @@ import (
 	{{ end }}
 )
 
+{{ with .Builder.GetControllerOptions -}}
+const (
+	// {{ $.Resource.Kind }}DependencyRequeueDelay is the delay before the dependencies of a {{ $.Resource.Kind }}
+	// are checked again when they are not satisfied.
+	{{ $.Resource.Kind }}DependencyRequeueDelay = {{ .DependencyRequeueDelayCode }}
+
+	// {{ $.Resource.Kind }}CheckReadyRequeueDelay is the delay before the child resources of a {{ $.Resource.Kind }}
+	// are checked again when they are not ready.
+	{{ $.Resource.Kind }}CheckReadyRequeueDelay = {{ .CheckReadyRequeueDelayCode }}
+	{{- if .HasResyncPeriod }}
+
+	// {{ $.Resource.Kind }}ResyncPeriod is the period after which a {{ $.Resource.Kind }} is reconciled again
+	// once it has been reconciled successfully.
+	{{ $.Resource.Kind }}ResyncPeriod = {{ .ResyncPeriodCode }}
+	{{- end }}
+	{{- range $.Builder.GetPhases }}
+	{{- if .HasRequeueDelay }}
+
+	// {{ .RequeueDelayName $.Resource.Kind }} is the delay before the {{ .Name }} phase of a
+	// {{ $.Resource.Kind }} is executed again when it does not proceed.
+	{{ .RequeueDelayName $.Resource.Kind }} = {{ .RequeueDelayCode }}
+	{{- end }}
+	{{- end }}
+)
+{{- end }}
+
 // {{ .Resource.Kind }}Reconciler reconciles a {{ .Resource.Kind }} object.
 type {{ .Resource.Kind }}Reconciler struct {
 	client.Client
@@ -193,7 +231,17 @@ func (r *{{ .Resource.Kind }}Reconciler) Reconcile(ctx context.Context, request
 	}
 
 	// execute the phases
+	{{- if .Builder.GetControllerOptions.HasResyncPeriod }}
+	result, err := r.Phases.HandleExecution(r, req)
+	if err != nil || !result.IsZero() || !req.Workload.GetDeletionTimestamp().IsZero() {
+		return result, err
+	}
+
+	// reconcile the workload again once the resync period has elapsed
+	return ctrl.Result{RequeueAfter: {{ .Resource.Kind }}ResyncPeriod}, nil
+	{{- else }}
 	return r.Phases.HandleExecution(r, req)
+	{{- end }}
 }
 
 func (r *{{ .Resource.Kind }}Reconciler) NewRequest(ctx context.Context, request ctrl.Request) (*workload.Request, error) {
@@ -404,6 +452,30 @@ func (r *{{ .Resource.Kind }}Reconciler) CheckReady(req *workload.Request) (bool
 	return dependencies.{{ .Resource.Kind }}CheckReady(r, req)
 }
 
+// {{ .Resource.Kind }}DependencyPhase executes a dependency check prior to attempting to create resources.  In
+// addition to the workloads which a {{ .Resource.Kind }} depends upon, it ensures that the resources which
+// are not managed by this operator, but are depended upon, are ready.
+func {{ .Resource.Kind }}DependencyPhase(r workload.Reconciler, req *workload.Request, options ...phases.ResourceOption) (bool, error) {
+	satisfied, err := phases.DependencyPhase(r, req, options...)
+	if err != nil || !satisfied {
+		return satisfied, err
+	}
+{{ if .Builder.GetExternalDependencies }}
+	return dependencies.ExternalDependenciesSatisfied(r, req,
+		{{- range .Builder.GetExternalDependencies }}
+		dependencies.ExternalDependency{
+			GroupVersionKind: schema.GroupVersionKind{Group: "{{ .Group }}", Version: "{{ .Version }}", Kind: "{{ .Kind }}"},
+			Name:             "{{ .Name }}",
+			Namespace:        "{{ .Namespace }}",
+			Condition:        "{{ .Condition }}",
+		},
+		{{- end }}
+	)
+{{- else }}
+	return true, nil
+{{- end }}
+}
+
 // Mutate will run the mutate function for the workload.
 // WARN: this will be deprecated in the future.  See apis/group/version/kind/mutate*
 func (r *{{ .Resource.Kind }}Reconciler) Mutate(
@@ -419,6 +491,21 @@ func (r *{{ .Resource.Kind }}Reconciler) SetupWithManager(mgr ctrl.Manager) erro
 	baseController, err := ctrl.NewControllerManagedBy(mgr).
 		WithEventFilter(predicates.WorkloadPredicates()).
 		For(&{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}{}).
+		{{- with .Builder.GetControllerOptions }}
+		{{- if .HasControllerOptions }}
+		WithOptions(controller.Options{
+			{{- if .MaxConcurrentReconciles }}
+			MaxConcurrentReconciles: {{ .MaxConcurrentReconciles }},
+			{{- end }}
+			{{- with .RateLimiter }}
+			RateLimiter: workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](
+				{{ .BaseDelayCode }},
+				{{ .MaxDelayCode }},
+			),
+			{{- end }}
+		}).
+		{{- end }}
+		{{- end }}
 		Build(r)
 	if err != nil {
 		return fmt.Errorf("unable to setup controller, %w", err)
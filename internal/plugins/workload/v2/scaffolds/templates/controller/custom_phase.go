@@ -0,0 +1,65 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+
+	"github.com/nukleros/operator-builder/internal/baseline"
+	"github.com/nukleros/operator-builder/internal/utils"
+	"github.com/nukleros/operator-builder/internal/workload/v1/kinds"
+)
+
+var _ machinery.Template = &CustomPhase{}
+
+// CustomPhase scaffolds the function which executes a custom phase of the workload's controller.
+// The file is owned by the user once it is scaffolded and is never overwritten.
+type CustomPhase struct {
+	machinery.TemplateMixin
+	machinery.BoilerplateMixin
+	machinery.RepositoryMixin
+	machinery.ResourceMixin
+
+	// input fields
+	Phase *kinds.Phase
+	FS    afero.Fs
+}
+
+func (f *CustomPhase) SetTemplateDefaults() error {
+	f.Path = filepath.Join(
+		"controllers",
+		f.Resource.Group,
+		fmt.Sprintf("%s_%s_phase.go", utils.ToFileName(f.Resource.Kind), utils.ToFileName(f.Phase.Name)),
+	)
+
+	f.TemplateBody = customPhaseTemplate
+	f.IfExistsAction = baseline.IfExistsAction(f.FS, f.Path)
+
+	return nil
+}
+
+const customPhaseTemplate = `{{ .Boilerplate }}
+
+package {{ .Resource.Group }}
+
+import (
+	"github.com/nukleros/operator-builder-tools/pkg/controller/phases"
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+)
+
+// {{ .Phase.FuncName .Resource.Kind }} executes the {{ .Phase.Name }} phase of a {{ .Resource.Kind }}.  The
+// phase proceeds when it returns true and is executed again when it returns false.
+func {{ .Phase.FuncName .Resource.Kind }}(
+	r workload.Reconciler,
+	req *workload.Request,
+	options ...phases.ResourceOption,
+) (bool, error) {
+	// TODO: implement the {{ .Phase.Name }} phase
+	return true, nil
+}
+`
@@ -4,24 +4,79 @@
 package controller
 
 import (
+	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
+	"regexp"
+	"strings"
 
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/afero"
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
 
+	"github.com/nukleros/operator-builder/internal/baseline"
 	"github.com/nukleros/operator-builder/internal/utils"
+	"github.com/nukleros/operator-builder/internal/workload/v1/kinds"
 )
 
-var _ machinery.Template = &Controller{}
+var _ machinery.Template = &Phases{}
 
-// Controller scaffolds the workload's controller.
+var (
+	phaseRegisterRegex = regexp.MustCompile(`\.Phases\.Register\(`)
+	phaseNameRegex     = regexp.MustCompile(`"([^"]+)"`)
+	phaseEventRegex    = regexp.MustCompile(`phases\.(Create|Update|Delete)Event`)
+
+	// dependencyHandlerRegex matches the handler of the dependency phase which was registered by
+	// phases files that were scaffolded before the dependency phase of a workload was generated
+	dependencyHandlerRegex = regexp.MustCompile(`\bphases\.DependencyPhase\b`)
+
+	// requeueAfterRegex matches the requeue delay of a registration, and scaffoldedRequeueRegex
+	// matches the delay which was scaffolded before the requeue delays of a workload were generated
+	requeueAfterRegex      = regexp.MustCompile(`(RequeueAfter:\s*)([^},]*[^},\s])`)
+	scaffoldedRequeueRegex = regexp.MustCompile(`^5\s*\*\s*time\.Second$`)
+	timeImportRegex        = regexp.MustCompile(`(?m)^\s*"time"\n(\s*\n)?`)
+	timeUsageRegex         = regexp.MustCompile(`\btime\.`)
+)
+
+// Phases scaffolds the registration of the phases of the workload's controller.  The phases file
+// is owned by the user once it is scaffolded, so the registrations for custom phases which are
+// missing from an existing phases file are inserted, and the registrations of the generated phases
+// which are out of date are updated, without modifying the rest of the file.
 type Phases struct {
 	machinery.TemplateMixin
 	machinery.BoilerplateMixin
 	machinery.RepositoryMixin
 	machinery.ResourceMixin
 
-	PackageName string
+	// input fields
+	Builder kinds.WorkloadBuilder
+	FS      afero.Fs
+
+	// template fields
+	CreatePhases string
+	UpdatePhases string
+	DeletePhases string
+
+	// Existing is the content of an existing phases file with the missing custom phases inserted,
+	// which is written as is rather than executed as a template.
+	Existing string
+}
+
+// phaseRegistration represents the registration of a single phase for an event.
+type phaseRegistration struct {
+	name         string
+	handler      string
+	event        string
+	requeueDelay string
+}
+
+// registeredPhase represents a phase which is registered within an existing phases file.
+type registeredPhase struct {
+	name  string
+	event string
+	start int
+	end   int
 }
 
 func (f *Phases) SetTemplateDefaults() error {
@@ -31,19 +86,350 @@ func (f *Phases) SetTemplateDefaults() error {
 		fmt.Sprintf("%s_phases.go", utils.ToFileName(f.Resource.Kind)),
 	)
 
+	content, err := afero.ReadFile(f.FS, f.Path)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("%w; unable to read phases file %s", err, f.Path)
+		}
+
+		return f.setNewFile()
+	}
+
+	// a phases file which has a baseline is generated in full and merged with the edits which have
+	// been made to it, which includes any custom phases that are missing from it
+	if f.hasBaseline() {
+		return f.setNewFile()
+	}
+
+	updated := f.insertCustomPhases(f.updateRequeueDelays(f.updateDependencyHandlers(string(content))))
+
+	// leave the phases file untouched unless it is out of date
+	if updated == string(content) {
+		f.TemplateBody = phasesTemplate
+		f.IfExistsAction = machinery.SkipFile
+
+		return nil
+	}
+
+	f.Existing = updated
+	f.TemplateBody = existingPhasesTemplate
+	f.IfExistsAction = machinery.OverwriteFile
+
+	return nil
+}
+
+// hasBaseline returns whether a baseline has been recorded for the phases file.
+func (f *Phases) hasBaseline() bool {
+	return baseline.Exists(f.FS, f.Path)
+}
+
+// setNewFile sets the template for a new phases file, which registers all of the phases in order.
+func (f *Phases) setNewFile() error {
+	registrations := map[string]*string{
+		kinds.PhaseEventCreate: &f.CreatePhases,
+		kinds.PhaseEventUpdate: &f.UpdatePhases,
+		kinds.PhaseEventDelete: &f.DeletePhases,
+	}
+
+	for _, event := range kinds.PhaseEvents() {
+		ordered, err := kinds.OrderPhases(f.Builder.GetPhases(), event)
+		if err != nil {
+			return fmt.Errorf("%w; unable to order phases for %s", err, f.Resource.Kind)
+		}
+
+		code := make([]string, len(ordered))
+		for i, name := range ordered {
+			code[i] = f.registration(name, event).code()
+		}
+
+		*registrations[event] = strings.Join(code, "\n")
+	}
+
 	f.TemplateBody = phasesTemplate
 	f.IfExistsAction = machinery.SkipFile
 
+	if f.hasBaseline() {
+		f.IfExistsAction = machinery.OverwriteFile
+	}
+
+	return nil
+}
+
+// insertCustomPhases inserts the registrations for the custom phases which are missing from the
+// content of an existing phases file.  Each registration is inserted next to the registration of
+// the phase which it is positioned relative to.
+func (f *Phases) insertCustomPhases(content string) string {
+	for _, event := range kinds.PhaseEvents() {
+		for _, phase := range f.Builder.GetPhases() {
+			if !phase.HasEvent(event) {
+				continue
+			}
+
+			registered := parseRegisteredPhases(content)
+
+			if findRegisteredPhase(registered, phase.Name, event) != nil {
+				continue
+			}
+
+			anchorName := phase.Position.Before
+			if anchorName == "" {
+				anchorName = phase.Position.After
+			}
+
+			anchor := findRegisteredPhase(registered, anchorName, event)
+			if anchor == nil {
+				log.Warnf(
+					"unable to find the registration of phase %s for the %s event in %s; register phase %s with %s manually",
+					anchorName, event, f.Path, phase.Name, phase.FuncName(f.Resource.Kind),
+				)
+
+				continue
+			}
+
+			code := f.registration(phase.Name, event).code()
+
+			if phase.Position.Before != "" {
+				position := strings.LastIndex(content[:anchor.start], "\n") + 1
+				content = content[:position] + code + "\n" + content[position:]
+
+				continue
+			}
+
+			// insert after the anchor, along with any custom phases which were positioned after
+			// the same anchor before this phase
+			position := f.skipPhasesAfter(registered, anchor, anchorName)
+			content = content[:position] + "\n\n" + strings.TrimSuffix(code, "\n") + content[position:]
+		}
+	}
+
+	return content
+}
+
+// updateDependencyHandlers replaces the handler of each registration of the dependency phase within
+// the content of an existing phases file with the generated dependency phase of the workload, which
+// also checks the external dependencies of the workload.
+func (f *Phases) updateDependencyHandlers(content string) string {
+	handler := f.registration(kinds.PhaseDependency, kinds.PhaseEventCreate).handler
+	registered := parseRegisteredPhases(content)
+
+	// the registrations are updated from the last so that the positions of the earlier ones are kept
+	for i := len(registered) - 1; i >= 0; i-- {
+		phase := registered[i]
+		if phase.name != kinds.PhaseDependency {
+			continue
+		}
+
+		call := content[phase.start:phase.end]
+		if !dependencyHandlerRegex.MatchString(call) {
+			continue
+		}
+
+		log.Infof("updating the handler of phase %s for the %s event in %s to %s",
+			phase.name, phase.event, f.Path, handler)
+
+		content = content[:phase.start] + dependencyHandlerRegex.ReplaceAllLiteralString(call, handler) + content[phase.end:]
+	}
+
+	return content
+}
+
+// updateRequeueDelays replaces the requeue delay of each registration of the dependency and
+// check-ready phases within the content of an existing phases file, which was scaffolded with a
+// fixed delay, with the generated requeue delay of the workload.  A delay which has been edited
+// is kept, and the user is warned that it overrides the configured requeue delay.
+func (f *Phases) updateRequeueDelays(content string) string {
+	registered := parseRegisteredPhases(content)
+
+	// the registrations are updated from the last so that the positions of the earlier ones are kept
+	for i := len(registered) - 1; i >= 0; i-- {
+		phase := registered[i]
+		if phase.name != kinds.PhaseDependency && phase.name != kinds.PhaseCheckReady {
+			continue
+		}
+
+		delay := f.registration(phase.name, phase.event).requeueDelay
+		call := content[phase.start:phase.end]
+
+		match := requeueAfterRegex.FindStringSubmatchIndex(call)
+		if match == nil || call[match[4]:match[5]] == delay {
+			continue
+		}
+
+		if !scaffoldedRequeueRegex.MatchString(call[match[4]:match[5]]) {
+			log.Warnf(
+				"the requeue delay of phase %s for the %s event in %s overrides the configured requeue delay; replace it with %s to use the configured delay",
+				phase.name, phase.event, f.Path, delay,
+			)
+
+			continue
+		}
+
+		log.Infof("updating the requeue delay of phase %s for the %s event in %s to %s",
+			phase.name, phase.event, f.Path, delay)
+
+		content = content[:phase.start] + call[:match[4]] + delay + call[match[5]:] + content[phase.end:]
+	}
+
+	// the time package was only imported for the scaffolded requeue delays
+	if !timeUsageRegex.MatchString(content) {
+		content = timeImportRegex.ReplaceAllLiteralString(content, "")
+	}
+
+	return content
+}
+
+// skipPhasesAfter returns the position following the closing parenthesis of the registration of an
+// anchor, skipping over the registrations of the custom phases which directly follow the anchor and
+// are positioned after it.
+func (f *Phases) skipPhasesAfter(registered []*registeredPhase, anchor *registeredPhase, anchorName string) int {
+	end := anchor.end
+
+	for _, next := range registered {
+		if next.start <= end {
+			continue
+		}
+
+		if next.event != anchor.event || !f.isPositionedAfter(next.name, anchorName) {
+			break
+		}
+
+		end = next.end
+	}
+
+	return end + 1
+}
+
+// isPositionedAfter returns whether a custom phase is positioned after another phase.
+func (f *Phases) isPositionedAfter(name, anchorName string) bool {
+	for _, phase := range f.Builder.GetPhases() {
+		if phase.Name == name {
+			return phase.Position.After == anchorName
+		}
+	}
+
+	return false
+}
+
+// registration returns the registration of a phase for an event.
+func (f *Phases) registration(name, event string) *phaseRegistration {
+	kind := f.Resource.Kind
+
+	registration := &phaseRegistration{
+		name:  name,
+		event: fmt.Sprintf("phases.%sEvent", utils.ToTitle(event)),
+	}
+
+	switch name {
+	case kinds.PhaseDependency:
+		registration.handler = kind + "DependencyPhase"
+		registration.requeueDelay = kind + "DependencyRequeueDelay"
+	case kinds.PhaseCreateResources:
+		registration.handler = "phases.CreateResourcesPhase"
+	case kinds.PhaseCheckReady:
+		registration.handler = "phases.CheckReadyPhase"
+		registration.requeueDelay = kind + "CheckReadyRequeueDelay"
+	case kinds.PhaseComplete:
+		registration.handler = "phases.CompletePhase"
+	case kinds.PhaseDeletionComplete:
+		registration.handler = "phases.DeletionCompletePhase"
+	default:
+		for _, phase := range f.Builder.GetPhases() {
+			if phase.Name != name {
+				continue
+			}
+
+			registration.handler = phase.FuncName(kind)
+
+			if phase.HasRequeueDelay() {
+				registration.requeueDelay = phase.RequeueDelayName(kind)
+			}
+		}
+	}
+
+	return registration
+}
+
+// code returns the source code which registers the phase, terminated by a newline.
+func (registration *phaseRegistration) code() string {
+	var code strings.Builder
+
+	code.WriteString("\tr.Phases.Register(\n")
+	fmt.Fprintf(&code, "\t\t%q,\n", registration.name)
+	fmt.Fprintf(&code, "\t\t%s,\n", registration.handler)
+	fmt.Fprintf(&code, "\t\t%s,\n", registration.event)
+
+	if registration.requeueDelay != "" {
+		fmt.Fprintf(&code, "\t\tphases.WithCustomRequeueResult(ctrl.Result{RequeueAfter: %s}),\n", registration.requeueDelay)
+	}
+
+	code.WriteString("\t)\n")
+
+	return code.String()
+}
+
+// parseRegisteredPhases returns the phases which are registered within the content of a phases
+// file, in the order in which they appear.
+func parseRegisteredPhases(content string) []*registeredPhase {
+	registered := []*registeredPhase{}
+
+	for _, match := range phaseRegisterRegex.FindAllStringIndex(content, -1) {
+		// find the closing parenthesis of the call to register the phase
+		depth, end := 1, -1
+
+		for i := match[1]; i < len(content) && end < 0; i++ {
+			switch content[i] {
+			case '(':
+				depth++
+			case ')':
+				if depth--; depth == 0 {
+					end = i
+				}
+			}
+		}
+
+		if end < 0 {
+			continue
+		}
+
+		call := content[match[1]:end]
+
+		name := phaseNameRegex.FindStringSubmatch(call)
+		event := phaseEventRegex.FindStringSubmatch(call)
+
+		if name == nil || event == nil {
+			continue
+		}
+
+		registered = append(registered, &registeredPhase{
+			name:  name[1],
+			event: strings.ToLower(event[1]),
+			start: match[0],
+			end:   end,
+		})
+	}
+
+	return registered
+}
+
+// findRegisteredPhase returns the registration of a phase for an event, or nil if the phase is not
+// registered for the event.
+func findRegisteredPhase(registered []*registeredPhase, name, event string) *registeredPhase {
+	for _, phase := range registered {
+		if phase.name == name && phase.event == event {
+			return phase
+		}
+	}
+
 	return nil
 }
 
+const existingPhasesTemplate = `{{ .Existing }}`
+
 const phasesTemplate = `{{ .Boilerplate }}
 
 package {{ .Resource.Group }}
 
 import (
-	"time"
-
 	"github.com/nukleros/operator-builder-tools/pkg/controller/phases"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
@@ -52,64 +438,10 @@ import (
 // in the order they are listed.
 func (r *{{ .Resource.Kind }}Reconciler) InitializePhases() {
 	// Create Phases
-	r.Phases.Register(
-		"Dependency",
-		phases.DependencyPhase,
-		phases.CreateEvent,
-		phases.WithCustomRequeueResult(ctrl.Result{RequeueAfter: 5 * time.Second }),
-	)
-
-	r.Phases.Register(
-		"Create-Resources",
-		phases.CreateResourcesPhase,
-		phases.CreateEvent,
-	)
-
-	r.Phases.Register(
-		"Check-Ready",
-		phases.CheckReadyPhase,
-		phases.CreateEvent,
-		phases.WithCustomRequeueResult(ctrl.Result{RequeueAfter: 5 * time.Second }),
-	)
-
-	r.Phases.Register(
-		"Complete",
-		phases.CompletePhase,
-		phases.CreateEvent,
-	)
-
+{{ .CreatePhases }}
 	// Update Phases
-	r.Phases.Register(
-		"Dependency",
-		phases.DependencyPhase,
-		phases.UpdateEvent,
-		phases.WithCustomRequeueResult(ctrl.Result{RequeueAfter: 5 * time.Second }),
-	)
-
-	r.Phases.Register(
-		"Create-Resources",
-		phases.CreateResourcesPhase,
-		phases.UpdateEvent,
-	)
-
-	r.Phases.Register(
-		"Check-Ready",
-		phases.CheckReadyPhase,
-		phases.UpdateEvent,
-		phases.WithCustomRequeueResult(ctrl.Result{RequeueAfter: 5 * time.Second }),
-	)
-
-	r.Phases.Register(
-		"Complete",
-		phases.CompletePhase,
-		phases.UpdateEvent,
-	)
-
+{{ .UpdatePhases }}
 	// Delete Phases
-	r.Phases.Register(
-		"DeletionComplete",
-		phases.DeletionCompletePhase,
-		phases.DeleteEvent,
-	)
+{{ .DeletePhases -}}
 }
 `
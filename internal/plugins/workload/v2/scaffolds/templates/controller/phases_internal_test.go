@@ -0,0 +1,362 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
+
+	"github.com/nukleros/operator-builder/internal/baseline"
+	"github.com/nukleros/operator-builder/internal/workload/v1/kinds"
+)
+
+const testPhasesFile = `package apps
+
+// InitializePhases defines what phases should be run for each event loop.
+func (r *WebAppReconciler) InitializePhases() {
+	// Create Phases
+	r.Phases.Register(
+		"Dependency",
+		WebAppDependencyPhase,
+		phases.CreateEvent,
+		phases.WithCustomRequeueResult(ctrl.Result{RequeueAfter: WebAppDependencyRequeueDelay}),
+	)
+
+	r.Phases.Register(
+		"Create-Resources",
+		phases.CreateResourcesPhase,
+		phases.CreateEvent,
+	)
+
+	// Delete Phases
+	r.Phases.Register(
+		"DeletionComplete",
+		phases.DeletionCompletePhase,
+		phases.DeleteEvent,
+	)
+}
+`
+
+func newTestPhases(phases ...*kinds.Phase) *Phases {
+	workload := &kinds.StandaloneWorkload{}
+	workload.Spec.Phases = phases
+
+	f := &Phases{Builder: workload, FS: afero.NewMemMapFs()}
+	f.Resource = &resource.Resource{GVK: resource.GVK{Group: "apps", Version: "v1alpha1", Kind: "WebApp"}}
+
+	return f
+}
+
+func TestParseRegisteredPhases(t *testing.T) {
+	t.Parallel()
+
+	registered := parseRegisteredPhases(testPhasesFile)
+	require.Len(t, registered, 3)
+
+	want := []struct {
+		name  string
+		event string
+	}{
+		{name: "Dependency", event: kinds.PhaseEventCreate},
+		{name: "Create-Resources", event: kinds.PhaseEventCreate},
+		{name: "DeletionComplete", event: kinds.PhaseEventDelete},
+	}
+
+	for i, phase := range registered {
+		assert.Equal(t, want[i].name, phase.name)
+		assert.Equal(t, want[i].event, phase.event)
+		assert.Equal(t, ".Phases.Register(", testPhasesFile[phase.start:phase.start+len(".Phases.Register(")])
+		assert.Equal(t, byte(')'), testPhasesFile[phase.end])
+	}
+
+	assert.Empty(t, parseRegisteredPhases("r.Phases.Register(\n\t\"Unterminated\",\n"))
+}
+
+func TestPhases_skipPhasesAfter(t *testing.T) {
+	t.Parallel()
+
+	content := `	r.Phases.Register("Dependency", WebAppDependencyPhase, phases.CreateEvent)
+	r.Phases.Register("Audit", WebAppAuditPhase, phases.CreateEvent)
+	r.Phases.Register("Notify", WebAppNotifyPhase, phases.CreateEvent)
+	r.Phases.Register("Create-Resources", phases.CreateResourcesPhase, phases.CreateEvent)
+`
+
+	f := newTestPhases(
+		&kinds.Phase{Name: "Audit", Position: kinds.PhasePosition{After: "Dependency"}},
+		&kinds.Phase{Name: "Notify", Position: kinds.PhasePosition{Before: "Create-Resources"}},
+	)
+
+	registered := parseRegisteredPhases(content)
+	require.Len(t, registered, 4)
+
+	// the audit phase follows the dependency phase, but the notify phase is positioned before
+	// another phase
+	assert.Equal(t, registered[1].end+1, f.skipPhasesAfter(registered, registered[0], "Dependency"))
+	assert.Equal(t, registered[2].end+1, f.skipPhasesAfter(registered, registered[2], "Notify"))
+}
+
+func TestPhases_insertCustomPhases(t *testing.T) {
+	t.Parallel()
+
+	f := newTestPhases(
+		&kinds.Phase{Name: "Validate", Position: kinds.PhasePosition{Before: "Create-Resources"}},
+		&kinds.Phase{
+			Name:         "Backup",
+			Position:     kinds.PhasePosition{After: "DeletionComplete"},
+			Events:       []string{kinds.PhaseEventDelete},
+			RequeueDelay: "30s",
+		},
+		&kinds.Phase{Name: "Missing", Position: kinds.PhasePosition{After: "Unknown"}},
+	)
+
+	want := `package apps
+
+// InitializePhases defines what phases should be run for each event loop.
+func (r *WebAppReconciler) InitializePhases() {
+	// Create Phases
+	r.Phases.Register(
+		"Dependency",
+		WebAppDependencyPhase,
+		phases.CreateEvent,
+		phases.WithCustomRequeueResult(ctrl.Result{RequeueAfter: WebAppDependencyRequeueDelay}),
+	)
+
+	r.Phases.Register(
+		"Validate",
+		WebAppValidatePhase,
+		phases.CreateEvent,
+	)
+
+	r.Phases.Register(
+		"Create-Resources",
+		phases.CreateResourcesPhase,
+		phases.CreateEvent,
+	)
+
+	// Delete Phases
+	r.Phases.Register(
+		"DeletionComplete",
+		phases.DeletionCompletePhase,
+		phases.DeleteEvent,
+	)
+
+	r.Phases.Register(
+		"Backup",
+		WebAppBackupPhase,
+		phases.DeleteEvent,
+		phases.WithCustomRequeueResult(ctrl.Result{RequeueAfter: WebAppBackupRequeueDelay}),
+	)
+}
+`
+
+	updated := f.insertCustomPhases(testPhasesFile)
+	assert.Equal(t, want, updated)
+
+	// the phases which are already registered are not inserted again
+	assert.Equal(t, updated, f.insertCustomPhases(updated))
+}
+
+func TestPhases_updateDependencyHandlers(t *testing.T) {
+	t.Parallel()
+
+	content := `	r.Phases.Register(
+		"Dependency",
+		phases.DependencyPhase,
+		phases.CreateEvent,
+	)
+
+	r.Phases.Register(
+		"Create-Resources",
+		phases.CreateResourcesPhase,
+		phases.CreateEvent,
+	)
+
+	r.Phases.Register("Dependency", phases.DependencyPhase, phases.UpdateEvent)
+`
+
+	want := `	r.Phases.Register(
+		"Dependency",
+		WebAppDependencyPhase,
+		phases.CreateEvent,
+	)
+
+	r.Phases.Register(
+		"Create-Resources",
+		phases.CreateResourcesPhase,
+		phases.CreateEvent,
+	)
+
+	r.Phases.Register("Dependency", WebAppDependencyPhase, phases.UpdateEvent)
+`
+
+	f := newTestPhases()
+
+	updated := f.updateDependencyHandlers(content)
+	assert.Equal(t, want, updated)
+
+	// the registrations which already use the generated dependency phase are unchanged
+	assert.Equal(t, updated, f.updateDependencyHandlers(updated))
+	assert.Equal(t, testPhasesFile, f.updateDependencyHandlers(testPhasesFile))
+}
+
+func TestPhases_updateRequeueDelays(t *testing.T) {
+	t.Parallel()
+
+	content := `package apps
+
+import (
+	"time"
+
+	"github.com/nukleros/operator-builder-tools/pkg/controller/phases"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func (r *WebAppReconciler) InitializePhases() {
+	r.Phases.Register(
+		"Dependency",
+		WebAppDependencyPhase,
+		phases.CreateEvent,
+		phases.WithCustomRequeueResult(ctrl.Result{RequeueAfter: 5 * time.Second }),
+	)
+
+	r.Phases.Register(
+		"Check-Ready",
+		phases.CheckReadyPhase,
+		phases.CreateEvent,
+		phases.WithCustomRequeueResult(ctrl.Result{RequeueAfter: 5*time.Second}),
+	)
+}
+`
+
+	want := `package apps
+
+import (
+	"github.com/nukleros/operator-builder-tools/pkg/controller/phases"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func (r *WebAppReconciler) InitializePhases() {
+	r.Phases.Register(
+		"Dependency",
+		WebAppDependencyPhase,
+		phases.CreateEvent,
+		phases.WithCustomRequeueResult(ctrl.Result{RequeueAfter: WebAppDependencyRequeueDelay }),
+	)
+
+	r.Phases.Register(
+		"Check-Ready",
+		phases.CheckReadyPhase,
+		phases.CreateEvent,
+		phases.WithCustomRequeueResult(ctrl.Result{RequeueAfter: WebAppCheckReadyRequeueDelay}),
+	)
+}
+`
+
+	f := newTestPhases()
+
+	updated := f.updateRequeueDelays(content)
+	assert.Equal(t, want, updated)
+
+	// the registrations which already use the generated requeue delays are unchanged
+	assert.Equal(t, updated, f.updateRequeueDelays(updated))
+	assert.Equal(t, testPhasesFile, f.updateRequeueDelays(testPhasesFile))
+
+	// an edited requeue delay is kept along with the import of the time package
+	edited := strings.Replace(content, "5*time.Second", "time.Minute", 1)
+	assert.Contains(t, f.updateRequeueDelays(edited), "RequeueAfter: time.Minute}")
+	assert.Contains(t, f.updateRequeueDelays(edited), "\t\"time\"\n")
+}
+
+func TestPhases_SetTemplateDefaults(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join("controllers", "apps", "webapp_phases.go")
+	content := "// {{ docs }} for {{ .Resource.Kind }}\n" + testPhasesFile
+
+	tests := []struct {
+		name         string
+		existing     string
+		baseline     bool
+		wantTemplate string
+		wantExisting string
+		wantAction   machinery.IfExistsAction
+	}{
+		{
+			name:         "new file",
+			wantTemplate: phasesTemplate,
+			wantAction:   machinery.SkipFile,
+		},
+		{
+			name:         "file with a baseline",
+			existing:     content,
+			baseline:     true,
+			wantTemplate: phasesTemplate,
+			wantAction:   machinery.OverwriteFile,
+		},
+		{
+			name:         "file without missing phases",
+			existing:     content,
+			wantTemplate: phasesTemplate,
+			wantAction:   machinery.SkipFile,
+		},
+		{
+			name:         "file with the dependency phase of the library is updated",
+			existing:     strings.Replace(content, "WebAppDependencyPhase", "phases.DependencyPhase", 1),
+			wantTemplate: existingPhasesTemplate,
+			wantExisting: "\"Dependency\",\n\t\tWebAppDependencyPhase,",
+			wantAction:   machinery.OverwriteFile,
+		},
+		{
+			name:         "file with the scaffolded requeue delay is updated",
+			existing:     strings.Replace(content, "WebAppDependencyRequeueDelay", "5 * time.Second", 1),
+			wantTemplate: existingPhasesTemplate,
+			wantExisting: "RequeueAfter: WebAppDependencyRequeueDelay}",
+			wantAction:   machinery.OverwriteFile,
+		},
+		{
+			name:         "file with missing phases is written as is",
+			existing:     content,
+			wantTemplate: existingPhasesTemplate,
+			wantExisting: "r.Phases.Register(\n\t\t\"Validate\",",
+			wantAction:   machinery.OverwriteFile,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := newTestPhases()
+			if tt.wantExisting != "" {
+				f = newTestPhases(&kinds.Phase{Name: "Validate", Position: kinds.PhasePosition{Before: "Create-Resources"}})
+			}
+
+			if tt.existing != "" {
+				require.NoError(t, afero.WriteFile(f.FS, path, []byte(tt.existing), 0o644))
+			}
+
+			if tt.baseline {
+				require.NoError(t, afero.WriteFile(f.FS, baseline.Path(path), []byte(tt.existing), 0o644))
+			}
+
+			require.NoError(t, f.SetTemplateDefaults())
+			assert.Equal(t, path, f.Path)
+			assert.Equal(t, tt.wantTemplate, f.TemplateBody)
+			assert.Equal(t, tt.wantAction, f.IfExistsAction)
+
+			if tt.wantExisting != "" {
+				assert.Contains(t, f.Existing, "// {{ docs }} for {{ .Resource.Kind }}\n")
+				assert.Contains(t, f.Existing, tt.wantExisting)
+			}
+		})
+	}
+}
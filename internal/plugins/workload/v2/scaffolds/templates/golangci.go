@@ -20,8 +20,10 @@ limitations under the License.
 package templates
 
 import (
+	"github.com/spf13/afero"
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
 
+	"github.com/nukleros/operator-builder/internal/baseline"
 	commontemplates "github.com/nukleros/operator-builder/internal/plugins/workload/templates"
 )
 
@@ -31,6 +33,8 @@ var _ machinery.Template = &Golangci{}
 type Golangci struct {
 	machinery.TemplateMixin
 	machinery.ProjectNameMixin
+
+	FS afero.Fs
 }
 
 // SetTemplateDefaults implements machinery.Template.
@@ -41,7 +45,7 @@ func (f *Golangci) SetTemplateDefaults() error {
 
 	f.TemplateBody = commontemplates.Linter
 
-	f.IfExistsAction = machinery.SkipFile
+	f.IfExistsAction = baseline.IfExistsAction(f.FS, f.Path)
 
 	return nil
 }
@@ -7,8 +7,10 @@ import (
 	"fmt"
 	"path/filepath"
 
+	"github.com/spf13/afero"
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
 
+	"github.com/nukleros/operator-builder/internal/baseline"
 	"github.com/nukleros/operator-builder/internal/utils"
 )
 
@@ -21,6 +23,8 @@ type Component struct {
 	machinery.BoilerplateMixin
 	machinery.RepositoryMixin
 	machinery.ResourceMixin
+
+	FS afero.Fs
 }
 
 func (f *Component) SetTemplateDefaults() error {
@@ -32,7 +36,7 @@ func (f *Component) SetTemplateDefaults() error {
 
 	f.TemplateBody = componentTemplate
 
-	f.IfExistsAction = machinery.SkipFile
+	f.IfExistsAction = baseline.IfExistsAction(f.FS, f.Path)
 
 	return nil
 }
@@ -0,0 +1,139 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package dependencies
+
+import (
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+var _ machinery.Template = &External{}
+
+// External scaffolds the logic which determines whether the resources that are not managed by
+// the project, but are depended upon by a workload, are ready.
+type External struct {
+	machinery.TemplateMixin
+	machinery.BoilerplateMixin
+}
+
+func (f *External) SetTemplateDefaults() error {
+	f.Path = filepath.Join(
+		"internal",
+		"dependencies",
+		"external.go",
+	)
+
+	f.TemplateBody = externalTemplate
+
+	f.IfExistsAction = machinery.OverwriteFile
+
+	return nil
+}
+
+const externalTemplate = `{{ .Boilerplate }}
+
+package dependencies
+
+import (
+	"fmt"
+
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/nukleros/operator-builder-tools/pkg/controller/workload"
+)
+
+// ExternalDependency is a dependency upon a resource which is not managed by this operator, such
+// as a resource of an API which is installed by another operator.
+type ExternalDependency struct {
+	GroupVersionKind schema.GroupVersionKind
+
+	// Name is the name of the resource.  If empty, the dependency is satisfied once the API
+	// for the kind exists in the cluster.
+	Name      string
+	Namespace string
+
+	// Condition is the type of a status condition which must be True for the resource.
+	Condition string
+}
+
+// ExternalDependenciesSatisfied returns whether each of the external dependencies is satisfied.
+func ExternalDependenciesSatisfied(r workload.Reconciler, req *workload.Request, dependencies ...ExternalDependency) (bool, error) {
+	for _, dependency := range dependencies {
+		satisfied, err := dependency.satisfied(r, req)
+		if err != nil || !satisfied {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// satisfied returns whether an individual external dependency is satisfied.
+func (dependency ExternalDependency) satisfied(r workload.Reconciler, req *workload.Request) (bool, error) {
+	gvk := dependency.GroupVersionKind
+
+	// ensure the api for the kind exists in the cluster
+	if _, err := r.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version); err != nil {
+		if meta.IsNoMatchError(err) {
+			req.Log.Info("waiting for api of external dependency", "kind", gvk.String())
+
+			return false, nil
+		}
+
+		return false, fmt.Errorf("unable to find api of external dependency %s, %w", gvk, err)
+	}
+
+	if dependency.Name == "" {
+		return true, nil
+	}
+
+	// ensure the resource exists in the cluster
+	resource := &unstructured.Unstructured{}
+	resource.SetGroupVersionKind(gvk)
+
+	key := client.ObjectKey{Name: dependency.Name, Namespace: dependency.Namespace}
+
+	if err := r.Get(req.Context, key, resource); err != nil {
+		if apierrs.IsNotFound(err) {
+			req.Log.Info("waiting for external dependency", "kind", gvk.String(), "name", key.String())
+
+			return false, nil
+		}
+
+		return false, fmt.Errorf("unable to get external dependency %s %s, %w", gvk.Kind, key, err)
+	}
+
+	if dependency.Condition == "" {
+		return true, nil
+	}
+
+	// ensure the requested condition of the resource is true
+	conditions, _, err := unstructured.NestedSlice(resource.Object, "status", "conditions")
+	if err != nil {
+		return false, fmt.Errorf("unable to retrieve status.conditions field of external dependency %s %s, %w", gvk.Kind, key, err)
+	}
+
+	for _, condition := range conditions {
+		fields, ok := condition.(map[string]interface{})
+		if !ok || fields["type"] != dependency.Condition {
+			continue
+		}
+
+		if fields["status"] == "True" {
+			return true, nil
+		}
+	}
+
+	req.Log.Info("waiting for condition of external dependency",
+		"kind", gvk.String(), "name", key.String(), "condition", dependency.Condition,
+	)
+
+	return false, nil
+}
+`
@@ -0,0 +1,317 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package metadata
+
+import (
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+)
+
+var _ machinery.Template = &Common{}
+
+// Common scaffolds the logic which applies the labels, annotations and name prefix that are
+// common to all of the child resources of a workload.
+type Common struct {
+	machinery.TemplateMixin
+	machinery.BoilerplateMixin
+}
+
+func (f *Common) SetTemplateDefaults() error {
+	f.Path = filepath.Join(
+		"internal",
+		"metadata",
+		"common.go",
+	)
+
+	f.TemplateBody = commonTemplate
+
+	f.IfExistsAction = machinery.OverwriteFile
+
+	return nil
+}
+
+const commonTemplate = `{{ .Boilerplate }}
+
+package metadata
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Common is the metadata which is applied to all of the child resources of a workload.
+type Common struct {
+	// Labels are applied to the child resources and to their label selectors.
+	Labels map[string]string
+
+	// ExtraLabels are applied to the child resources, but not to their label selectors, so that
+	// they may be changed once the child resources exist.
+	ExtraLabels map[string]string
+
+	// Annotations and ExtraAnnotations are applied to the child resources.
+	Annotations      map[string]string
+	ExtraAnnotations map[string]string
+
+	// NamePrefix is prepended to the names of the child resources and to the references to them
+	// from other child resources.
+	NamePrefix string
+}
+
+// labelPaths are the paths to the label selectors of a kind, along with the labels of the pods
+// which it manages.
+var labelPaths = map[string][]string{
+	"Service":               {"spec.selector"},
+	"ReplicationController": {"spec.selector", "spec.template.metadata.labels"},
+	"Deployment":            {"spec.selector.matchLabels", "spec.template.metadata.labels"},
+	"ReplicaSet":            {"spec.selector.matchLabels", "spec.template.metadata.labels"},
+	"DaemonSet":             {"spec.selector.matchLabels", "spec.template.metadata.labels"},
+	"StatefulSet":           {"spec.selector.matchLabels", "spec.template.metadata.labels"},
+	"Job":                   {"spec.template.metadata.labels"},
+	"CronJob":               {"spec.jobTemplate.metadata.labels", "spec.jobTemplate.spec.template.metadata.labels"},
+	"PodDisruptionBudget":   {"spec.selector.matchLabels"},
+	"NetworkPolicy":         {"spec.podSelector.matchLabels"},
+}
+
+// podSpecPaths are the paths to the pod spec of a kind which manages pods.
+var podSpecPaths = map[string]string{
+	"Pod":                   "spec",
+	"ReplicationController": "spec.template.spec",
+	"Deployment":            "spec.template.spec",
+	"ReplicaSet":            "spec.template.spec",
+	"DaemonSet":             "spec.template.spec",
+	"StatefulSet":           "spec.template.spec",
+	"Job":                   "spec.template.spec",
+	"CronJob":               "spec.jobTemplate.spec.template.spec",
+}
+
+// Apply applies the common metadata to the child resources of a workload.  The label selectors of
+// and the references to other child resources from a child resource are only updated when the
+// child resource is unstructured.
+func Apply(objects []client.Object, common Common) error {
+	// record the names of the child resources prior to prefixing them, so that only the
+	// references to child resources are prefixed
+	names := map[string]bool{}
+
+	for _, object := range objects {
+		names[nameKey(object.GetObjectKind().GroupVersionKind().Kind, object.GetName())] = true
+	}
+
+	for _, object := range objects {
+		kind := object.GetObjectKind().GroupVersionKind().Kind
+
+		object.SetLabels(merge(object.GetLabels(), common.Labels, common.ExtraLabels))
+		object.SetAnnotations(merge(object.GetAnnotations(), common.Annotations, common.ExtraAnnotations))
+
+		if common.NamePrefix != "" {
+			object.SetName(prefix(kind, object.GetName(), common.NamePrefix))
+		}
+
+		resource, ok := object.(*unstructured.Unstructured)
+		if !ok {
+			continue
+		}
+
+		if err := applyLabels(resource, kind, common.Labels); err != nil {
+			return err
+		}
+
+		if common.NamePrefix != "" {
+			if err := prefixReferences(resource.Object, kind, common.NamePrefix, names); err != nil {
+				return fmt.Errorf("unable to prefix references of %s %s, %w", kind, resource.GetName(), err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// merge returns the values of each of the maps, where the values of later maps take precedence.
+func merge(maps ...map[string]string) map[string]string {
+	merged := map[string]string{}
+
+	for _, values := range maps {
+		for key, value := range values {
+			merged[key] = value
+		}
+	}
+
+	if len(merged) == 0 {
+		return nil
+	}
+
+	return merged
+}
+
+// prefix returns the name of a child resource with the name prefix applied.  The names of
+// namespaces and custom resource definitions are never prefixed.
+func prefix(kind, name, namePrefix string) string {
+	if kind == "Namespace" || kind == "CustomResourceDefinition" {
+		return name
+	}
+
+	return namePrefix + name
+}
+
+// applyLabels applies labels to the label selectors of a child resource.  Selectors which do not
+// exist are not created, as an omitted selector may have a meaning of its own, whereas the labels
+// of a pod template are always created.
+func applyLabels(resource *unstructured.Unstructured, kind string, labels map[string]string) error {
+	if len(labels) == 0 {
+		return nil
+	}
+
+	for _, path := range labelPaths[kind] {
+		fields := strings.Split(path, ".")
+
+		existing, found, err := unstructured.NestedStringMap(resource.Object, fields...)
+		if err != nil {
+			return fmt.Errorf("unable to apply labels to %s %s, %w", kind, resource.GetName(), err)
+		}
+
+		if !found && !strings.HasSuffix(path, "metadata.labels") {
+			continue
+		}
+
+		if err := unstructured.SetNestedStringMap(resource.Object, merge(existing, labels), fields...); err != nil {
+			return fmt.Errorf("unable to apply labels to %s %s, %w", kind, resource.GetName(), err)
+		}
+	}
+
+	return nil
+}
+
+// prefixReferences prefixes the names within a child resource which reference other child
+// resources.
+func prefixReferences(object map[string]interface{}, kind, namePrefix string, names map[string]bool) error {
+	r := &referencePrefixer{namePrefix: namePrefix, names: names}
+
+	if path, ok := podSpecPaths[kind]; ok {
+		fields := strings.Split(path, ".")
+
+		if podSpec, found, _ := unstructured.NestedMap(object, fields...); found {
+			r.prefixPodSpec(podSpec)
+
+			if err := unstructured.SetNestedMap(object, podSpec, fields...); err != nil {
+				return err
+			}
+		}
+	}
+
+	switch kind {
+	case "StatefulSet":
+		r.prefix(object, "Service", "spec.serviceName")
+	case "RoleBinding", "ClusterRoleBinding":
+		if roleKind, found, _ := unstructured.NestedString(object, "roleRef", "kind"); found {
+			r.prefix(object, roleKind, "roleRef.name")
+		}
+
+		r.prefixEach(object, "subjects", func(subject map[string]interface{}) {
+			if subject["kind"] == "ServiceAccount" {
+				r.prefix(subject, "ServiceAccount", "name")
+			}
+		})
+	case "Ingress":
+		r.prefix(object, "Service", "spec.defaultBackend.service.name")
+
+		r.prefixEach(object, "spec.rules", func(rule map[string]interface{}) {
+			r.prefixEach(rule, "http.paths", func(path map[string]interface{}) {
+				r.prefix(path, "Service", "backend.service.name")
+			})
+		})
+
+		r.prefixEach(object, "spec.tls", func(tls map[string]interface{}) {
+			r.prefix(tls, "Secret", "secretName")
+		})
+	case "HorizontalPodAutoscaler":
+		if targetKind, found, _ := unstructured.NestedString(object, "spec", "scaleTargetRef", "kind"); found {
+			r.prefix(object, targetKind, "spec.scaleTargetRef.name")
+		}
+	}
+
+	return nil
+}
+
+// referencePrefixer prefixes the references to child resources.
+type referencePrefixer struct {
+	namePrefix string
+	names      map[string]bool
+}
+
+// prefixPodSpec prefixes the references to child resources within a pod spec.
+func (r *referencePrefixer) prefixPodSpec(podSpec map[string]interface{}) {
+	r.prefix(podSpec, "ServiceAccount", "serviceAccountName")
+
+	r.prefixEach(podSpec, "imagePullSecrets", func(secret map[string]interface{}) {
+		r.prefix(secret, "Secret", "name")
+	})
+
+	r.prefixEach(podSpec, "volumes", func(volume map[string]interface{}) {
+		r.prefix(volume, "ConfigMap", "configMap.name")
+		r.prefix(volume, "Secret", "secret.secretName")
+		r.prefix(volume, "PersistentVolumeClaim", "persistentVolumeClaim.claimName")
+
+		r.prefixEach(volume, "projected.sources", func(source map[string]interface{}) {
+			r.prefix(source, "ConfigMap", "configMap.name")
+			r.prefix(source, "Secret", "secret.name")
+		})
+	})
+
+	for _, containers := range []string{"initContainers", "containers"} {
+		r.prefixEach(podSpec, containers, func(container map[string]interface{}) {
+			r.prefixEach(container, "envFrom", func(source map[string]interface{}) {
+				r.prefix(source, "ConfigMap", "configMapRef.name")
+				r.prefix(source, "Secret", "secretRef.name")
+			})
+
+			r.prefixEach(container, "env", func(env map[string]interface{}) {
+				r.prefix(env, "ConfigMap", "valueFrom.configMapKeyRef.name")
+				r.prefix(env, "Secret", "valueFrom.secretKeyRef.name")
+			})
+		})
+	}
+}
+
+// prefix prefixes the name at a path when it references a child resource of a kind.
+func (r *referencePrefixer) prefix(object map[string]interface{}, kind, path string) {
+	fields := strings.Split(path, ".")
+
+	name, found, err := unstructured.NestedString(object, fields...)
+	if err != nil || !found || !r.names[nameKey(kind, name)] {
+		return
+	}
+
+	_ = unstructured.SetNestedField(object, prefix(kind, name, r.namePrefix), fields...)
+}
+
+// prefixEach calls a function for each of the objects within the list at a path.
+func (r *referencePrefixer) prefixEach(
+	object map[string]interface{},
+	path string,
+	prefixFunc func(map[string]interface{}),
+) {
+	fields := strings.Split(path, ".")
+
+	items, found, err := unstructured.NestedSlice(object, fields...)
+	if err != nil || !found {
+		return
+	}
+
+	for _, item := range items {
+		if item, ok := item.(map[string]interface{}); ok {
+			prefixFunc(item)
+		}
+	}
+
+	_ = unstructured.SetNestedSlice(object, items, fields...)
+}
+
+// nameKey returns the key which identifies a child resource by its kind and name.
+func nameKey(kind, name string) string {
+	return kind + "/" + name
+}
+`
@@ -7,8 +7,10 @@ import (
 	"fmt"
 	"path/filepath"
 
+	"github.com/spf13/afero"
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
 
+	"github.com/nukleros/operator-builder/internal/baseline"
 	"github.com/nukleros/operator-builder/internal/utils"
 )
 
@@ -20,6 +22,8 @@ type Component struct {
 	machinery.BoilerplateMixin
 	machinery.RepositoryMixin
 	machinery.ResourceMixin
+
+	FS afero.Fs
 }
 
 func (f *Component) SetTemplateDefaults() error {
@@ -31,7 +35,7 @@ func (f *Component) SetTemplateDefaults() error {
 
 	f.TemplateBody = componentTemplate
 
-	f.IfExistsAction = machinery.SkipFile
+	f.IfExistsAction = baseline.IfExistsAction(f.FS, f.Path)
 
 	return nil
 }
@@ -18,6 +18,7 @@ const (
 	addSchemeMarker = "scheme"
 	setupMarker     = "reconcilers"
 	webhookMarker   = "webhook"
+	cacheMarker     = "cache"
 )
 
 var _ machinery.Template = &Main{}
@@ -38,6 +39,7 @@ func (f *Main) SetTemplateDefaults() error {
 	f.TemplateBody = fmt.Sprintf(mainTemplate,
 		machinery.NewMarkerFor(f.Path, importMarker),
 		machinery.NewMarkerFor(f.Path, addSchemeMarker),
+		machinery.NewMarkerFor(f.Path, cacheMarker),
 		machinery.NewMarkerFor(f.Path, setupMarker),
 		machinery.NewMarkerFor(f.Path, webhookMarker),
 	)
@@ -56,6 +58,10 @@ type MainUpdater struct {
 
 	// Flags to indicate which parts need to be included when updating the file
 	WireResource, WireController, WireWebhook bool
+
+	// WireNamespacedCache restricts the cache of the manager to its own namespace, for a workload
+	// with namespace scoped RBAC.
+	WireNamespacedCache bool
 }
 
 func (*MainUpdater) GetPath() string {
@@ -70,6 +76,7 @@ func (f *MainUpdater) GetMarkers() []machinery.Marker {
 	return []machinery.Marker{
 		machinery.NewMarkerFor(defaultMainPath, importMarker),
 		machinery.NewMarkerFor(defaultMainPath, addSchemeMarker),
+		machinery.NewMarkerFor(defaultMainPath, cacheMarker),
 		machinery.NewMarkerFor(defaultMainPath, setupMarker),
 		machinery.NewMarkerFor(defaultMainPath, webhookMarker),
 	}
@@ -83,6 +90,10 @@ const (
 	multiGroupControllerImportCodeFragment = `%scontrollers "%s/controllers/%s"
 `
 	addschemeCodeFragment = `utilruntime.Must(%s.AddToScheme(scheme))
+`
+	namespacedCacheCodeFragment = `if namespace, err := os.ReadFile("/var/run/secrets/kubernetes.io/serviceaccount/namespace"); err == nil {
+		cacheOptions.DefaultNamespaces = map[string]cache.Config{string(namespace): {}}
+	}
 `
 	reconcilerSetupCodeFragment = `controllers.New%sReconciler(mgr),
 `
@@ -102,7 +113,7 @@ const (
 
 //nolint:gocyclo
 func (f *MainUpdater) GetCodeFragments() machinery.CodeFragmentsMap {
-	const options = 4
+	const options = 5
 
 	fragments := make(machinery.CodeFragmentsMap, options)
 
@@ -132,6 +143,12 @@ func (f *MainUpdater) GetCodeFragments() machinery.CodeFragmentsMap {
 		addScheme = append(addScheme, fmt.Sprintf(addschemeCodeFragment, f.Resource.ImportAlias()))
 	}
 
+	// Generate cache code fragments
+	cacheSetup := make([]string, 0)
+	if f.WireNamespacedCache {
+		cacheSetup = append(cacheSetup, namespacedCacheCodeFragment)
+	}
+
 	// Generate setup code fragments
 	setup := make([]string, 0)
 
@@ -177,6 +194,10 @@ func (f *MainUpdater) GetCodeFragments() machinery.CodeFragmentsMap {
 		fragments[machinery.NewMarkerFor(defaultMainPath, addSchemeMarker)] = addScheme
 	}
 
+	if len(cacheSetup) != 0 {
+		fragments[machinery.NewMarkerFor(defaultMainPath, cacheMarker)] = cacheSetup
+	}
+
 	if len(setup) != 0 {
 		fragments[machinery.NewMarkerFor(defaultMainPath, setupMarker)] = setup
 	}
@@ -218,6 +239,7 @@ import (
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
@@ -339,8 +361,14 @@ func main() {
 		metricsServerOptions.FilterProvider = filters.WithAuthenticationAndAuthorization
 	}
 
+	// The cache is restricted to the namespace of the manager when the RBAC of a workload is
+	// namespace scoped, as the manager may not list or watch its resources in other namespaces.
+	cacheOptions := cache.Options{}
+	%s
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                 scheme,
+		Cache:                  cacheOptions,
 		Metrics:                metricsServerOptions,
 		WebhookServer:          webhookServer,
 		HealthProbeBindAddress: probeAddr,
@@ -7,9 +7,11 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/spf13/afero"
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
 	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
 
+	"github.com/nukleros/operator-builder/internal/baseline"
 	"github.com/nukleros/operator-builder/internal/utils"
 	"github.com/nukleros/operator-builder/internal/workload/v1/kinds"
 )
@@ -32,6 +34,7 @@ type WorkloadTest struct {
 
 	// input fields
 	Builder kinds.WorkloadBuilder
+	FS      afero.Fs
 
 	// template fields
 	TesterName                string
@@ -60,7 +63,7 @@ func (f *WorkloadTest) SetTemplateDefaults() error {
 		strings.ToLower(f.Resource.Kind),
 	)
 
-	f.IfExistsAction = machinery.SkipFile
+	f.IfExistsAction = baseline.IfExistsAction(f.FS, f.Path)
 	f.TemplateBody = e2eWorkloadsTemplate
 
 	return nil
@@ -25,6 +25,8 @@ import (
 	"strings"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+
+	"github.com/nukleros/operator-builder/internal/workload/v1/kinds"
 )
 
 var _ machinery.Template = &Webhook{}
@@ -43,6 +45,10 @@ type Webhook struct {
 	AdmissionReviewVersions string
 
 	Force bool
+
+	// Markers is the logic of the webhooks which is generated from the webhook markers.  If it
+	// is nil, the defaulting and validating logic is left to the user.
+	Markers *kinds.Webhook
 }
 
 // SetTemplateDefaults implements machinery.Template.
@@ -93,6 +99,9 @@ import (
 	{{- end }}
 
 	ctrl "sigs.k8s.io/controller-runtime"
+	{{- if and .Markers .Resource.HasValidationWebhook }}
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	{{- end }}
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	{{- if .Resource.HasValidationWebhook }}
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -114,7 +123,7 @@ func Setup{{ .Resource.Kind }}WebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr, &{{ .Resource.Kind }}{}).
 	{{- end }}
 		{{- if .Resource.HasValidationWebhook }}
-		WithValidator(&{{ .Resource.Kind }}Validator{}).
+		WithValidator(&{{ .Resource.Kind }}Validator{ {{- if .Markers }}Client: mgr.GetClient(){{ end -}} }).
 		{{- if ne .Resource.Webhooks.ValidationPath "" }}
 		WithValidatorCustomPath("{{ .Resource.Webhooks.ValidationPath }}").
 		{{- end }}
@@ -147,6 +156,10 @@ type {{ .Resource.Kind }}Defaulter struct {
 // Default implements admission.Defaulter so a webhook will be registered for the Kind {{ .Resource.Kind }}.
 func (d *{{ .Resource.Kind }}Defaulter) Default(_ context.Context, obj *{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}) error {
 	{{ lower .Resource.Kind }}log.Info("Defaulting for {{ .Resource.Kind }}", "name", obj.GetName())
+	{{- if .Markers }}
+
+	default{{ .Resource.Kind }}FromMarkers(obj)
+	{{- end }}
 
 	// TODO(user): fill in your defaulting logic.
 
@@ -166,25 +179,42 @@ func (d *{{ .Resource.Kind }}Defaulter) Default(_ context.Context, obj *{{ .Reso
 // NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
 // as this struct is used only for temporary operations and does not need to be deeply copied.
 type {{ .Resource.Kind }}Validator struct{
+	{{- if .Markers }}
+	// Client reads the objects which are looked up by the validations of the webhook markers.
+	Client client.Reader
+
+	{{- end }}
 	// TODO(user): Add more fields as needed for validation
 }
 
 // ValidateCreate implements admission.Validator so a webhook will be registered for the type {{ .Resource.Kind }}.
-func (v *{{ .Resource.Kind }}Validator) ValidateCreate(_ context.Context, obj *{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}) (admission.Warnings, error) {
+func (v *{{ .Resource.Kind }}Validator) ValidateCreate({{ if .Markers }}ctx{{ else }}_{{ end }} context.Context, obj *{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}) (admission.Warnings, error) {
 	{{ lower .Resource.Kind }}log.Info("Validation for {{ .Resource.Kind }} upon creation", "name", obj.GetName())
 
 	// TODO(user): fill in your validation logic upon object creation.
 
+	{{- if .Markers }}
+
+	return validate{{ .Resource.Kind }}FromMarkers(ctx, v.Client, obj)
+	{{- else }}
+
 	return nil, nil
+	{{- end }}
 }
 
 // ValidateUpdate implements admission.Validator so a webhook will be registered for the type {{ .Resource.Kind }}.
-func (v *{{ .Resource.Kind }}Validator) ValidateUpdate(_ context.Context, oldObj, newObj *{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}) (admission.Warnings, error) {
+func (v *{{ .Resource.Kind }}Validator) ValidateUpdate({{ if .Markers }}ctx{{ else }}_{{ end }} context.Context, oldObj, newObj *{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}) (admission.Warnings, error) {
 	{{ lower .Resource.Kind }}log.Info("Validation for {{ .Resource.Kind }} upon update", "name", newObj.GetName())
 
 	// TODO(user): fill in your validation logic upon object update.
 
+	{{- if .Markers }}
+
+	return validate{{ .Resource.Kind }}FromMarkers(ctx, v.Client, newObj)
+	{{- else }}
+
 	return nil, nil
+	{{- end }}
 }
 
 // ValidateDelete implements admission.Validator so a webhook will be registered for the type {{ .Resource.Kind }}.
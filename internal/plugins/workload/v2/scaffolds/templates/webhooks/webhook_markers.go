@@ -0,0 +1,177 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package webhooks
+
+import (
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+
+	"github.com/nukleros/operator-builder/internal/workload/v1/kinds"
+)
+
+var _ machinery.Template = &WebhookMarkers{}
+
+// WebhookMarkers scaffolds the file that defines the defaulting and validating logic which is
+// generated from the webhook markers of a workload.  It is regenerated each time and is called
+// from the webhook file which is owned by the user.
+type WebhookMarkers struct {
+	machinery.TemplateMixin
+	machinery.MultiGroupMixin
+	machinery.BoilerplateMixin
+	machinery.ResourceMixin
+
+	// Markers is the logic of the webhooks which is generated from the webhook markers.
+	Markers *kinds.Webhook
+}
+
+// SetTemplateDefaults implements machinery.Template.
+func (f *WebhookMarkers) SetTemplateDefaults() error {
+	if f.Path == "" {
+		baseDir := filepath.Join("internal", "webhook")
+
+		if f.MultiGroup && f.Resource.Group != "" {
+			f.Path = filepath.Join(baseDir, "%[group]", "%[version]", "%[kind]_webhook_markers.go")
+		} else {
+			f.Path = filepath.Join(baseDir, "%[version]", "%[kind]_webhook_markers.go")
+		}
+	}
+
+	f.Path = f.Resource.Replacer().Replace(f.Path)
+
+	f.TemplateBody = webhookMarkersTemplate
+	f.IfExistsAction = machinery.OverwriteFile
+
+	return nil
+}
+
+//nolint:lll
+const webhookMarkersTemplate = `{{ .Boilerplate }}
+
+// Code generated by operator-builder from the webhook markers of the workload manifests. DO NOT EDIT.
+
+package {{ .Resource.Version }}
+
+import (
+	"context"
+	{{- if or .Markers.HasFormat .Markers.HasLookups }}
+	"fmt"
+	{{- end }}
+
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	{{- if .Markers.HasReferences }}
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	{{- end }}
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	{{ .Resource.ImportAlias }} "{{ .Resource.Path }}"
+)
+
+// default{{ .Resource.Kind }}FromMarkers sets the defaults of a {{ .Resource.Kind }} which are given by the
+// +operator-builder:webhook:default markers of the workload manifests.
+func default{{ .Resource.Kind }}FromMarkers(obj *{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}) {
+{{- range $i, $default := .Markers.Defaults }}
+{{- if $i }}
+{{ end }}
+{{- if .Merge }}
+	// merge the default entries of {{ .Path }} into the entries which are given
+	if obj.{{ .Selector }} == nil {
+		obj.{{ .Selector }} = map[string]string{}
+	}
+
+	for key, value := range map[string]string{
+		{{- range $key, $value := .Merge }}
+		{{ printf "%q" $key }}: {{ printf "%q" $value }},
+		{{- end }}
+	} {
+		if _, found := obj.{{ .Selector }}[key]; !found {
+			obj.{{ .Selector }}[key] = value
+		}
+	}
+{{- else }}
+	// compute {{ .Path }} when it is not given
+	if obj.{{ .Selector }} == {{ .ZeroValue }} {
+		{{- if .Format }}
+		obj.{{ .Selector }} = fmt.Sprintf({{ printf "%q" .Format }}, obj.{{ .From }})
+		{{- else }}
+		obj.{{ .Selector }} = obj.{{ .From }}
+		{{- end }}
+	}
+{{- end }}
+{{- end }}
+}
+
+// validate{{ .Resource.Kind }}FromMarkers performs the validations of a {{ .Resource.Kind }} which are given by
+// the +operator-builder:webhook:validate markers of the workload manifests.
+func validate{{ .Resource.Kind }}FromMarkers(
+	ctx context.Context,
+	reader client.Reader,
+	obj *{{ .Resource.ImportAlias }}.{{ .Resource.Kind }},
+) (admission.Warnings, error) {
+	var errs field.ErrorList
+{{ range .Markers.Validations }}
+{{- if .Reference }}
+	// {{ .Path }} must reference an existing {{ .Reference.Kind }}
+	if value := obj.{{ .Selector }}; value != {{ .ZeroValue }} {
+		ref := &unstructured.Unstructured{}
+		ref.SetAPIVersion("{{ .Reference.APIVersion }}")
+		ref.SetKind("{{ .Reference.Kind }}")
+
+		{{ if .Reference.ClusterScoped -}}
+		key := client.ObjectKey{Name: value}
+		{{- else -}}
+		key := client.ObjectKey{Namespace: obj.GetNamespace(), Name: value}
+		{{- end }}
+		if err := reader.Get(ctx, key, ref); err != nil {
+			if !apierrs.IsNotFound(err) {
+				return nil, fmt.Errorf("unable to get {{ .Reference.Kind }} %s: %w", value, err)
+			}
+
+			errs = append(errs, field.Invalid(field.NewPath({{ .FieldPath }}), value,
+				{{ if .Message }}{{ printf "%q" .Message }}{{ else }}"must reference an existing {{ .Reference.Kind }}"{{ end }}))
+		}
+	}
+{{ end }}
+{{- if .Unique }}
+	// {{ .Path }} must be unique among the {{ $.Resource.Kind }} resources{{ if not $.Markers.ClusterScoped }} in the namespace{{ end }}
+	if value := obj.{{ .Selector }}; value != {{ .ZeroValue }} {
+		others := &{{ $.Resource.ImportAlias }}.{{ $.Resource.Kind }}List{}
+		{{ if $.Markers.ClusterScoped -}}
+		if err := reader.List(ctx, others); err != nil {
+		{{- else -}}
+		if err := reader.List(ctx, others, client.InNamespace(obj.GetNamespace())); err != nil {
+		{{- end }}
+			return nil, fmt.Errorf("unable to list {{ $.Resource.Kind }} resources: %w", err)
+		}
+
+		for i := range others.Items {
+			other := &others.Items[i]
+			if other.GetNamespace() == obj.GetNamespace() && other.GetName() == obj.GetName() {
+				continue
+			}
+
+			if other.{{ .Selector }} == value {
+				errs = append(errs, field.Invalid(field.NewPath({{ .FieldPath }}), value,
+					{{ if .Message }}{{ printf "%q" .Message }}{{ else }}"must be unique among {{ $.Resource.Kind }} resources{{ if not $.Markers.ClusterScoped }} in the namespace{{ end }}"{{ end }}))
+
+				break
+			}
+		}
+	}
+{{ end }}
+{{- end }}
+	if len(errs) == 0 {
+		return nil, nil
+	}
+
+	return nil, apierrs.NewInvalid(
+		schema.GroupKind{Group: {{ .Resource.ImportAlias }}.GroupVersion.Group, Kind: "{{ .Resource.Kind }}"},
+		obj.GetName(),
+		errs,
+	)
+}
+`
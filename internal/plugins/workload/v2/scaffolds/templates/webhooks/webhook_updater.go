@@ -29,6 +29,8 @@ import (
 	"strings"
 
 	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+
+	"github.com/nukleros/operator-builder/internal/workload/v1/kinds"
 )
 
 const coreGroup = "core"
@@ -48,6 +50,10 @@ type WebhookUpdater struct {
 
 	// AdmissionReviewVersions defines value for AdmissionReviewVersions marker
 	AdmissionReviewVersions string
+
+	// Markers is the logic of the webhooks which is generated from the webhook markers.  If it
+	// is nil, the defaulting and validating logic is left to the user.
+	Markers *kinds.Webhook
 }
 
 // GetPath implements file.Builder.
@@ -117,6 +123,12 @@ func (f *WebhookUpdater) applyDefaultingWebhook(content []byte, fileContent stri
 	if typeDefPattern.MatchString(string(content)) {
 		log.Info("Defaulting webhook already exists, skipping", "kind", f.Resource.Kind)
 
+		if f.Markers != nil && !strings.Contains(fileContent, fmt.Sprintf("default%sFromMarkers(", f.Resource.Kind)) {
+			log.Warn("Defaulting webhook does not apply the defaults of the webhook markers",
+				"kind", f.Resource.Kind,
+				"suggestion", fmt.Sprintf("Call default%sFromMarkers(obj) from the Default method", f.Resource.Kind))
+		}
+
 		return fileContent
 	}
 
@@ -140,6 +152,12 @@ func (f *WebhookUpdater) applyValidationWebhook(content []byte, fileContent stri
 	if typeDefPattern.MatchString(string(content)) {
 		log.Info("Validation webhook already exists, skipping", "kind", f.Resource.Kind)
 
+		if f.Markers != nil && !strings.Contains(fileContent, fmt.Sprintf("validate%sFromMarkers(", f.Resource.Kind)) {
+			log.Warn("Validation webhook does not perform the validations of the webhook markers",
+				"kind", f.Resource.Kind,
+				"suggestion", fmt.Sprintf("Call validate%sFromMarkers(ctx, client, obj) from the Validate methods", f.Resource.Kind))
+		}
+
 		return fileContent
 	}
 
@@ -147,12 +165,16 @@ func (f *WebhookUpdater) applyValidationWebhook(content []byte, fileContent stri
 		fileContent = f.addAdmissionImport(fileContent)
 	}
 
+	if f.Markers != nil {
+		fileContent = f.addClientImport(fileContent)
+	}
+
 	if validationCode := f.generateValidationWebhookCode(); validationCode != "" {
 		newCode.WriteString(validationCode)
 	}
 
 	setupCode := f.generateValidatorSetupCode()
-	if !strings.Contains(fileContent, fmt.Sprintf("WithValidator(&%s{})", validatorType)) {
+	if !strings.Contains(fileContent, fmt.Sprintf("WithValidator(&%s{", validatorType)) {
 		fileContent = f.injectBeforeComplete(fileContent, setupCode)
 	}
 
@@ -291,6 +313,26 @@ func (f *WebhookUpdater) addContextImport(content string) string {
 	return content
 }
 
+// addClientImport adds the client package import required by the validations of the webhook markers.
+func (f *WebhookUpdater) addClientImport(content string) string {
+	const clientImport = `"sigs.k8s.io/controller-runtime/pkg/client"`
+	if strings.Contains(content, clientImport) {
+		return content
+	}
+
+	ctrlPattern := regexp.MustCompile(`(?m)^([ \t]*)ctrl "sigs\.k8s\.io/controller-runtime"`)
+
+	if match := ctrlPattern.FindStringSubmatch(content); len(match) > 1 {
+		return strings.Replace(content, match[0], match[0]+"\n"+match[1]+clientImport, 1)
+	}
+
+	log.Warn("Could not add client import",
+		"kind", f.Resource.Kind,
+		"suggestion", "Manually add: sigs.k8s.io/controller-runtime/pkg/client")
+
+	return content
+}
+
 // generateDefaulterSetupCode generates the setup code for defaulting webhook.
 func (f *WebhookUpdater) generateDefaulterSetupCode() string {
 	code := fmt.Sprintf("\t\tWithDefaulter(&%sDefaulter{}).", f.Resource.Kind)
@@ -304,6 +346,10 @@ func (f *WebhookUpdater) generateDefaulterSetupCode() string {
 // generateValidatorSetupCode generates the setup code for validation webhook.
 func (f *WebhookUpdater) generateValidatorSetupCode() string {
 	code := fmt.Sprintf("\t\tWithValidator(&%sValidator{}).", f.Resource.Kind)
+	if f.Markers != nil {
+		code = fmt.Sprintf("\t\tWithValidator(&%sValidator{Client: mgr.GetClient()}).", f.Resource.Kind)
+	}
+
 	if f.Resource.Webhooks.ValidationPath != "" {
 		code += fmt.Sprintf("\n\t\tWithValidatorCustomPath(%q).", f.Resource.Webhooks.ValidationPath)
 	}
@@ -349,17 +395,22 @@ type %sDefaulter struct {
 	// Default method
 	objType := f.Resource.ImportAlias() + "." + f.Resource.Kind
 
+	var markersCode string
+	if f.Markers != nil {
+		markersCode = fmt.Sprintf("\n\tdefault%sFromMarkers(obj)\n", f.Resource.Kind)
+	}
+
 	fmt.Fprintf(&code, `// Default implements admission.Defaulter so a webhook will be registered for the Kind %s.
 func (d *%sDefaulter) Default(_ context.Context, obj *%s) error {
 	%slog.Info("Defaulting for %s", "name", obj.GetName())
-
+%s
 	// TODO(user): fill in your defaulting logic.
 
 	return nil
 }
 `,
 		f.Resource.Kind, f.Resource.Kind, objType,
-		strings.ToLower(f.Resource.Kind), f.Resource.Kind)
+		strings.ToLower(f.Resource.Kind), f.Resource.Kind, markersCode)
 
 	return code.String()
 }
@@ -393,34 +444,41 @@ func (f *WebhookUpdater) generateValidationWebhookCode() string {
 // NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
 // as this struct is used only for temporary operations and does not need to be deeply copied.
 type %sValidator struct{
-	// TODO(user): Add more fields as needed for validation
+%s	// TODO(user): Add more fields as needed for validation
 }
 
 `,
 		validationPath, f.getGroupValue(), f.Resource.Plural, f.Resource.Version,
 		strings.ToLower(f.Resource.Kind), f.Resource.Version, f.AdmissionReviewVersions,
-		f.Resource.Kind, f.Resource.Kind, f.Resource.Kind)
+		f.Resource.Kind, f.Resource.Kind, f.Resource.Kind, f.generateValidatorFields())
 
 	// Validation methods
 	objType := f.Resource.ImportAlias() + "." + f.Resource.Kind
 
+	ctxName, createReturn, updateReturn := "_", "nil, nil", "nil, nil"
+	if f.Markers != nil {
+		ctxName = "ctx"
+		createReturn = fmt.Sprintf("validate%sFromMarkers(ctx, v.Client, obj)", f.Resource.Kind)
+		updateReturn = fmt.Sprintf("validate%sFromMarkers(ctx, v.Client, newObj)", f.Resource.Kind)
+	}
+
 	fmt.Fprintf(&code,
 		`// ValidateCreate implements admission.Validator so a webhook will be registered for the type %s.
-func (v *%sValidator) ValidateCreate(_ context.Context, obj *%s) (admission.Warnings, error) {
+func (v *%sValidator) ValidateCreate(%s context.Context, obj *%s) (admission.Warnings, error) {
 	%slog.Info("Validation for %s upon creation", "name", obj.GetName())
 
 	// TODO(user): fill in your validation logic upon object creation.
 
-	return nil, nil
+	return %s
 }
 
 // ValidateUpdate implements admission.Validator so a webhook will be registered for the type %s.
-func (v *%sValidator) ValidateUpdate(_ context.Context, oldObj, newObj *%s) (admission.Warnings, error) {
+func (v *%sValidator) ValidateUpdate(%s context.Context, oldObj, newObj *%s) (admission.Warnings, error) {
 	%slog.Info("Validation for %s upon update", "name", newObj.GetName())
 
 	// TODO(user): fill in your validation logic upon object update.
 
-	return nil, nil
+	return %s
 }
 
 // ValidateDelete implements admission.Validator so a webhook will be registered for the type %s.
@@ -432,16 +490,27 @@ func (v *%sValidator) ValidateDelete(_ context.Context, obj *%s) (admission.Warn
 	return nil, nil
 }
 `,
-		f.Resource.Kind, f.Resource.Kind, objType,
-		strings.ToLower(f.Resource.Kind), f.Resource.Kind,
-		f.Resource.Kind, f.Resource.Kind, objType,
-		strings.ToLower(f.Resource.Kind), f.Resource.Kind,
+		f.Resource.Kind, f.Resource.Kind, ctxName, objType,
+		strings.ToLower(f.Resource.Kind), f.Resource.Kind, createReturn,
+		f.Resource.Kind, f.Resource.Kind, ctxName, objType,
+		strings.ToLower(f.Resource.Kind), f.Resource.Kind, updateReturn,
 		f.Resource.Kind, f.Resource.Kind, objType,
 		strings.ToLower(f.Resource.Kind), f.Resource.Kind)
 
 	return code.String()
 }
 
+// generateValidatorFields generates the fields of the validator which are required by the
+// validations of the webhook markers.
+func (f *WebhookUpdater) generateValidatorFields() string {
+	if f.Markers == nil {
+		return ""
+	}
+
+	return "\t// Client reads the objects which are looked up by the validations of the webhook markers.\n" +
+		"\tClient client.Reader\n\n"
+}
+
 // getGroupValue returns the group value for webhook markers.
 func (f *WebhookUpdater) getGroupValue() string {
 	if f.Resource.Core && f.Resource.QualifiedGroup() == coreGroup {
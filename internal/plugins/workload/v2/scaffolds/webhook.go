@@ -37,6 +37,7 @@ import (
 	"github.com/nukleros/operator-builder/internal/plugins/workload/v2/scaffolds/templates/api"
 	"github.com/nukleros/operator-builder/internal/plugins/workload/v2/scaffolds/templates/hack"
 	"github.com/nukleros/operator-builder/internal/plugins/workload/v2/scaffolds/templates/webhooks"
+	"github.com/nukleros/operator-builder/internal/workload/v1/kinds"
 )
 
 var _ plugins.Scaffolder = &webhookScaffolder{}
@@ -50,14 +51,33 @@ type webhookScaffolder struct {
 
 	// force indicates whether to scaffold controller files even if it exists or not
 	force bool
+
+	// markers is the logic of the defaulting and validating webhooks which is generated from the
+	// webhook markers of the workload
+	markers *kinds.Webhook
+
+	// conversions are the generated conversions between the hub version and each spoke version
+	conversions map[string]*kinds.Conversion
 }
 
 // NewWebhookScaffolder returns a new Scaffolder for v2 webhook creation operations.
-func NewWebhookScaffolder(cfg config.Config, res *resource.Resource, force bool) plugins.Scaffolder {
+func NewWebhookScaffolder(
+	cfg config.Config,
+	res *resource.Resource,
+	force bool,
+	markers *kinds.Webhook,
+	conversions map[string]*kinds.Conversion,
+) plugins.Scaffolder {
+	if markers.IsEmpty() {
+		markers = nil
+	}
+
 	return &webhookScaffolder{
-		config:   cfg,
-		resource: *res,
-		force:    force,
+		config:      cfg,
+		resource:    *res,
+		force:       force,
+		markers:     markers,
+		conversions: conversions,
 	}
 }
 
@@ -124,6 +144,14 @@ func (s *webhookScaffolder) Scaffold() error {
 			return err
 		}
 
+		// the logic which is generated from the webhook markers is regenerated on each run and is
+		// called from the webhook file, which is owned by the user
+		if (doDefaulting || doValidation) && s.markers != nil {
+			if err := scaffold.Execute(&webhooks.WebhookMarkers{Markers: s.markers}); err != nil {
+				return fmt.Errorf("error scaffolding webhook markers: %w", err)
+			}
+		}
+
 		// Update main.go to wire webhook setup function (for all webhook types)
 		if err = scaffold.Execute(
 			&templates.MainUpdater{WireWebhook: true},
@@ -143,13 +171,18 @@ func (s *webhookScaffolder) Scaffold() error {
 			return fmt.Errorf("error updating types file with storage version marker: %w", err)
 		}
 
-		if err = scaffold.Execute(&api.Hub{Force: s.force}); err != nil {
+		if err = scaffold.Execute(&api.Hub{Force: s.force, FS: s.fs.FS}); err != nil {
 			return fmt.Errorf("error scaffold resource with hub: %w", err)
 		}
 
 		for _, spoke := range s.resource.Webhooks.Spoke {
 			log.Info("Scaffolding for spoke version", "version", spoke)
-			if err = scaffold.Execute(&api.Spoke{Force: s.force, SpokeVersion: spoke}); err != nil {
+			if err = scaffold.Execute(&api.Spoke{
+				FS:           s.fs.FS,
+				Force:        s.force,
+				SpokeVersion: spoke,
+				Conversion:   s.conversions[spoke],
+			}); err != nil {
 				return fmt.Errorf("failed to scaffold spoke %s: %w", spoke, err)
 			}
 		}
@@ -210,14 +243,14 @@ func (s *webhookScaffolder) getWebhookTestFilePath() string {
 func (s *webhookScaffolder) scaffoldWebhookFile(scaffold *machinery.Scaffold, fileExists bool) error {
 	if !fileExists || s.force {
 		if err := scaffold.Execute(
-			&webhooks.Webhook{Force: s.force},
+			&webhooks.Webhook{Force: s.force, Markers: s.markers},
 		); err != nil {
 			return fmt.Errorf("error creating webhook: %w", err)
 		}
 	} else if fileExists && !s.force {
 		log.Info("Adding new webhook type to existing file")
 		if err := scaffold.Execute(
-			&webhooks.WebhookUpdater{},
+			&webhooks.WebhookUpdater{Markers: s.markers},
 		); err != nil {
 			return fmt.Errorf("error updating webhook: %w", err)
 		}
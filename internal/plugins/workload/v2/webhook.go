@@ -37,6 +37,7 @@ import (
 
 	"github.com/nukleros/operator-builder/internal/plugins/workload"
 	"github.com/nukleros/operator-builder/internal/plugins/workload/v2/scaffolds"
+	"github.com/nukleros/operator-builder/internal/workload/v1/commands/subcommand"
 	workloadconfig "github.com/nukleros/operator-builder/internal/workload/v1/config"
 	"github.com/nukleros/operator-builder/internal/workload/v1/kinds"
 )
@@ -59,7 +60,10 @@ type createWebhookSubcommand struct {
 	runMake bool
 
 	workloadConfigPath string
+	configVars         []string
+	variables          workloadconfig.Variables
 	workload           kinds.WorkloadBuilder
+	processor          *workloadconfig.Processor
 }
 
 func (p *createWebhookSubcommand) UpdateMetadata(cliMeta plugin.CLIMetadata, subcmdMeta *plugin.SubcommandMetadata) {
@@ -94,7 +98,7 @@ validating and/or conversion webhooks.
 }
 
 func (p *createWebhookSubcommand) BindFlags(fs *pflag.FlagSet) {
-	workload.AddFlags(fs, &p.workloadConfigPath, nil)
+	workload.AddFlags(fs, &p.workloadConfigPath, &p.configVars, nil)
 
 	p.options = &goPlugin.Options{}
 
@@ -139,14 +143,29 @@ func (p *createWebhookSubcommand) BindFlags(fs *pflag.FlagSet) {
 }
 
 func (p *createWebhookSubcommand) InjectConfig(c config.Config) error {
-	processor, err := workloadconfig.Parse(p.workloadConfigPath)
+	// variables which were given to a previous command are used when none are given.  the
+	// project config does not contain the plugin config prior to the first command.
+	var storedConfig workloadconfig.Plugin
+	if err := c.DecodePluginConfig(workloadconfig.PluginKey, &storedConfig); err != nil {
+		storedConfig = workloadconfig.Plugin{}
+	}
+
+	variables, err := workload.ParseConfigVars(p.configVars, storedConfig.ConfigVars)
+	if err != nil {
+		return fmt.Errorf("unable to inject config into %s, %w", p.workloadConfigPath, err)
+	}
+
+	p.variables = variables
+
+	processor, err := workloadconfig.Parse(p.workloadConfigPath, p.variables)
 	if err != nil {
 		return fmt.Errorf("unable to inject config into %s, %w", p.workloadConfigPath, err)
 	}
 
 	p.workload = processor.Workload
+	p.processor = processor
 
-	pluginConfig := workloadconfig.Plugin{WorkloadConfigPath: p.workloadConfigPath}
+	pluginConfig := workloadconfig.Plugin{WorkloadConfigPath: p.workloadConfigPath, ConfigVars: p.variables}
 
 	if err := c.EncodePluginConfig(workloadconfig.PluginKey, pluginConfig); err != nil {
 		return fmt.Errorf("unable to encode plugin config at key %s, %w", workloadconfig.PluginKey, err)
@@ -201,7 +220,31 @@ func (p *createWebhookSubcommand) InjectResource(res *resource.Resource) error {
 }
 
 func (p *createWebhookSubcommand) Scaffold(fs machinery.Filesystem) error {
-	scaffolder := scaffolds.NewWebhookScaffolder(p.config, p.resource, p.force)
+	var webhook *kinds.Webhook
+
+	var conversions map[string]*kinds.Conversion
+
+	// external and core types are not generated from the workload config and therefore have
+	// neither webhook markers nor other versions to convert
+	if !p.resource.External && !p.resource.Core {
+		hubProcessor, err := subcommand.CreateWebhook(p.processor, p.resource.Group, p.resource.Version, p.resource.Kind)
+		if err != nil {
+			return fmt.Errorf("failed to process workload config for webhook: %w", err)
+		}
+
+		if hubProcessor != nil {
+			webhook = hubProcessor.Workload.GetWebhook()
+		}
+
+		if p.resource.HasConversionWebhook() {
+			conversions, err = subcommand.CreateConversions(hubProcessor, p.variables)
+			if err != nil {
+				return fmt.Errorf("failed to compare API versions for conversion: %w", err)
+			}
+		}
+	}
+
+	scaffolder := scaffolds.NewWebhookScaffolder(p.config, p.resource, p.force, webhook, conversions)
 	scaffolder.InjectFS(fs)
 	if err := scaffolder.Scaffold(); err != nil {
 		return fmt.Errorf("failed to scaffold webhook: %w", err)
@@ -4,21 +4,54 @@
 package workload
 
 import (
+	"fmt"
+
 	"github.com/spf13/pflag"
 	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
 
+	"github.com/nukleros/operator-builder/internal/dryrun"
+	workloadconfig "github.com/nukleros/operator-builder/internal/workload/v1/config"
 	"github.com/nukleros/operator-builder/internal/workload/v1/kinds"
 )
 
 // AddFlags adds a consistent set of workload flags across plugin versions and commands.
-func AddFlags(fs *pflag.FlagSet, workloadConfigPath *string, enableOlm *bool) {
+func AddFlags(fs *pflag.FlagSet, workloadConfigPath *string, configVars *[]string, enableOlm *bool) {
 	fs.StringVar(workloadConfigPath, "workload-config", "", "path to workload config file")
+	AddConfigVarFlag(fs, configVars)
 
 	if enableOlm != nil {
 		fs.BoolVar(enableOlm, "enable-olm", false, "enable support for OpenShift Lifecycle Manager")
 	}
 }
 
+// AddConfigVarFlag adds the flag for variables which are substituted within a workload config.
+func AddConfigVarFlag(fs *pflag.FlagSet, configVars *[]string) {
+	fs.StringArrayVar(configVars, "config-var", []string{},
+		"variable in the format key=value which is substituted for ${key} in the workload config, may be repeated")
+}
+
+// AddDryRunFlag adds the flag which requests that a command scaffolds to an in-memory filesystem
+// rather than to disk.  The filesystem itself is set up by the CLI before the command is run.
+func AddDryRunFlag(fs *pflag.FlagSet, dryRun *bool) {
+	fs.BoolVar(dryRun, dryrun.Flag, false,
+		"if set, print the files which would be created, overwritten or skipped along with their diffs, without writing them")
+}
+
+// ParseConfigVars parses the variables given with the config var flag.  When no variables are given,
+// the variables which are stored in the project config, if any, are used.
+func ParseConfigVars(configVars []string, stored workloadconfig.Variables) (workloadconfig.Variables, error) {
+	if len(configVars) == 0 && stored != nil {
+		return stored, nil
+	}
+
+	variables, err := workloadconfig.ParseVariables(configVars)
+	if err != nil {
+		return nil, fmt.Errorf("%w; unable to parse --config-var flag", err)
+	}
+
+	return variables, nil
+}
+
 // InjectResourceGVK injects the resource group version and kind.  It adds them from a workload
 // if they are explicitly missing from the resource.
 func InjectResourceGVK(res *resource.Resource, workload kinds.WorkloadBuilder) {
@@ -22,14 +22,15 @@ const (
 // a workload for the purpose of generating a companion CLI.
 type companionCLIProcessor interface {
 	IsCollection() bool
+	IsComponent() bool
 	GetAPIKind() string
 }
 
 // CLI defines the command name and description for the root command or
 // subcommand of a companion CLI.
 type CLI struct {
-	Name          string
-	Description   string
+	Name          string `jsonschema_description:"The name of the command."`
+	Description   string `jsonschema_description:"The description of the command."`
 	VarName       string `json:"-" yaml:"-" validate:"omitempty"`
 	FileName      string `json:"-" yaml:"-" validate:"omitempty"`
 	IsSubcommand  bool   `json:"-" yaml:"-" validate:"omitempty"`
@@ -84,7 +85,9 @@ func (cli *CLI) GetSubCmdRelativeFileName(
 
 // getDefaultName determines the default command name for a companion CLI subcommand.
 func (cli *CLI) getDefaultName(workload companionCLIProcessor) string {
-	if workload.IsCollection() && cli.IsSubcommand {
+	// a nested collection is named by its kind so that it does not conflict with the subcommand
+	// of its parent collection
+	if workload.IsCollection() && !workload.IsComponent() && cli.IsSubcommand {
 		return defaultCollectionSubcommandName
 	}
 
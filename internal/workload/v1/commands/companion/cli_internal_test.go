@@ -12,6 +12,7 @@ import (
 
 type companionCLITester struct {
 	collection bool
+	component  bool
 	kind       string
 }
 
@@ -19,6 +20,10 @@ func (tester *companionCLITester) IsCollection() bool {
 	return tester.collection
 }
 
+func (tester *companionCLITester) IsComponent() bool {
+	return tester.component
+}
+
 func (tester *companionCLITester) GetAPIKind() string {
 	return tester.kind
 }
@@ -230,6 +235,22 @@ func TestCLI_getDefaultName(t *testing.T) {
 			},
 			want: defaultCollectionSubcommandName,
 		},
+		{
+			name: "ensure nested collection sub command name is properly returned",
+			fields: fields{
+				Name:         "nested-collection-sub",
+				Description:  "nested-collection-sub",
+				IsSubcommand: true,
+			},
+			args: args{
+				workload: &companionCLITester{
+					collection: true,
+					component:  true,
+					kind:       "NestedCollectionSub",
+				},
+			},
+			want: "nestedcollectionsub",
+		},
 		{
 			name: "ensure component sub command name is properly returned",
 			fields: fields{
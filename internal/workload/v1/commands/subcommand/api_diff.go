@@ -0,0 +1,285 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package subcommand
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/nukleros/operator-builder/internal/workload/v1/config"
+	"github.com/nukleros/operator-builder/internal/workload/v1/kinds"
+)
+
+var (
+	ErrBreakingAPIChanges     = errors.New("breaking api changes found")
+	ErrMissingPreviousAPI     = errors.New("one of a git revision or a previous workload config is required")
+	ErrConflictingPreviousAPI = errors.New("only one of a git revision or a previous workload config may be given")
+)
+
+type APIDiffOptions struct {
+	WorkloadConfigPath         string
+	PreviousWorkloadConfigPath string
+	Revision                   string
+	Variables                  config.Variables
+	Output                     io.Writer
+}
+
+// APIDiff runs through the logic that happens when the `api-diff` command is executed.  It
+// processes the current workload config, along with either the same workload config at a previous
+// git revision or the workload config of another version of the APIs, and compares the API spec
+// fields of each API which is generated from both.  An error is returned if an API has breaking
+// changes without a change of version.
+func APIDiff(options *APIDiffOptions) error {
+	previousPath := options.PreviousWorkloadConfigPath
+
+	switch {
+	case options.Revision == "" && previousPath == "":
+		return ErrMissingPreviousAPI
+	case options.Revision != "" && previousPath != "":
+		return ErrConflictingPreviousAPI
+	case options.Revision != "":
+		dir, err := os.MkdirTemp("", "operator-builder-api-diff-")
+		if err != nil {
+			return fmt.Errorf("%w; unable to create directory for git revision %s", err, options.Revision)
+		}
+
+		defer os.RemoveAll(dir)
+
+		if previousPath, err = extractRevision(options.Revision, options.WorkloadConfigPath, dir); err != nil {
+			return err
+		}
+	}
+
+	current, err := processAPIs(options.WorkloadConfigPath, options.Variables)
+	if err != nil {
+		return err
+	}
+
+	previous, err := processAPIs(previousPath, options.Variables)
+	if err != nil {
+		return err
+	}
+
+	breaking := []string{}
+
+	for _, workload := range current {
+		previousWorkload := findAPI(previous, workload.GetAPIGroup(), workload.GetAPIKind())
+		if previousWorkload == nil {
+			continue
+		}
+
+		sameVersion := previousWorkload.GetAPIVersion() == workload.GetAPIVersion()
+
+		changes, err := kinds.DiffAPIFields(
+			previousWorkload.GetAPISpecFields(),
+			workload.GetAPISpecFields(),
+			getFieldMappings(workload, previousWorkload.GetAPIVersion()),
+		)
+		if err != nil {
+			return fmt.Errorf("%w; unable to compare api %s", err, apiName(workload))
+		}
+
+		fmt.Fprintf(options.Output, "%s/%s %s -> %s:\n",
+			workload.GetAPIGroup(), workload.GetAPIKind(), previousWorkload.GetAPIVersion(), workload.GetAPIVersion())
+
+		if len(changes) == 0 {
+			fmt.Fprintf(options.Output, "  no changes\n")
+
+			continue
+		}
+
+		for _, change := range changes {
+			if !change.Breaking {
+				fmt.Fprintf(options.Output, "  %s\n", change)
+
+				continue
+			}
+
+			fmt.Fprintf(options.Output, "  %s [breaking]\n", change)
+
+			if sameVersion {
+				breaking = append(breaking, apiName(workload))
+			}
+		}
+	}
+
+	for _, previousWorkload := range previous {
+		if findAPI(current, previousWorkload.GetAPIGroup(), previousWorkload.GetAPIKind()) == nil {
+			fmt.Fprintf(options.Output, "%s removed [breaking]\n", apiName(previousWorkload))
+
+			breaking = append(breaking, apiName(previousWorkload))
+		}
+	}
+
+	if len(breaking) > 0 {
+		return fmt.Errorf("%w without a change of version in %s", ErrBreakingAPIChanges, strings.Join(unique(breaking), ", "))
+	}
+
+	return nil
+}
+
+// processAPIs processes a workload config in the same way as the `create api` subcommand and
+// returns each of the workloads for which an API is generated.
+func processAPIs(workloadConfigPath string, variables config.Variables) ([]kinds.WorkloadBuilder, error) {
+	processor, err := config.Parse(workloadConfigPath, variables)
+	if err != nil {
+		return nil, fmt.Errorf("%w; unable to parse workload config %s", err, workloadConfigPath)
+	}
+
+	if err := CreateAPI(processor); err != nil {
+		return nil, fmt.Errorf("%w; unable to process workload config %s", err, workloadConfigPath)
+	}
+
+	workloads := []kinds.WorkloadBuilder{}
+	for _, topLevelProcessor := range processor.GetTopLevelProcessors() {
+		workloads = append(workloads, topLevelProcessor.GetWorkloads()...)
+	}
+
+	return workloads, nil
+}
+
+// getFieldMappings returns the fields which were renamed since a previous version of an API, as
+// they are given in the conversion section of the API.
+func getFieldMappings(workload kinds.WorkloadBuilder, previousVersion string) []*kinds.FieldMapping {
+	for _, conversion := range workload.GetAPIConversions() {
+		if conversion.Version == previousVersion {
+			return conversion.Fields
+		}
+	}
+
+	return nil
+}
+
+func findAPI(workloads []kinds.WorkloadBuilder, group, kind string) kinds.WorkloadBuilder {
+	for _, workload := range workloads {
+		if workload.GetAPIGroup() == group && workload.GetAPIKind() == kind {
+			return workload
+		}
+	}
+
+	return nil
+}
+
+func apiName(workload kinds.WorkloadBuilder) string {
+	return fmt.Sprintf("%s/%s, Kind=%s", workload.GetAPIGroup(), workload.GetAPIVersion(), workload.GetAPIKind())
+}
+
+func unique(values []string) []string {
+	found := map[string]bool{}
+	result := []string{}
+
+	for _, value := range values {
+		if !found[value] {
+			found[value] = true
+			result = append(result, value)
+		}
+	}
+
+	return result
+}
+
+// extractRevision extracts the files of the git repository which contains a workload config, as
+// they were at a git revision, to a directory and returns the path of the workload config within
+// the directory.
+func extractRevision(revision, workloadConfigPath, dir string) (string, error) {
+	absPath, err := filepath.Abs(workloadConfigPath)
+	if err != nil {
+		return "", fmt.Errorf("%w; unable to find workload config %s", err, workloadConfigPath)
+	}
+
+	root, err := runGit(filepath.Dir(absPath), "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", err
+	}
+
+	root = strings.TrimSpace(root)
+
+	// resolve symlinks, such as those of temporary directories, so that the paths are comparable
+	if absPath, err = filepath.EvalSymlinks(absPath); err != nil {
+		return "", fmt.Errorf("%w; unable to find workload config %s", err, workloadConfigPath)
+	}
+
+	if root, err = filepath.EvalSymlinks(root); err != nil {
+		return "", fmt.Errorf("%w; unable to find git repository %s", err, root)
+	}
+
+	relPath, err := filepath.Rel(root, absPath)
+	if err != nil {
+		return "", fmt.Errorf("%w; unable to find workload config %s within git repository %s", err, workloadConfigPath, root)
+	}
+
+	archive, err := runGit(root, "archive", "--format=tar", revision)
+	if err != nil {
+		return "", err
+	}
+
+	reader := tar.NewReader(strings.NewReader(archive))
+
+	for {
+		header, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return "", fmt.Errorf("%w; unable to read git revision %s", err, revision)
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		path := filepath.Join(dir, filepath.FromSlash(header.Name))
+		if !strings.HasPrefix(path, filepath.Clean(dir)+string(filepath.Separator)) {
+			continue
+		}
+
+		if err := extractFile(reader, path); err != nil {
+			return "", fmt.Errorf("%w; unable to extract %s from git revision %s", err, header.Name, revision)
+		}
+	}
+
+	return filepath.Join(dir, relPath), nil
+}
+
+func extractFile(reader io.Reader, path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("%w; unable to create directory for %s", err, path)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("%w; unable to create %s", err, path)
+	}
+
+	defer file.Close()
+
+	if _, err := io.Copy(file, reader); err != nil { //nolint:gosec
+		return fmt.Errorf("%w; unable to write %s", err, path)
+	}
+
+	return nil
+}
+
+func runGit(dir string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("%w; unable to run git %s: %s", err, strings.Join(args, " "), strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.String(), nil
+}
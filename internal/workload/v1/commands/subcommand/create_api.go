@@ -20,25 +20,33 @@ var (
 )
 
 type createAPIProcessor struct {
-	components       []*kinds.ComponentWorkload
-	collection       *kinds.WorkloadCollection
 	configProcessors []*config.Processor
 }
 
 // CreateAPI runs through the logic that happens when the `create api` subcommand is executed.  It is responsible
 // for the processing of manifests and the markers within them, generating source code, and setting the values
-// used during scaffolding.
+// used during scaffolding.  Each of the top-level workloads is processed independently of the others.
 func CreateAPI(processor *config.Processor) error {
-	// run through pre-processing to gather the collection and the components
+	for _, topLevelProcessor := range processor.GetTopLevelProcessors() {
+		if err := createAPI(topLevelProcessor); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// createAPI runs through the `create api` logic for a single top-level workload and its children.
+func createAPI(processor *config.Processor) error {
+	// run through pre-processing to load the manifests for each of the workloads
 	apiProcessor := &createAPIProcessor{configProcessors: processor.GetProcessors()}
 	if err := apiProcessor.preProcess(); err != nil {
 		return fmt.Errorf("%w; %s", err, ErrCreateAPIPreProcess.Error())
 	}
 
-	if len(apiProcessor.components) > 0 {
-		if err := processor.Workload.SetComponents(apiProcessor.components); err != nil {
-			return fmt.Errorf("%w; %s", err, ErrCreateAPISetComponents.Error())
-		}
+	// set the components and nested collections of each of the collections
+	if err := setComponents(processor); err != nil {
+		return fmt.Errorf("%w; %s", err, ErrCreateAPISetComponents.Error())
 	}
 
 	// run through processing
@@ -56,46 +64,57 @@ func (apiProcessor *createAPIProcessor) preProcess() error {
 			return fmt.Errorf("%w; error loading manifests for workload %s", err, processor.Workload.GetName())
 		}
 
-		// find the collection and the components
-		switch workload := processor.Workload.(type) {
-		case *kinds.StandaloneWorkload:
-			continue
-		case *kinds.WorkloadCollection:
-			// a collection is still a collection to itself
-			apiProcessor.collection = workload
-			workload.Spec.Collection = workload
-			workload.Spec.ForCollection = true
-		case *kinds.ComponentWorkload:
-			// add this component to the list of components
-			apiProcessor.components = append(apiProcessor.components, workload)
+		// a collection is still a collection to itself
+		if collection, ok := processor.Workload.(*kinds.WorkloadCollection); ok {
+			collection.Spec.Collection = collection
+			collection.Spec.ForCollection = true
 		}
 	}
 
 	return nil
 }
 
-func (apiProcessor *createAPIProcessor) process() error {
-	fieldMarkers := &markers.MarkerCollection{
-		FieldMarkers:           []*markers.FieldMarker{},
-		CollectionFieldMarkers: []*markers.CollectionFieldMarker{},
+// setComponents sets the components and nested collections for the collection of a processor
+// and each of the collections nested within it.  Each component belongs to the nearest collection
+// which encloses it.
+func setComponents(processor *config.Processor) error {
+	collection, ok := processor.Workload.(*kinds.WorkloadCollection)
+	if !ok {
+		return nil
 	}
 
-	workloadSpecs := make([]*kinds.WorkloadSpec, len(apiProcessor.configProcessors))
+	components := []*kinds.ComponentWorkload{}
 
-	// set the resources and collect the markers and specs
-	for i := range apiProcessor.configProcessors {
-		// get the spec and set the collection on the components
-		switch workload := apiProcessor.configProcessors[i].Workload.(type) {
-		case *kinds.StandaloneWorkload:
-			workloadSpecs[i] = &workload.Spec.WorkloadSpec
-		case *kinds.WorkloadCollection:
-			workloadSpecs[i] = &workload.Spec.WorkloadSpec
+	for _, child := range processor.Children {
+		switch workload := child.Workload.(type) {
 		case *kinds.ComponentWorkload:
-			workloadSpecs[i] = &workload.Spec.WorkloadSpec
-			workload.Spec.Collection = apiProcessor.collection
-			workload.Spec.API.Domain = apiProcessor.collection.Spec.API.Domain
+			workload.Spec.Collection = collection
+			workload.Spec.API.Domain = collection.Spec.API.Domain
+
+			components = append(components, workload)
+		case *kinds.WorkloadCollection:
+			collection.Spec.Collections = append(collection.Spec.Collections, workload)
+
+			if err := setComponents(child); err != nil {
+				return err
+			}
 		}
+	}
 
+	if len(components) == 0 {
+		return nil
+	}
+
+	if err := collection.SetComponents(components); err != nil {
+		return fmt.Errorf("%w; error setting components for collection %s", err, collection.GetName())
+	}
+
+	return nil
+}
+
+func (apiProcessor *createAPIProcessor) process() error {
+	// set the resources for each of the workloads
+	for i := range apiProcessor.configProcessors {
 		if err := apiProcessor.configProcessors[i].Workload.SetResources(apiProcessor.configProcessors[i].Path); err != nil {
 			return fmt.Errorf(
 				"%w; error setting resources for workload %s",
@@ -104,17 +123,60 @@ func (apiProcessor *createAPIProcessor) process() error {
 		}
 
 		apiProcessor.configProcessors[i].Workload.SetRBAC()
-
-		fieldMarkers.FieldMarkers = append(fieldMarkers.FieldMarkers, workloadSpecs[i].FieldMarkers...)
-		fieldMarkers.CollectionFieldMarkers = append(fieldMarkers.CollectionFieldMarkers, workloadSpecs[i].CollectionFieldMarkers...)
 	}
 
-	// loop through the collected workload specs and process the resource markers
-	for i := range workloadSpecs {
-		if err := workloadSpecs[i].ProcessResourceMarkers(fieldMarkers); err != nil {
+	// process the resource markers for each of the workloads, which may only reference the markers
+	// of the workload itself and the markers of the nearest collection which encloses it
+	for _, processor := range apiProcessor.configProcessors {
+		workloadSpec := getWorkloadSpec(processor.Workload)
+		if workloadSpec == nil {
+			continue
+		}
+
+		fieldMarkers := &markers.MarkerCollection{
+			FieldMarkers:           []*markers.FieldMarker{},
+			CollectionFieldMarkers: []*markers.CollectionFieldMarker{},
+		}
+
+		for _, spec := range []*kinds.WorkloadSpec{workloadSpec, getCollectionSpec(processor.Workload)} {
+			if spec == nil {
+				continue
+			}
+
+			fieldMarkers.FieldMarkers = append(fieldMarkers.FieldMarkers, spec.FieldMarkers...)
+			fieldMarkers.CollectionFieldMarkers = append(fieldMarkers.CollectionFieldMarkers, spec.CollectionFieldMarkers...)
+		}
+
+		if err := workloadSpec.ProcessResourceMarkers(fieldMarkers); err != nil {
 			return fmt.Errorf("%w; error processing resource markers", err)
 		}
 	}
 
 	return nil
 }
+
+// getWorkloadSpec returns the workload spec for a workload.
+func getWorkloadSpec(workload kinds.WorkloadBuilder) *kinds.WorkloadSpec {
+	switch typed := workload.(type) {
+	case *kinds.StandaloneWorkload:
+		return &typed.Spec.WorkloadSpec
+	case *kinds.WorkloadCollection:
+		return &typed.Spec.WorkloadSpec
+	case *kinds.ComponentWorkload:
+		return &typed.Spec.WorkloadSpec
+	}
+
+	return nil
+}
+
+// getCollectionSpec returns the workload spec for the nearest collection which encloses a workload.  A
+// top-level collection, which is a collection to itself, and a standalone workload have no enclosing
+// collection.
+func getCollectionSpec(workload kinds.WorkloadBuilder) *kinds.WorkloadSpec {
+	collection := workload.GetCollection()
+	if collection == nil || collection == workload {
+		return nil
+	}
+
+	return &collection.Spec.WorkloadSpec
+}
@@ -0,0 +1,87 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package subcommand
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/nukleros/operator-builder/internal/workload/v1/config"
+	"github.com/nukleros/operator-builder/internal/workload/v1/kinds"
+)
+
+var ErrMissingSpokeWorkload = errors.New("no workload found for spoke version")
+
+// CreateWebhook runs through the logic that happens when the `create webhook` subcommand is executed.  It processes
+// the workload config in the same way as the `create api` subcommand and returns the processor of the workload which
+// generates the API, whose webhook markers are resolved, or nil if no workload within the workload config generates it.
+func CreateWebhook(processor *config.Processor, group, version, kind string) (*config.Processor, error) {
+	if err := CreateAPI(processor); err != nil {
+		return nil, fmt.Errorf("%w; unable to process workload config %s", err, processor.Path)
+	}
+
+	return findProcessor(processor, group, version, kind), nil
+}
+
+// CreateConversions returns the conversion between the hub version of an API, which is generated for the workload of
+// a processor returned by CreateWebhook, and each of the spoke versions for which a workload config is given in the
+// conversion section of the API.  Spoke versions without a workload config are not returned.
+func CreateConversions(hubProcessor *config.Processor, variables config.Variables) (map[string]*kinds.Conversion, error) {
+	conversions := map[string]*kinds.Conversion{}
+
+	if hubProcessor == nil {
+		return conversions, nil
+	}
+
+	group, kind := hubProcessor.Workload.GetAPIGroup(), hubProcessor.Workload.GetAPIKind()
+
+	for _, apiConversion := range hubProcessor.Workload.GetAPIConversions() {
+		spokePath := filepath.Join(filepath.Dir(hubProcessor.Path), apiConversion.WorkloadConfig)
+
+		spokeProcessor, err := config.Parse(spokePath, variables)
+		if err != nil {
+			return nil, fmt.Errorf("%w; unable to parse workload config for spoke version %s", err, apiConversion.Version)
+		}
+
+		if err := CreateAPI(spokeProcessor); err != nil {
+			return nil, fmt.Errorf("%w; unable to process workload config for spoke version %s", err, apiConversion.Version)
+		}
+
+		spoke := findProcessor(spokeProcessor, group, apiConversion.Version, kind)
+		if spoke == nil {
+			return nil, fmt.Errorf("%w %s of kind %s in workload config %s",
+				ErrMissingSpokeWorkload, apiConversion.Version, kind, spokePath)
+		}
+
+		conversion, err := kinds.NewConversion(
+			hubProcessor.Workload.GetAPISpecFields(),
+			spoke.Workload.GetAPISpecFields(),
+			apiConversion.Fields,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%w; unable to convert spoke version %s", err, apiConversion.Version)
+		}
+
+		conversions[apiConversion.Version] = conversion
+	}
+
+	return conversions, nil
+}
+
+// findProcessor returns the processor of the workload which generates an API, or nil if no
+// workload within the workload config generates it.
+func findProcessor(processor *config.Processor, group, version, kind string) *config.Processor {
+	for _, topLevelProcessor := range processor.GetTopLevelProcessors() {
+		for _, workloadProcessor := range topLevelProcessor.GetProcessors() {
+			workload := workloadProcessor.Workload
+
+			if workload.GetAPIGroup() == group && workload.GetAPIVersion() == version && workload.GetAPIKind() == kind {
+				return workloadProcessor
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package subcommand
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/nukleros/operator-builder/internal/workload/v1/config"
+	"github.com/nukleros/operator-builder/internal/workload/v1/graph"
+)
+
+type GraphOptions struct {
+	WorkloadConfigPath string
+	Variables          config.Variables
+	Format             string
+	Output             io.Writer
+}
+
+// Graph runs through the logic that happens when the `graph` command is executed.  It writes the
+// graph of the workloads, their dependencies and their child resources to the output and returns
+// an error reporting each of the dependency cycles between the components, if any exist.
+func Graph(options *GraphOptions) error {
+	processor, err := config.Parse(options.WorkloadConfigPath, options.Variables)
+	if err != nil {
+		return fmt.Errorf("%w; unable to parse workload config %s", err, options.WorkloadConfigPath)
+	}
+
+	workloadGraph, err := graph.New(processor)
+	if err != nil {
+		return fmt.Errorf("%w; unable to graph workload config %s", err, options.WorkloadConfigPath)
+	}
+
+	if err := workloadGraph.Write(options.Output, options.Format); err != nil {
+		return fmt.Errorf("%w; unable to write graph for workload config %s", err, options.WorkloadConfigPath)
+	}
+
+	return workloadGraph.CycleError()
+}
@@ -0,0 +1,198 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package subcommand
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+
+	"github.com/nukleros/operator-builder/internal/utils"
+	"github.com/nukleros/operator-builder/internal/workload/v1/manifests"
+)
+
+var (
+	ErrImportManifestsMissingFiles = errors.New("no manifest files provided for import")
+	ErrImportManifestsMissingKind  = errors.New("imported object is missing kind or metadata.name")
+	ErrImportManifestsDuplicate    = errors.New("multiple imported objects resolve to the same file")
+)
+
+const directoryPermissions = 0755
+
+type ImportManifestsOptions struct {
+	Files     []string
+	OutputDir string
+	Force     bool
+}
+
+// importedObject represents a single object that was imported from an exported manifest file.
+type importedObject struct {
+	kind      string
+	name      string
+	namespace string
+	source    string
+	node      *yaml.Node
+}
+
+// ImportManifests runs through the logic that happens when the `import-manifests` command is executed.  It
+// reads manifests which were exported from a running cluster, sanitizes each of the objects within them
+// and writes each object as a clean manifest to its own file in the output directory.
+func ImportManifests(options *ImportManifestsOptions) error {
+	if len(options.Files) == 0 {
+		return ErrImportManifestsMissingFiles
+	}
+
+	var objects []*importedObject
+
+	for _, pattern := range options.Files {
+		files, err := utils.Glob(pattern)
+		if err != nil {
+			return fmt.Errorf("%w; error finding manifest files for %s", err, pattern)
+		}
+
+		for _, file := range files {
+			imported, err := importFile(file)
+			if err != nil {
+				return err
+			}
+
+			objects = append(objects, imported...)
+		}
+	}
+
+	return writeImportedObjects(options, objects)
+}
+
+// importFile reads all of the objects from a manifest file and sanitizes them.
+func importFile(file string) ([]*importedObject, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("%w; unable to read manifest file %s", err, file)
+	}
+
+	manifest := &manifests.Manifest{Filename: file, Content: content}
+
+	extracted, err := manifest.ExtractManifests()
+	if err != nil {
+		return nil, fmt.Errorf("%w; unable to extract manifests from file %s", err, file)
+	}
+
+	objects := make([]*importedObject, len(extracted))
+
+	for i := range extracted {
+		var node yaml.Node
+
+		if err := yaml.Unmarshal([]byte(extracted[i]), &node); err != nil {
+			return nil, fmt.Errorf("%w; unable to decode manifest from file %s", err, file)
+		}
+
+		manifests.Sanitize(&node)
+
+		var metadata struct {
+			Kind     string `yaml:"kind"`
+			Metadata struct {
+				Name      string `yaml:"name"`
+				Namespace string `yaml:"namespace"`
+			} `yaml:"metadata"`
+		}
+
+		if err := node.Decode(&metadata); err != nil {
+			return nil, fmt.Errorf("%w; unable to decode object metadata from file %s", err, file)
+		}
+
+		if metadata.Kind == "" || metadata.Metadata.Name == "" {
+			return nil, fmt.Errorf("%w in file %s", ErrImportManifestsMissingKind, file)
+		}
+
+		objects[i] = &importedObject{
+			kind:      metadata.Kind,
+			name:      metadata.Metadata.Name,
+			namespace: metadata.Metadata.Namespace,
+			source:    file,
+			node:      &node,
+		}
+	}
+
+	return objects, nil
+}
+
+// writeImportedObjects writes each of the imported objects to its own file in the output
+// directory.  No files are written when multiple objects resolve to the same file.
+func writeImportedObjects(options *ImportManifestsOptions, objects []*importedObject) error {
+	fileNames := importedFileNames(objects)
+	paths := make([]string, len(objects))
+	seen := map[string]*importedObject{}
+
+	for i, object := range objects {
+		paths[i] = filepath.Join(options.OutputDir, fileNames[i])
+
+		if existing, found := seen[paths[i]]; found {
+			return fmt.Errorf("%w; %s from %s and %s from %s at %s",
+				ErrImportManifestsDuplicate,
+				existing.name, existing.source,
+				object.name, object.source,
+				paths[i],
+			)
+		}
+
+		seen[paths[i]] = object
+	}
+
+	if err := os.MkdirAll(options.OutputDir, directoryPermissions); err != nil {
+		return fmt.Errorf("%w; unable to create output directory %s", err, options.OutputDir)
+	}
+
+	for i, object := range objects {
+		buf := new(bytes.Buffer)
+		yamlEncoder := yaml.NewEncoder(buf)
+		yamlEncoder.SetIndent(indentLevel)
+
+		if err := yamlEncoder.Encode(object.node); err != nil {
+			return fmt.Errorf("%w; unable to encode yaml for %s %s", err, object.kind, object.name)
+		}
+
+		if err := outputFile(&InitConfigOptions{Path: paths[i], Force: options.Force}, buf.Bytes()); err != nil {
+			return err
+		}
+
+		log.Infof("imported %s %s from %s to %s", object.kind, object.name, object.source, paths[i])
+	}
+
+	return nil
+}
+
+// importedFileNames returns the file names for a set of imported objects.  File names are derived from the
+// kind and name of the object.  The namespace is included only when objects of the same kind and name exist
+// in multiple namespaces.
+func importedFileNames(objects []*importedObject) []string {
+	baseName := func(object *importedObject) string {
+		return utils.ToFileName(fmt.Sprintf("%s-%s", strings.ToLower(object.kind), object.name))
+	}
+
+	found := map[string]int{}
+
+	for _, object := range objects {
+		found[baseName(object)]++
+	}
+
+	fileNames := make([]string, len(objects))
+
+	for i, object := range objects {
+		name := baseName(object)
+
+		if found[name] > 1 && object.namespace != "" {
+			name = utils.ToFileName(fmt.Sprintf("%s-%s", object.namespace, name))
+		}
+
+		fileNames[i] = name + ".yaml"
+	}
+
+	return fileNames
+}
@@ -0,0 +1,93 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package subcommand
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// newTestImportedObject returns an imported object with the given kind, name and namespace.
+func newTestImportedObject(t *testing.T, kind, name, namespace, source string) *importedObject {
+	t.Helper()
+
+	node := &yaml.Node{}
+	require.NoError(t, yaml.Unmarshal([]byte("kind: "+kind+"\nmetadata:\n  name: "+name+"\n"), node))
+
+	return &importedObject{kind: kind, name: name, namespace: namespace, source: source, node: node}
+}
+
+func TestWriteImportedObjects(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		objects   func(t *testing.T) []*importedObject
+		wantFiles []string
+		wantErr   error
+	}{
+		{
+			name: "objects are written to their own files",
+			objects: func(t *testing.T) []*importedObject {
+				t.Helper()
+
+				return []*importedObject{
+					newTestImportedObject(t, "ConfigMap", "webapp", "dev", "dev.yaml"),
+					newTestImportedObject(t, "ConfigMap", "webapp", "prod", "prod.yaml"),
+					newTestImportedObject(t, "Secret", "webapp", "dev", "dev.yaml"),
+				}
+			},
+			wantFiles: []string{"dev_configmap_webapp.yaml", "prod_configmap_webapp.yaml", "secret_webapp.yaml"},
+		},
+		{
+			name: "no files are written when objects resolve to the same file",
+			objects: func(t *testing.T) []*importedObject {
+				t.Helper()
+
+				return []*importedObject{
+					newTestImportedObject(t, "Secret", "webapp", "", "first.yaml"),
+					newTestImportedObject(t, "ConfigMap", "webapp", "", "first.yaml"),
+					newTestImportedObject(t, "ConfigMap", "webapp", "", "second.yaml"),
+				}
+			},
+			wantErr: ErrImportManifestsDuplicate,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			outputDir := filepath.Join(t.TempDir(), "manifests")
+
+			err := writeImportedObjects(&ImportManifestsOptions{OutputDir: outputDir}, tt.objects(t))
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+
+				_, statErr := os.Stat(outputDir)
+				assert.ErrorIs(t, statErr, os.ErrNotExist)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			entries, err := os.ReadDir(outputDir)
+			require.NoError(t, err)
+
+			files := make([]string, len(entries))
+			for i, entry := range entries {
+				files[i] = entry.Name()
+			}
+
+			assert.Equal(t, tt.wantFiles, files)
+		})
+	}
+}
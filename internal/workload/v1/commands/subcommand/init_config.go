@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"gopkg.in/yaml.v3"
 
@@ -29,25 +30,40 @@ const (
 type InitConfigOptions struct {
 	Path           string
 	Force          bool
+	FromDir        string
 	WorkloadConfig kinds.WorkloadBuilder
 }
 
 func InitConfig(options *InitConfigOptions) error {
+	if options.FromDir != "" {
+		return initConfigFromDir(options)
+	}
+
+	data, err := encodeConfig(options.WorkloadConfig)
+	if err != nil {
+		return err
+	}
+
+	return outputFile(options, data)
+}
+
+// encodeConfig validates a workload configuration and encodes it as yaml.
+func encodeConfig(workloadConfig kinds.WorkloadBuilder) ([]byte, error) {
 	// validate the configuration
-	if err := options.WorkloadConfig.Validate(); err != nil {
-		return fmt.Errorf("%w; invalid configuration", err)
+	if err := workloadConfig.Validate(); err != nil {
+		return nil, fmt.Errorf("%w; invalid configuration", err)
 	}
 
 	// mutate typed fields
-	yamlBytes, err := mutateConfig(options.WorkloadConfig)
+	yamlBytes, err := mutateConfig(workloadConfig)
 	if err != nil {
-		return fmt.Errorf("%w; unable to mutate configuration fields", err)
+		return nil, fmt.Errorf("%w; unable to mutate configuration fields", err)
 	}
 
 	var yamlNode yaml.Node
 
 	if err := yaml.Unmarshal(yamlBytes, &yamlNode); err != nil {
-		return fmt.Errorf("%w; unable to unmarshal workload config as yaml.Node", err)
+		return nil, fmt.Errorf("%w; unable to unmarshal workload config as yaml.Node", err)
 	}
 
 	buf := new(bytes.Buffer)
@@ -55,10 +71,10 @@ func InitConfig(options *InitConfigOptions) error {
 	yamlEncoder.SetIndent(indentLevel)
 
 	if err := yamlEncoder.Encode(&yamlNode); err != nil {
-		return fmt.Errorf("%w; unable to encode yaml", err)
+		return nil, fmt.Errorf("%w; unable to encode yaml", err)
 	}
 
-	return outputFile(options, buf.Bytes())
+	return buf.Bytes(), nil
 }
 
 func outputFile(options *InitConfigOptions, data []byte) error {
@@ -80,6 +96,10 @@ func outputFile(options *InitConfigOptions, data []byte) error {
 		}
 	}
 
+	if err := os.MkdirAll(filepath.Dir(options.Path), directoryPermissions); err != nil {
+		return fmt.Errorf("%w; unable to create directory for %s", err, options.Path)
+	}
+
 	if err := os.WriteFile(options.Path, data, permissions); err != nil {
 		return fmt.Errorf("%w; %s at location %s", err, ErrWriteFile.Error(), options.Path)
 	}
@@ -119,31 +139,40 @@ func mutateConfig(workloadConfig kinds.WorkloadBuilder) ([]byte, error) {
 	return yamlBytes, nil
 }
 
+// mutateResources converts the manifests field, which is an array of manifest objects, to the
+// resources field, which is a flat array of file names.
 func mutateResources(yamlData map[string]interface{}) error {
 	specField, err := utils.ToMapStringInterface(yamlData["spec"])
 	if err != nil {
 		return fmt.Errorf("%w; error converting workload config spec %v", err, yamlData["spec"])
 	}
 
-	resourceObjs, err := utils.ToArrayInterface(specField["resources"])
+	resources, err := utils.ToArrayString(specField["resources"])
 	if err != nil {
 		return fmt.Errorf("%w; error converting spec.resources %v", err, specField["resources"])
 	}
 
-	resources := make([]string, len(resourceObjs))
-
-	for i, resource := range resourceObjs {
-		resourceMap, err := utils.ToMapStringInterface(resource)
+	if specField["manifests"] != nil {
+		manifestObjs, err := utils.ToArrayInterface(specField["manifests"])
 		if err != nil {
-			return fmt.Errorf("%w; error converting spec.resources item %v", err, resource)
+			return fmt.Errorf("%w; error converting spec.manifests %v", err, specField["manifests"])
 		}
 
-		filename, err := utils.ToString(resourceMap["filename"])
-		if err != nil {
-			return fmt.Errorf("%w; error converting spec.resources.filename %v", err, resource)
+		for _, manifest := range manifestObjs {
+			manifestMap, err := utils.ToMapStringInterface(manifest)
+			if err != nil {
+				return fmt.Errorf("%w; error converting spec.manifests item %v", err, manifest)
+			}
+
+			filename, err := utils.ToString(manifestMap["filename"])
+			if err != nil {
+				return fmt.Errorf("%w; error converting spec.manifests.filename %v", err, manifest)
+			}
+
+			resources = append(resources, filename)
 		}
 
-		resources[i] = filename
+		delete(specField, "manifests")
 	}
 
 	specField["resources"] = resources
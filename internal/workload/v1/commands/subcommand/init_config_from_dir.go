@@ -0,0 +1,350 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package subcommand
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/nukleros/operator-builder/internal/utils"
+	"github.com/nukleros/operator-builder/internal/workload/v1/graph"
+	"github.com/nukleros/operator-builder/internal/workload/v1/kinds"
+	"github.com/nukleros/operator-builder/internal/workload/v1/manifests"
+)
+
+var (
+	ErrInitConfigFromDirKind  = errors.New("workload kind does not support generating a config from a directory")
+	ErrInitConfigFromDirEmpty = errors.New("no manifests found in directory")
+)
+
+const componentConfigFileName = "workload.yaml"
+
+// componentConfig represents a component workload config which is generated from a subdirectory
+// of manifests for a collection.
+type componentConfig struct {
+	path       string
+	workload   *kinds.ComponentWorkload
+	references *manifests.References
+}
+
+// initConfigFromDir generates a workload config from the manifests within an existing directory
+// rather than from a static sample.
+func initConfigFromDir(options *InitConfigOptions) error {
+	configDir := "."
+	if options.Path != "-" {
+		configDir = filepath.Dir(options.Path)
+	}
+
+	switch sample := options.WorkloadConfig.(type) {
+	case *kinds.StandaloneWorkload:
+		return initStandaloneFromDir(options, configDir, sample)
+	case *kinds.WorkloadCollection:
+		return initCollectionFromDir(options, configDir, sample)
+	}
+
+	return fmt.Errorf("%w; kind %s", ErrInitConfigFromDirKind, options.WorkloadConfig.GetWorkloadKind())
+}
+
+// initStandaloneFromDir generates a standalone workload config with all of the manifests within a
+// directory, and its subdirectories, as its resources.
+func initStandaloneFromDir(options *InitConfigOptions, configDir string, sample *kinds.StandaloneWorkload) error {
+	files, err := manifests.FindManifests(options.FromDir, true)
+	if err != nil {
+		return err
+	}
+
+	if len(files) == 0 {
+		return fmt.Errorf("%w %s", ErrInitConfigFromDirEmpty, options.FromDir)
+	}
+
+	resources, err := relativePaths(configDir, files)
+	if err != nil {
+		return err
+	}
+
+	name, api, err := apiFromDir(options.FromDir, sample.Spec.API)
+	if err != nil {
+		return err
+	}
+
+	workload := kinds.NewStandaloneWorkload(name, api, resources)
+	workload.Spec.CompanionCliRootcmd.SetDefaults(workload, false)
+
+	data, err := encodeConfig(workload)
+	if err != nil {
+		return err
+	}
+
+	return outputFile(options, data)
+}
+
+// initCollectionFromDir generates a workload collection config with the manifests at the top level of a
+// directory as its resources.  Each subdirectory is proposed as a component workload config, with its
+// dependencies inferred from the objects that it uses which are provided by other components.
+func initCollectionFromDir(options *InitConfigOptions, configDir string, sample *kinds.WorkloadCollection) error {
+	files, err := manifests.FindManifests(options.FromDir, false)
+	if err != nil {
+		return err
+	}
+
+	resources, err := relativePaths(configDir, files)
+	if err != nil {
+		return err
+	}
+
+	name, api, err := apiFromDir(options.FromDir, sample.Spec.API)
+	if err != nil {
+		return err
+	}
+
+	// a directory which is itself a kustomization is a single resource and has no components
+	var components []*componentConfig
+
+	if len(files) != 1 || files[0] != options.FromDir {
+		if components, err = componentsFromDir(options.FromDir, configDir, api); err != nil {
+			return err
+		}
+	}
+
+	if len(files) == 0 && len(components) == 0 {
+		return fmt.Errorf("%w %s", ErrInitConfigFromDirEmpty, options.FromDir)
+	}
+
+	componentFiles := make([]string, len(components))
+
+	for i, component := range components {
+		componentFile, err := filepath.Rel(configDir, component.path)
+		if err != nil {
+			return fmt.Errorf("%w; unable to determine relative path for component %s", err, component.path)
+		}
+
+		componentFiles[i] = filepath.ToSlash(componentFile)
+	}
+
+	collection := kinds.NewWorkloadCollection(name, api, componentFiles)
+	collection.Spec.Manifests = manifests.FromFiles(resources)
+	collection.Spec.CompanionCliRootcmd.SetDefaults(collection, false)
+	collection.Spec.CompanionCliSubcmd.SetDefaults(collection, true)
+
+	data, err := encodeConfig(collection)
+	if err != nil {
+		return err
+	}
+
+	// when writing to stdout, each component config is proposed as an additional document which is
+	// commented with the path that it is expected at
+	if options.Path == "-" {
+		for _, component := range components {
+			componentData, err := encodeConfig(component.workload)
+			if err != nil {
+				return err
+			}
+
+			data = append(data, []byte(fmt.Sprintf("---\n# %s\n", filepath.ToSlash(component.path)))...)
+			data = append(data, componentData...)
+		}
+
+		return outputFile(options, data)
+	}
+
+	if err := outputFile(options, data); err != nil {
+		return err
+	}
+
+	for _, component := range components {
+		componentData, err := encodeConfig(component.workload)
+		if err != nil {
+			return err
+		}
+
+		if err := outputFile(&InitConfigOptions{Path: component.path, Force: options.Force}, componentData); err != nil {
+			return err
+		}
+
+		log.Infof("generated component workload config %s", component.path)
+	}
+
+	return nil
+}
+
+// componentsFromDir returns a component workload config for each subdirectory of a directory.  Components
+// are ordered by the name of their subdirectory.
+func componentsFromDir(dir, configDir string, collectionAPI kinds.WorkloadAPISpec) ([]*componentConfig, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("%w; unable to read directory %s", err, dir)
+	}
+
+	var components []*componentConfig
+
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		componentDir := filepath.Join(dir, entry.Name())
+
+		component, err := componentFromDir(componentDir, filepath.Join(configDir, entry.Name()), collectionAPI)
+		if err != nil {
+			return nil, err
+		}
+
+		if component == nil {
+			log.Warnf("skipping component directory %s; %s", componentDir, ErrInitConfigFromDirEmpty.Error())
+
+			continue
+		}
+
+		components = append(components, component)
+	}
+
+	inferDependencies(components)
+
+	return components, nil
+}
+
+// inferDependencies sets the dependencies of each component to the components which provide the
+// objects that it uses.  A component always depends on the earlier components which it uses, whereas
+// a dependency upon a later component is skipped if it would complete a dependency cycle.
+func inferDependencies(components []*componentConfig) {
+	workloadGraph := &graph.Graph{Workloads: make([]*graph.Workload, len(components))}
+
+	for i, component := range components {
+		workloadGraph.Workloads[i] = &graph.Workload{Name: component.workload.Name}
+	}
+
+	// dependencies upon earlier components are added first, as they never complete a cycle
+	for _, later := range []bool{false, true} {
+		for i, component := range components {
+			node := workloadGraph.Workloads[i]
+
+			for j, dependency := range components {
+				if i == j || (j > i) != later || !component.references.UsesAny(dependency.references) {
+					continue
+				}
+
+				node.Dependencies = append(node.Dependencies, dependency.workload.Name)
+
+				if later && len(workloadGraph.FindCycles()) > 0 {
+					node.Dependencies = node.Dependencies[:len(node.Dependencies)-1]
+
+					log.Warnf("component %s uses objects from component %s but does not depend on it to avoid a dependency cycle",
+						component.workload.Name, dependency.workload.Name)
+				}
+			}
+		}
+	}
+
+	for i, component := range components {
+		component.workload.Spec.Dependencies = append(component.workload.Spec.Dependencies, workloadGraph.Workloads[i].Dependencies...)
+	}
+}
+
+// componentFromDir returns a component workload config for the manifests within a directory, or nil
+// if the directory contains no manifests.
+func componentFromDir(dir, componentConfigDir string, collectionAPI kinds.WorkloadAPISpec) (*componentConfig, error) {
+	files, err := manifests.FindManifests(dir, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	resources, err := relativePaths(componentConfigDir, files)
+	if err != nil {
+		return nil, err
+	}
+
+	name, api, err := apiFromDir(dir, collectionAPI)
+	if err != nil {
+		return nil, err
+	}
+
+	workload := kinds.NewComponentWorkload(name, api, resources, []string{})
+	workload.Spec.CompanionCliSubcmd.SetDefaults(workload, true)
+
+	// gather the objects which the component provides and uses so that dependencies may be inferred
+	expanded, err := manifests.ExpandManifests(componentConfigDir, resources)
+	if err != nil {
+		return nil, fmt.Errorf("%w; error loading manifests for component %s", err, name)
+	}
+
+	references := &manifests.References{}
+
+	for _, manifest := range *expanded {
+		if err := manifest.LoadContent(false); err != nil {
+			return nil, fmt.Errorf("%w; error loading manifests for component %s", err, name)
+		}
+
+		manifestReferences, err := manifest.References()
+		if err != nil {
+			return nil, fmt.Errorf("%w; error finding references for component %s", err, name)
+		}
+
+		references.Provides = append(references.Provides, manifestReferences.Provides...)
+		references.Uses = append(references.Uses, manifestReferences.Uses...)
+	}
+
+	return &componentConfig{
+		path:       filepath.Join(componentConfigDir, componentConfigFileName),
+		workload:   workload,
+		references: references,
+	}, nil
+}
+
+// apiFromDir returns the workload name and api spec for a workload which is generated from a directory.
+// The name and the kind of the api are inferred from the name of the directory.
+func apiFromDir(dir string, sample kinds.WorkloadAPISpec) (string, kinds.WorkloadAPISpec, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", sample, fmt.Errorf("%w; unable to determine absolute path for directory %s", err, dir)
+	}
+
+	name := strings.Trim(strings.Map(func(char rune) rune {
+		if unicode.IsLetter(char) || unicode.IsDigit(char) {
+			return unicode.ToLower(char)
+		}
+
+		return '-'
+	}, filepath.Base(absDir)), "-")
+
+	api := sample
+	api.Kind = utils.ToPascalCase(name)
+
+	return name, api, nil
+}
+
+// relativePaths returns the paths of a set of files relative to a base directory.
+func relativePaths(base string, files []string) ([]string, error) {
+	absBase, err := filepath.Abs(base)
+	if err != nil {
+		return nil, fmt.Errorf("%w; unable to determine absolute path for directory %s", err, base)
+	}
+
+	paths := make([]string, len(files))
+
+	for i, file := range files {
+		absFile, err := filepath.Abs(file)
+		if err != nil {
+			return nil, fmt.Errorf("%w; unable to determine absolute path for file %s", err, file)
+		}
+
+		path, err := filepath.Rel(absBase, absFile)
+		if err != nil {
+			return nil, fmt.Errorf("%w; unable to determine relative path for file %s", err, file)
+		}
+
+		paths[i] = filepath.ToSlash(path)
+	}
+
+	return paths, nil
+}
@@ -0,0 +1,124 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package subcommand
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nukleros/operator-builder/internal/workload/v1/kinds"
+)
+
+// writeTestComponent writes a component directory with a service account, which the component
+// provides, and a pod which uses the service account of another component.
+func writeTestComponent(t *testing.T, dir, name, uses string) {
+	t.Helper()
+
+	manifest := fmt.Sprintf(`apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: %[1]s
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: %[1]s
+spec:
+  serviceAccountName: %[2]s
+  containers:
+    - name: %[1]s
+      image: nginx:1.0
+`, name, uses)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, name), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name, "app.yaml"), []byte(manifest), 0o600))
+}
+
+func testCollectionAPI() kinds.WorkloadAPISpec {
+	return kinds.WorkloadAPISpec{Domain: "acme.com", Group: "platform", Version: "v1alpha1", Kind: "Platform"}
+}
+
+func TestComponentsFromDir(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		components [][2]string
+		want       map[string][]string
+	}{
+		{
+			name:       "components which use each other depend on the earlier component",
+			components: [][2]string{{"alpha", "beta"}, {"beta", "alpha"}},
+			want: map[string][]string{
+				"alpha": {},
+				"beta":  {"alpha"},
+			},
+		},
+		{
+			name:       "components which use each other through another component do not form a cycle",
+			components: [][2]string{{"alpha", "gamma"}, {"beta", "alpha"}, {"gamma", "beta"}},
+			want: map[string][]string{
+				"alpha": {},
+				"beta":  {"alpha"},
+				"gamma": {"beta"},
+			},
+		},
+		{
+			name:       "components depend on later components without a cycle",
+			components: [][2]string{{"alpha", "beta"}, {"beta", "default"}},
+			want: map[string][]string{
+				"alpha": {"beta"},
+				"beta":  {},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			root := t.TempDir()
+			dir := filepath.Join(root, "manifests")
+
+			for _, component := range tt.components {
+				writeTestComponent(t, dir, component[0], component[1])
+			}
+
+			components, err := componentsFromDir(dir, filepath.Join(root, "config"), testCollectionAPI())
+			require.NoError(t, err)
+			require.Len(t, components, len(tt.want))
+
+			for _, component := range components {
+				assert.ElementsMatch(t, tt.want[component.workload.Name], component.workload.Spec.Dependencies,
+					"dependencies of component %s", component.workload.Name)
+			}
+		})
+	}
+}
+
+func TestInitConfig_fromDirNestedPath(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	dir := filepath.Join(root, "platform")
+
+	writeTestComponent(t, dir, "alpha", "default")
+
+	path := filepath.Join(root, "config", "nested", "workload.yaml")
+
+	require.NoError(t, InitConfig(&InitConfigOptions{
+		Path:           path,
+		FromDir:        dir,
+		WorkloadConfig: kinds.NewWorkloadCollection("platform", testCollectionAPI(), []string{}),
+	}))
+
+	assert.FileExists(t, path)
+	assert.FileExists(t, filepath.Join(root, "config", "nested", "alpha", componentConfigFileName))
+}
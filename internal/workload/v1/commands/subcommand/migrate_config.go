@@ -0,0 +1,125 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package subcommand
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+
+	"github.com/nukleros/operator-builder/internal/utils"
+	"github.com/nukleros/operator-builder/internal/workload/v1/kinds"
+)
+
+type MigrateConfigOptions struct {
+	WorkloadConfigPath string
+}
+
+// MigrateConfig runs through the logic that happens when the `migrate-config` command is executed.  It
+// rewrites a workload config, along with the component configs of a collection, in place as the current
+// version of the workload config format.  Comments within the configs are kept.
+func MigrateConfig(options *MigrateConfigOptions) error {
+	return migrateConfigFile(options.WorkloadConfigPath, map[string]bool{})
+}
+
+// migrateConfigFile migrates a single workload config file and the component files which it references.
+func migrateConfigFile(path string, migrated map[string]bool) error {
+	if migrated[path] {
+		return nil
+	}
+
+	migrated[path] = true
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("%w; unable to read workload config %s", err, path)
+	}
+
+	migratedContent, changed, err := kinds.MigrateContent(content)
+	if err != nil {
+		return fmt.Errorf("%w; unable to migrate workload config %s", err, path)
+	}
+
+	if changed {
+		if err := os.WriteFile(path, migratedContent, permissions); err != nil {
+			return fmt.Errorf("%w; %s at location %s", err, ErrWriteFile.Error(), path)
+		}
+
+		log.Infof("migrated workload config %s to %s", path, kinds.APIVersionCurrent)
+	} else {
+		log.Infof("workload config %s is already at %s", path, kinds.APIVersionCurrent)
+	}
+
+	// migrate the component files of any collections within the config
+	decoder := yaml.NewDecoder(bytes.NewReader(migratedContent))
+
+	for {
+		var document yaml.Node
+
+		if err := decoder.Decode(&document); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return fmt.Errorf("%w; unable to read migrated workload config %s", err, path)
+		}
+
+		for _, componentFile := range componentFiles(&document) {
+			componentPaths, err := utils.Glob(filepath.Join(filepath.Dir(path), componentFile))
+			if err != nil {
+				return fmt.Errorf("%w; error globbing workload config at path %s", err, componentFile)
+			}
+
+			for _, componentPath := range componentPaths {
+				if err := migrateConfigFile(componentPath, migrated); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// componentFiles returns the component files of a workload collection document, or nil if the
+// document is not a workload collection.
+func componentFiles(document *yaml.Node) []string {
+	kind := mappingValue(document.Content[0], "kind")
+	if kind == nil || kind.Value != kinds.WorkloadKindCollectionString {
+		return nil
+	}
+
+	files := mappingValue(mappingValue(document.Content[0], "spec"), "componentFiles")
+	if files == nil || files.Kind != yaml.SequenceNode {
+		return nil
+	}
+
+	componentFiles := make([]string, len(files.Content))
+
+	for i, file := range files.Content {
+		componentFiles[i] = file.Value
+	}
+
+	return componentFiles
+}
+
+// mappingValue returns the value of a key within a mapping node, or nil if the node is not a mapping
+// or the key is not found.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i < len(node.Content)-1; i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+
+	return nil
+}
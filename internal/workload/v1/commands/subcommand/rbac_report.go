@@ -0,0 +1,86 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package subcommand
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/nukleros/operator-builder/internal/workload/v1/config"
+	"github.com/nukleros/operator-builder/internal/workload/v1/kinds"
+	"github.com/nukleros/operator-builder/internal/workload/v1/rbac"
+)
+
+type RBACReportOptions struct {
+	WorkloadConfigPath string
+	Variables          config.Variables
+	Format             string
+	Minimal            bool
+	Output             io.Writer
+	ErrOutput          io.Writer
+}
+
+// RBACReport runs through the logic that happens when the `rbac report` command is executed.  It
+// processes the workloads in the same way as the `create api` subcommand and writes the rules
+// which are granted to their controllers to the output, along with a warning for each of the
+// rules which grant access by way of a wildcard.
+func RBACReport(options *RBACReportOptions) error {
+	processor, err := config.Parse(options.WorkloadConfigPath, options.Variables)
+	if err != nil {
+		return fmt.Errorf("%w; unable to parse workload config %s", err, options.WorkloadConfigPath)
+	}
+
+	workloads := []kinds.WorkloadBuilder{}
+	for _, topLevelProcessor := range processor.GetTopLevelProcessors() {
+		workloads = append(workloads, topLevelProcessor.GetWorkloads()...)
+	}
+
+	if options.Minimal {
+		for _, workload := range workloads {
+			setMinimalRBAC(workload)
+		}
+	}
+
+	if err := CreateAPI(processor); err != nil {
+		return fmt.Errorf("%w; unable to process workload config %s", err, options.WorkloadConfigPath)
+	}
+
+	report := &rbac.Report{}
+
+	for _, workload := range workloads {
+		if workloadSpec := getWorkloadSpec(workload); workloadSpec != nil {
+			report.Add(workload.GetName(), workloadSpec.RBACGrants...)
+		}
+	}
+
+	if err := report.Write(options.Output, options.Format); err != nil {
+		return fmt.Errorf("%w; unable to write rbac report for workload config %s", err, options.WorkloadConfigPath)
+	}
+
+	for _, rule := range report.Wildcards() {
+		source := rule.Source
+		if rule.Manifest != "" {
+			source = fmt.Sprintf("%s in %s", rule.Source, rule.Manifest)
+		}
+
+		fmt.Fprintf(options.ErrOutput, "warning: %s of workload %s grants a wildcard: %s\n", source, rule.Workload, rule.Marker)
+	}
+
+	return nil
+}
+
+// setMinimalRBAC sets the rules for the child resources of a workload to only include the verbs
+// which the controller uses, regardless of the rbac options in its workload config.
+func setMinimalRBAC(workload kinds.WorkloadBuilder) {
+	workloadSpec := getWorkloadSpec(workload)
+	if workloadSpec == nil {
+		return
+	}
+
+	if workloadSpec.RBAC == nil {
+		workloadSpec.RBAC = &kinds.RBACOptions{}
+	}
+
+	workloadSpec.RBAC.Minimal = true
+}
@@ -0,0 +1,100 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package subcommand
+
+import (
+	"errors"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	kbconfig "sigs.k8s.io/kubebuilder/v4/pkg/config"
+
+	"github.com/nukleros/operator-builder/internal/dryrun"
+	"github.com/nukleros/operator-builder/internal/workload/v1/config"
+)
+
+var ErrMissingWorkloadConfigPath = errors.New("no workload config path found in project config")
+
+type RegenerateOptions struct {
+	ProjectConfig kbconfig.Config
+	DryRun        bool
+
+	// Execute runs a command of the CLI with the given arguments.
+	Execute func(args []string) error
+}
+
+// Regenerate runs through the logic that happens when the `regenerate` command is executed.  It
+// parses the workload config which is stored in the project config and runs the `create api`
+// subcommand for it, which scaffolds the API, controller and companion CLI of each of its
+// workloads.  Files which are owned by the user, such as the mutate and dependency functions, are
+// skipped as they already exist.
+func Regenerate(options *RegenerateOptions) error {
+	pluginConfig, err := getPluginConfig(options.ProjectConfig)
+	if err != nil {
+		return err
+	}
+
+	processor, err := config.Parse(pluginConfig.WorkloadConfigPath, pluginConfig.ConfigVars)
+	if err != nil {
+		return fmt.Errorf("%w; unable to parse workload config %s", err, pluginConfig.WorkloadConfigPath)
+	}
+
+	// resources which were created from another workload config are not regenerated, as only the
+	// most recent workload config is stored in the project config.
+	kinds := map[string]bool{}
+
+	for _, topLevelProcessor := range processor.GetTopLevelProcessors() {
+		for _, workload := range topLevelProcessor.GetWorkloads() {
+			kinds[fmt.Sprintf("%s/%s, Kind=%s", workload.GetAPIGroup(), workload.GetAPIVersion(), workload.GetAPIKind())] = true
+		}
+	}
+
+	resources, err := options.ProjectConfig.GetResources()
+	if err != nil {
+		return fmt.Errorf("%w; unable to get resources from project config", err)
+	}
+
+	for _, resource := range resources {
+		if !kinds[fmt.Sprintf("%s/%s, Kind=%s", resource.Group, resource.Version, resource.Kind)] {
+			log.Warnf("resource %s/%s, Kind=%s is not found in workload config %s and will not be regenerated",
+				resource.Group, resource.Version, resource.Kind, pluginConfig.WorkloadConfigPath)
+		}
+	}
+
+	// variables which are stored in the project config are used when none are given
+	args := []string{
+		"create", "api",
+		"--workload-config", pluginConfig.WorkloadConfigPath,
+		"--resource",
+		"--controller",
+		"--force",
+	}
+
+	if options.DryRun {
+		args = append(args, "--"+dryrun.Flag)
+	}
+
+	log.Infof("regenerating workloads from workload config %s", pluginConfig.WorkloadConfigPath)
+
+	if err := options.Execute(args); err != nil {
+		return fmt.Errorf("%w; unable to regenerate workloads from workload config %s", err, pluginConfig.WorkloadConfigPath)
+	}
+
+	return nil
+}
+
+// getPluginConfig returns the plugin config which is stored in a project config, which must
+// include the path of the workload config from which the project was generated.
+func getPluginConfig(projectConfig kbconfig.Config) (*config.Plugin, error) {
+	var pluginConfig config.Plugin
+	if err := projectConfig.DecodePluginConfig(config.PluginKey, &pluginConfig); err != nil {
+		return nil, fmt.Errorf("%w; unable to decode plugin config at key %s", err, config.PluginKey)
+	}
+
+	if pluginConfig.WorkloadConfigPath == "" {
+		return nil, fmt.Errorf("%w at key %s", ErrMissingWorkloadConfigPath, config.PluginKey)
+	}
+
+	return &pluginConfig, nil
+}
@@ -0,0 +1,206 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package subcommand
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	kbconfig "sigs.k8s.io/kubebuilder/v4/pkg/config"
+	cfgv3 "sigs.k8s.io/kubebuilder/v4/pkg/config/v3"
+	"sigs.k8s.io/kubebuilder/v4/pkg/model/resource"
+
+	"github.com/nukleros/operator-builder/internal/workload/v1/config"
+)
+
+const testRegenerateWorkload = `name: webapp
+kind: StandaloneWorkload
+spec:
+  api:
+    domain: acme.com
+    group: apps
+    version: v1alpha1
+    kind: WebApp
+    clusterScoped: false
+  resources:
+    - app.yaml
+`
+
+const testRegenerateManifest = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: webapp
+data:
+  key: value
+`
+
+var errTestExecute = errors.New("execute failed")
+
+// newTestProjectConfig returns a project config, with the given resources, which stores the path
+// of a workload config.
+func newTestProjectConfig(t *testing.T, workloadConfigPath string, kinds ...string) kbconfig.Config {
+	t.Helper()
+
+	projectConfig := cfgv3.New()
+	require.NoError(t, projectConfig.SetDomain("acme.com"))
+
+	for _, kind := range kinds {
+		require.NoError(t, projectConfig.AddResource(resource.Resource{
+			GVK: resource.GVK{Domain: "acme.com", Group: "apps", Version: "v1alpha1", Kind: kind},
+		}))
+	}
+
+	require.NoError(t, projectConfig.EncodePluginConfig(config.PluginKey, config.Plugin{
+		WorkloadConfigPath: workloadConfigPath,
+	}))
+
+	return projectConfig
+}
+
+// writeTestWorkloadConfig writes a standalone workload config along with its manifest and returns
+// the path of the workload config.
+func writeTestWorkloadConfig(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "workload.yaml")
+
+	require.NoError(t, os.WriteFile(path, []byte(testRegenerateWorkload), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "app.yaml"), []byte(testRegenerateManifest), 0o600))
+
+	return path
+}
+
+func TestRegenerate(t *testing.T) {
+	t.Parallel()
+
+	workloadConfigPath := writeTestWorkloadConfig(t)
+
+	tests := []struct {
+		name       string
+		dryRun     bool
+		executeErr error
+		wantArgs   []string
+		wantErr    error
+	}{
+		{
+			name: "workload config is regenerated",
+			wantArgs: []string{
+				"create", "api",
+				"--workload-config", workloadConfigPath,
+				"--resource",
+				"--controller",
+				"--force",
+			},
+		},
+		{
+			name:   "dry run is passed to the create api command",
+			dryRun: true,
+			wantArgs: []string{
+				"create", "api",
+				"--workload-config", workloadConfigPath,
+				"--resource",
+				"--controller",
+				"--force",
+				"--dry-run",
+			},
+		},
+		{
+			name:       "error from the create api command is returned",
+			executeErr: errTestExecute,
+			wantArgs: []string{
+				"create", "api",
+				"--workload-config", workloadConfigPath,
+				"--resource",
+				"--controller",
+				"--force",
+			},
+			wantErr: errTestExecute,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls [][]string
+
+			err := Regenerate(&RegenerateOptions{
+				ProjectConfig: newTestProjectConfig(t, workloadConfigPath, "WebApp"),
+				DryRun:        tt.dryRun,
+				Execute: func(args []string) error {
+					calls = append(calls, args)
+
+					return tt.executeErr
+				},
+			})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, [][]string{tt.wantArgs}, calls)
+		})
+	}
+}
+
+func TestRegenerate_missingWorkloadConfigPath(t *testing.T) {
+	t.Parallel()
+
+	err := Regenerate(&RegenerateOptions{
+		ProjectConfig: newTestProjectConfig(t, ""),
+		Execute: func(args []string) error {
+			t.Fatalf("unexpected execution with args %v", args)
+
+			return nil
+		},
+	})
+
+	assert.ErrorIs(t, err, ErrMissingWorkloadConfigPath)
+}
+
+// TestRegenerate_resourceNotInWorkloadConfig is not run in parallel, as it captures the output of
+// the global logger.
+//
+//nolint:paralleltest
+func TestRegenerate_resourceNotInWorkloadConfig(t *testing.T) {
+	hook := logtest.NewGlobal()
+	defer log.StandardLogger().ReplaceHooks(make(log.LevelHooks))
+
+	var calls [][]string
+
+	err := Regenerate(&RegenerateOptions{
+		ProjectConfig: newTestProjectConfig(t, writeTestWorkloadConfig(t), "WebApp", "Database"),
+		Execute: func(args []string) error {
+			calls = append(calls, args)
+
+			return nil
+		},
+	})
+	require.NoError(t, err)
+
+	// the workload config is still regenerated once, and the resource which is not found in it is
+	// only warned about
+	assert.Len(t, calls, 1)
+
+	var warnings []string
+
+	for _, entry := range hook.AllEntries() {
+		if entry.Level == log.WarnLevel {
+			warnings = append(warnings, entry.Message)
+		}
+	}
+
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "Kind=Database is not found in workload config")
+}
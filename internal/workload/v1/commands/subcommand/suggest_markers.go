@@ -0,0 +1,110 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package subcommand
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/nukleros/operator-builder/internal/workload/v1/config"
+	"github.com/nukleros/operator-builder/internal/workload/v1/manifests"
+)
+
+type SuggestMarkersOptions struct {
+	WorkloadConfigPath string
+	Variables          config.Variables
+	Write              bool
+	Output             io.Writer
+}
+
+// SuggestMarkers runs through the logic that happens when the `suggest-markers` command is executed.  It scans
+// the manifests of each workload for well-known tunable values and suggests field markers for them.  The
+// suggestions are either written to the output as a patch or added to the manifests in place.
+func SuggestMarkers(options *SuggestMarkersOptions) error {
+	processor, err := config.Parse(options.WorkloadConfigPath, options.Variables)
+	if err != nil {
+		return fmt.Errorf("%w; unable to parse workload config %s", err, options.WorkloadConfigPath)
+	}
+
+	// track the files which have been processed, as a file may be a resource for multiple workloads
+	processed := map[string]bool{}
+
+	// gather the processors for each of the top-level workloads and their children
+	processors := []*config.Processor{}
+	for _, topLevelProcessor := range processor.GetTopLevelProcessors() {
+		processors = append(processors, topLevelProcessor.GetProcessors()...)
+	}
+
+	for _, workloadProcessor := range processors {
+		workload := workloadProcessor.Workload
+
+		if err := workload.LoadManifests(filepath.Dir(workloadProcessor.Path)); err != nil {
+			return fmt.Errorf("%w; error loading manifests for workload %s", err, workload.GetName())
+		}
+
+		// use a single suggester per workload so that marker names are consistent across its manifests
+		suggester := manifests.NewMarkerSuggester()
+
+		for _, manifest := range *workload.GetManifests() {
+			if processed[manifest.Filename] {
+				continue
+			}
+
+			processed[manifest.Filename] = true
+
+			if manifest.Kustomization {
+				log.Warnf("skipping kustomization %s; markers must be added to the kustomization resources", manifest.Filename)
+
+				continue
+			}
+
+			if err := suggestManifestMarkers(options, suggester, manifest.Filename); err != nil {
+				return fmt.Errorf("%w; error suggesting markers for workload %s", err, workload.GetName())
+			}
+		}
+	}
+
+	return nil
+}
+
+// suggestManifestMarkers suggests the markers for a single manifest file.
+func suggestManifestMarkers(options *SuggestMarkersOptions, suggester *manifests.MarkerSuggester, filename string) error {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return fmt.Errorf("%w; unable to read manifest file %s", err, filename)
+	}
+
+	suggestions, err := suggester.Suggest(content)
+	if err != nil {
+		return fmt.Errorf("%w; unable to suggest markers for manifest file %s", err, filename)
+	}
+
+	if len(suggestions) == 0 {
+		return nil
+	}
+
+	updated := manifests.ApplySuggestions(content, suggestions)
+
+	if !options.Write {
+		if _, err := io.WriteString(options.Output, manifests.SuggestionPatch(filename, content, updated)); err != nil {
+			return fmt.Errorf("%w; %s", err, ErrWriteStdout.Error())
+		}
+
+		return nil
+	}
+
+	if err := os.WriteFile(filename, updated, permissions); err != nil {
+		return fmt.Errorf("%w; %s at location %s", err, ErrWriteFile.Error(), filename)
+	}
+
+	for _, suggestion := range suggestions {
+		log.Infof("added marker %s to %s at %s:%d", suggestion.Name, suggestion.Path, filename, suggestion.Line)
+	}
+
+	return nil
+}
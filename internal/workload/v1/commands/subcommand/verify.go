@@ -0,0 +1,68 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package subcommand
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/afero"
+	kbconfig "sigs.k8s.io/kubebuilder/v4/pkg/config"
+
+	"github.com/nukleros/operator-builder/internal/workload/v1/config"
+	"github.com/nukleros/operator-builder/internal/workload/v1/lock"
+)
+
+var ErrStaleCode = errors.New("generated code is stale")
+
+type VerifyOptions struct {
+	ProjectConfig kbconfig.Config
+	Output        io.Writer
+}
+
+// Verify runs through the logic that happens when the `verify` command is executed.  It compares
+// the hashes of the workload config and manifests, which are stored in the project config, with
+// the hashes which were recorded in the lock file when the code was last generated, and returns
+// an error if any of them have changed.
+func Verify(options *VerifyOptions) error {
+	pluginConfig, err := getPluginConfig(options.ProjectConfig)
+	if err != nil {
+		return err
+	}
+
+	processor, err := config.Parse(pluginConfig.WorkloadConfigPath, pluginConfig.ConfigVars)
+	if err != nil {
+		return fmt.Errorf("%w; unable to parse workload config %s", err, pluginConfig.WorkloadConfigPath)
+	}
+
+	if err := CreateAPI(processor); err != nil {
+		return fmt.Errorf("%w; unable to process workload config %s", err, pluginConfig.WorkloadConfigPath)
+	}
+
+	current, err := lock.New(processor)
+	if err != nil {
+		return fmt.Errorf("%w; unable to hash inputs of workload config %s", err, pluginConfig.WorkloadConfigPath)
+	}
+
+	recorded, err := lock.Read(afero.NewOsFs())
+	if err != nil {
+		return fmt.Errorf("%w; %s", err, ErrStaleCode.Error())
+	}
+
+	changes := recorded.Changes(current)
+	if len(changes) == 0 {
+		fmt.Fprintf(options.Output, "generated code is current with workload config %s\n", pluginConfig.WorkloadConfigPath)
+
+		return nil
+	}
+
+	fmt.Fprintf(options.Output, "inputs changed since the code was last generated:\n")
+
+	for _, change := range changes {
+		fmt.Fprintf(options.Output, "  %s\n", change)
+	}
+
+	return fmt.Errorf("%w; regenerate the code for workload config %s", ErrStaleCode, pluginConfig.WorkloadConfigPath)
+}
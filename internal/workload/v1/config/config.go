@@ -12,4 +12,9 @@ type Plugin struct {
 	CliRootCommandName string `json:"cliRootCommandName" yaml:"cliRootCommandName"`
 	ControllerImg      string `json:"controllerImg" yaml:"controllerImg"`
 	EnableOLM          bool   `json:"enableOlm" yaml:"enableOlm"`
+
+	// ConfigVars are the variables, given with the --config-var flag, which are substituted within
+	// the workload config.  They are stored so that later commands expand the workload config the
+	// same way.
+	ConfigVars Variables `json:"configVars,omitempty" yaml:"configVars,omitempty"`
 }
@@ -9,10 +9,12 @@ import (
 	"fmt"
 	"io"
 	"path/filepath"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 
 	"github.com/nukleros/operator-builder/internal/utils"
+	"github.com/nukleros/operator-builder/internal/workload/v1/commands/companion"
 	"github.com/nukleros/operator-builder/internal/workload/v1/kinds"
 )
 
@@ -22,19 +24,23 @@ var (
 	ErrConvertComponent     = errors.New("error converting workload to component workload")
 	ErrConvertCollection    = errors.New("error converting workload to collection")
 	ErrCollectionRequired   = errors.New("a WorkloadCollection is required when using WorkloadComponents")
-	ErrMultipleConfigs      = errors.New("multiple configs found - please provide only one standalone or collection workload")
+	ErrMultipleRootCommands = errors.New("multiple companion CLI root commands found - workloads must share a single root command")
+	ErrUniqueSubcommands    = errors.New("each companion CLI subcommand name must be unique")
 	ErrMissingWorkload      = errors.New("could not find either standalone or collection workload, please provide one")
 	ErrMissingDependencies  = errors.New("missing dependencies - no workload config provided")
 )
 
 // Parse will parse and individual workload config given the path at which it exists.  It also
-// returns the processor and all of its attributes that were parsed and set during parsing.
-func Parse(configPath string) (*Processor, error) {
+// returns the processor and all of its attributes that were parsed and set during parsing.  The
+// variables are substituted for references to variables within the workload config.
+func Parse(configPath string, variables Variables) (*Processor, error) {
 	processor, err := NewProcessor(configPath)
 	if err != nil {
 		return nil, fmt.Errorf("%s - error creating new processor - %w", ErrParseConfig.Error(), err)
 	}
 
+	processor.Variables = variables
+
 	// create the validator we need to track workloads as they are parsed and fail fast
 	validator := &inlineValidator{
 		names:         make(map[string]bool),
@@ -42,36 +48,71 @@ func Parse(configPath string) (*Processor, error) {
 	}
 
 	// parse the workload configuration from the newly created object
-	if err := processor.parse(validator); err != nil {
+	if err := processor.parse(validator, nil); err != nil {
 		return nil, fmt.Errorf("%s at config path %s - %w", ErrParseConfig.Error(), configPath, err)
 	}
 
-	// we must ensure the top-level workload that we created is not a component, as the top-level
+	if processor.Workload == nil {
+		return nil, fmt.Errorf("%s at config path %s - %w", ErrParseConfig.Error(), configPath, ErrMissingWorkload)
+	}
+
+	topLevelProcessors := processor.GetTopLevelProcessors()
+
+	// we must ensure the top-level workloads that we created are not components, as a top-level
 	// workload should always represent either a collection or a standalone
-	if processor.Workload.IsComponent() {
-		return nil, fmt.Errorf(
-			"%s - no %s found at config path %s - %w",
-			ErrParseConfig.Error(),
-			kinds.WorkloadKindCollection,
-			configPath,
-			ErrCollectionRequired,
-		)
+	for _, topLevelProcessor := range topLevelProcessors {
+		if topLevelProcessor.Workload.IsComponent() {
+			return nil, fmt.Errorf(
+				"%s - no %s found at config path %s - %w",
+				ErrParseConfig.Error(),
+				kinds.WorkloadKindCollection,
+				configPath,
+				ErrCollectionRequired,
+			)
+		}
 	}
 
-	// finally, we must ensure that any dependencies specified exist within this configuration
-	// bundle.
-	for _, component := range processor.Children {
-		if err := setDependencies(component.Workload, processor.GetWorkloads()); err != nil {
-			return nil, fmt.Errorf("%w; unable to set dependencies for component: %s", err, component.Workload.GetName())
+	// set the companion CLI for each of the top-level workloads so that they share a single
+	// companion CLI when multiple top-level workloads exist
+	if len(topLevelProcessors) > 1 {
+		if err := setCompanionCLI(topLevelProcessors); err != nil {
+			return nil, fmt.Errorf("%s at config path %s - %w", ErrParseConfig.Error(), configPath, err)
 		}
 	}
 
+	for _, topLevelProcessor := range topLevelProcessors {
+		if err := topLevelProcessor.process(validator); err != nil {
+			return nil, fmt.Errorf("%s at config path %s - %w", ErrParseConfig.Error(), configPath, err)
+		}
+
+		// we must ensure that any dependencies specified exist within the configuration bundle of
+		// the top-level workload.  nested collections have no dependencies, although the components
+		// within them do.
+		for _, child := range topLevelProcessor.GetProcessors()[1:] {
+			if _, ok := child.Workload.(*kinds.ComponentWorkload); !ok {
+				continue
+			}
+
+			if err := setDependencies(child.Workload, topLevelProcessor.GetWorkloads()); err != nil {
+				return nil, fmt.Errorf("%w; unable to set dependencies for component: %s", err, child.Workload.GetName())
+			}
+		}
+	}
+
+	// finally, we must ensure that the workloads do not share a companion CLI subcommand
+	if err := validateSubcommands(topLevelProcessors); err != nil {
+		return nil, fmt.Errorf("%s at config path %s - %w", ErrParseConfig.Error(), configPath, err)
+	}
+
 	return processor, nil
 }
 
 // parse will parse a given workload config into its appropriate workload object
-// definitions.
-func (processor *Processor) parse(validator *inlineValidator) error {
+// definitions.  The first workload within the config is set as the workload of the processor
+// and each additional workload is set as a peer of the processor.  The collection is the
+// collection which references the config as a component file, if any, and is set as the parent
+// of any collections found within the config.
+func (processor *Processor) parse(validator *inlineValidator, collection *kinds.WorkloadCollection) error {
 	file, err := utils.ReadStream(processor.Path)
 	if err != nil {
 		return fmt.Errorf("%w; error reading file %s", err, processor.Path)
@@ -79,10 +120,16 @@ func (processor *Processor) parse(validator *inlineValidator) error {
 
 	defer utils.CloseFile(file)
 
-	var kindReader bytes.Buffer
-	reader := io.TeeReader(file, &kindReader)
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return fmt.Errorf("%w; error reading file %s", err, processor.Path)
+	}
+
+	if content, err = processor.Variables.Expand(content); err != nil {
+		return fmt.Errorf("%w; error expanding variables in file %s", err, processor.Path)
+	}
 
-	sharedDecoder, kindDecoder := yaml.NewDecoder(reader), yaml.NewDecoder(&kindReader)
+	sharedDecoder, kindDecoder := yaml.NewDecoder(bytes.NewReader(content)), yaml.NewDecoder(bytes.NewReader(content))
 
 	kindDecoder.KnownFields(true)
 
@@ -97,11 +144,18 @@ func (processor *Processor) parse(validator *inlineValidator) error {
 		}
 
 		// decode the particular kind into its appropriate workload
-		workload, err := kinds.Decode(workloadID.Kind, kindDecoder)
+		workload, err := kinds.Decode(workloadID.APIVersion, workloadID.Kind, kindDecoder)
 		if err != nil {
 			return fmt.Errorf("failed to read file %s: %w", processor.Path, err)
 		}
 
+		// a collection within a component file is nested within its parent collection and
+		// shares the domain of its parent collection, as a component does
+		if nested, ok := workload.(*kinds.WorkloadCollection); ok && collection != nil {
+			nested.Spec.ParentCollection = collection
+			nested.Spec.API.Domain = collection.Spec.API.Domain
+		}
+
 		// perform validation of the configuration after decoding the config object
 		if err := validator.validate(workload, processor); err != nil {
 			return err
@@ -114,25 +168,39 @@ func (processor *Processor) parse(validator *inlineValidator) error {
 		)
 
 		// update the processor for this workload
-		workload.SetNames()
-		processor.Workload = workload
+		if processor.Workload == nil {
+			processor.Workload = workload
 
-		// parse the child components
-		if workload.IsCollection() {
-			collection, ok := workload.(*kinds.WorkloadCollection)
-			if !ok {
-				return fmt.Errorf("%w for workload %s labeled as collection", ErrConvertCollection, workload.GetName())
-			}
-
-			if err := processor.parseComponents(collection, processor.Path, validator); err != nil {
-				return err
-			}
+			continue
 		}
+
+		processor.Peers = append(processor.Peers, &Processor{
+			Path:      processor.Path,
+			Workload:  workload,
+			Variables: processor.Variables,
+		})
 	}
 
 	return nil
 }
 
+// process sets the names for the workload of a processor and parses the child components if the
+// workload is a collection.
+func (processor *Processor) process(validator *inlineValidator) error {
+	processor.Workload.SetNames()
+
+	if !processor.Workload.IsCollection() {
+		return nil
+	}
+
+	collection, ok := processor.Workload.(*kinds.WorkloadCollection)
+	if !ok {
+		return fmt.Errorf("%w for workload %s labeled as collection", ErrConvertCollection, processor.Workload.GetName())
+	}
+
+	return processor.parseComponents(collection, processor.Path, validator)
+}
+
 func (processor *Processor) parseComponents(
 	workload *kinds.WorkloadCollection,
 	workloadConfig string,
@@ -152,18 +220,99 @@ func (processor *Processor) parseComponents(
 				return err
 			}
 
-			// add the component processor as a child
-			processor.Children = append(processor.Children, componentProcessor)
+			componentProcessor.Variables = processor.Variables
 
-			if err := componentProcessor.parse(validator); err != nil {
+			if err := componentProcessor.parse(validator, workload); err != nil {
 				return fmt.Errorf("%w; %s at path %s", err, ErrParseComponentConfig.Error(), componentPath)
 			}
 
-			// set the config path
-			c := componentProcessor.Workload
-			if component, ok := c.(*kinds.ComponentWorkload); ok {
-				component.Spec.ConfigPath = componentPath
+			// add each of the component processors within the file as a child
+			for _, child := range componentProcessor.GetTopLevelProcessors() {
+				child.Peers = nil
+
+				switch childWorkload := child.Workload.(type) {
+				case *kinds.ComponentWorkload:
+					childWorkload.Spec.ConfigPath = componentPath
+					childWorkload.SetNames()
+				case *kinds.WorkloadCollection:
+					// parse the components of the nested collection
+					if err := child.process(validator); err != nil {
+						return fmt.Errorf("%w; %s at path %s", err, ErrParseComponentConfig.Error(), componentPath)
+					}
+				default:
+					return fmt.Errorf(
+						"%w for workload %s; component files may only contain %s or %s workloads at path %s",
+						ErrConvertComponent,
+						child.Workload.GetName(),
+						kinds.WorkloadKindComponent,
+						kinds.WorkloadKindCollection,
+						componentPath,
+					)
+				}
+
+				processor.Children = append(processor.Children, child)
+			}
+		}
+	}
+
+	return nil
+}
+
+// setCompanionCLI sets the companion CLI for each of the top-level workloads when multiple top-level
+// workloads exist within a config.  The workloads share the root command of the first workload which
+// requests one, and each workload is given a subcommand, which defaults to the lowercase kind, so that
+// all of the workloads may be managed from a single companion CLI.
+func setCompanionCLI(processors []*Processor) error {
+	var rootCmd *companion.CLI
+
+	for _, processor := range processors {
+		if cmd := processor.Workload.GetRootCommand(); cmd.HasName() {
+			if rootCmd != nil && rootCmd.Name != cmd.Name {
+				return fmt.Errorf("%w; found [%s] and [%s]", ErrMultipleRootCommands, rootCmd.Name, cmd.Name)
+			}
+
+			if rootCmd == nil {
+				rootCmd = cmd
+			}
+		}
+	}
+
+	// no companion CLI is generated if none of the workloads request one
+	if rootCmd == nil {
+		return nil
+	}
+
+	for _, processor := range processors {
+		*processor.Workload.GetRootCommand() = *rootCmd
+
+		if subCmd := processor.Workload.GetSubCommand(); !subCmd.HasName() {
+			subCmd.Name = strings.ToLower(processor.Workload.GetAPIKind())
+		}
+	}
+
+	return nil
+}
+
+// validateSubcommands ensures that the companion CLI subcommands for each of the workloads within a
+// config are unique.
+func validateSubcommands(processors []*Processor) error {
+	subCmds := make(map[string]string)
+
+	for _, processor := range processors {
+		for _, workload := range processor.GetWorkloads() {
+			if !workload.HasSubCmdName() {
+				continue
+			}
+
+			name := workload.GetSubCommand().Name
+			if existing, found := subCmds[name]; found {
+				return fmt.Errorf(
+					"%w; subcommand [%s] used by workloads [%s] and [%s]",
+					ErrUniqueSubcommands, name, existing, workload.GetName(),
+				)
 			}
+
+			subCmds[name] = workload.GetName()
 		}
 	}
 
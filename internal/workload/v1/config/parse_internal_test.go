@@ -6,6 +6,11 @@ package config
 import (
 	"os"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nukleros/operator-builder/internal/workload/v1/kinds"
 )
 
 func TestParse(t *testing.T) {
@@ -203,6 +208,111 @@ func TestParse(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "ensure valid multiple workloads do not return an error",
+			args: args{
+				configPath: testPath + "configs/multiple/valid.yaml",
+			},
+			wantErr: false,
+		},
+		{
+			name: "ensure multiple workloads with different root commands returns an error",
+			args: args{
+				configPath: testPath + "configs/multiple/invalid-root-commands.yaml",
+			},
+			wantErr: true,
+		},
+		{
+			name: "ensure multiple workloads with overlapping subcommands returns an error",
+			args: args{
+				configPath: testPath + "configs/multiple/invalid-overlapping-subcommands.yaml",
+			},
+			wantErr: true,
+		},
+		{
+			name: "ensure valid nested collections do not return an error",
+			args: args{
+				configPath: testPath + "configs/collection/valid-nested.yaml",
+			},
+			wantErr: false,
+		},
+		{
+			name: "ensure nested collection which references itself returns an error",
+			args: args{
+				configPath: testPath + "configs/collection/invalid-nested-recursive.yaml",
+			},
+			wantErr: true,
+		},
+		{
+			name: "ensure valid external dependencies do not return an error",
+			args: args{
+				configPath: testPath + "configs/collection/valid-external-dependencies.yaml",
+			},
+			wantErr: false,
+		},
+		{
+			name: "ensure external dependencies with missing fields return an error",
+			args: args{
+				configPath: testPath + "configs/collection/invalid-external-dependencies.yaml",
+			},
+			wantErr: true,
+		},
+		{
+			name: "ensure valid controller options do not return an error",
+			args: args{
+				configPath: testPath + "configs/standalone/valid-controller-options.yaml",
+			},
+			wantErr: false,
+		},
+		{
+			name: "ensure invalid controller options return an error",
+			args: args{
+				configPath: testPath + "configs/standalone/invalid-controller-options.yaml",
+			},
+			wantErr: true,
+		},
+		{
+			name: "ensure valid custom phases do not return an error",
+			args: args{
+				configPath: testPath + "configs/standalone/valid-phases.yaml",
+			},
+			wantErr: false,
+		},
+		{
+			name: "ensure invalid custom phases return an error",
+			args: args{
+				configPath: testPath + "configs/standalone/invalid-phases.yaml",
+			},
+			wantErr: true,
+		},
+		{
+			name: "ensure valid common metadata does not return an error",
+			args: args{
+				configPath: testPath + "configs/standalone/valid-common-metadata.yaml",
+			},
+			wantErr: false,
+		},
+		{
+			name: "ensure invalid common metadata returns an error",
+			args: args{
+				configPath: testPath + "configs/standalone/invalid-common-metadata.yaml",
+			},
+			wantErr: true,
+		},
+		{
+			name: "ensure valid rbac options do not return an error",
+			args: args{
+				configPath: testPath + "configs/standalone/valid-rbac.yaml",
+			},
+			wantErr: false,
+		},
+		{
+			name: "ensure invalid rbac options return an error",
+			args: args{
+				configPath: testPath + "configs/standalone/invalid-rbac.yaml",
+			},
+			wantErr: true,
+		},
 		{
 			name: "ensure workload with invalid type returns an error",
 			args: args{
@@ -216,7 +326,7 @@ func TestParse(t *testing.T) {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			_, err := Parse(tt.args.configPath)
+			_, err := Parse(tt.args.configPath, nil)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Parse() error = %v, wantErr %v", err, tt.wantErr)
 
@@ -225,3 +335,62 @@ func TestParse(t *testing.T) {
 		})
 	}
 }
+
+func TestParseMultipleWorkloads(t *testing.T) {
+	t.Parallel()
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+
+	processor, err := Parse(wd+"/../../../../test/configs/multiple/valid.yaml", nil)
+	require.NoError(t, err)
+
+	workloads := processor.GetTopLevelWorkloads()
+	require.Len(t, workloads, 3)
+
+	// each of the top-level workloads shares the root command and has its own subcommand
+	for i, subCmdName := range []string{"webstore", "redis", "platform"} {
+		assert.Equal(t, "acmectl", workloads[i].GetRootCommand().Name)
+		assert.Equal(t, "Manage acme workloads", workloads[i].GetRootCommand().Description)
+		assert.True(t, workloads[i].HasSubCmdName())
+		assert.Equal(t, subCmdName, workloads[i].GetSubCommand().Name)
+	}
+
+	// the components belong to the collection rather than the first workload
+	assert.Empty(t, processor.Children)
+	require.Len(t, processor.Peers[1].Children, 1)
+	assert.Equal(t, "component-valid-config", processor.Peers[1].Children[0].Workload.GetName())
+}
+
+func TestParseNestedCollections(t *testing.T) {
+	t.Parallel()
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+
+	processor, err := Parse(wd+"/../../../../test/configs/collection/valid-nested.yaml", nil)
+	require.NoError(t, err)
+
+	require.Len(t, processor.Children, 1)
+	require.Len(t, processor.Children[0].Children, 1)
+
+	platform, ok := processor.Workload.(*kinds.WorkloadCollection)
+	require.True(t, ok)
+
+	networking, ok := processor.Children[0].Workload.(*kinds.WorkloadCollection)
+	require.True(t, ok)
+
+	// the nested collection belongs to its parent collection and shares its domain
+	assert.False(t, platform.IsComponent())
+	assert.True(t, networking.IsComponent())
+	assert.Equal(t, platform, networking.GetCollection())
+	assert.Equal(t, "acme.com", networking.GetDomain())
+
+	// the nested collection is managed from a subcommand of the companion CLI of its parent
+	assert.Equal(t, "platformctl", networking.GetRootCommand().Name)
+	assert.Equal(t, "networking", networking.GetSubCommand().Name)
+
+	// the components of the nested collection are children of the nested collection
+	assert.Equal(t, "component-nested-ingress", processor.Children[0].Children[0].Workload.GetName())
+	assert.Len(t, processor.GetWorkloads(), 3)
+}
@@ -18,9 +18,15 @@ type Processor struct {
 
 	// Workload represents the top-level configuration (e.g. as passed in via the --workload-config) flag
 	// from the command line, while Children represents subordinate configurations that the parent files such
-	// as the componentFiles field.
+	// as the componentFiles field.  Peers represents additional top-level configurations which are found
+	// within the same file as the top-level configuration.
 	Workload kinds.WorkloadBuilder
 	Children []*Processor
+	Peers    []*Processor
+
+	// Variables are substituted for references to variables within the workload config and the
+	// configurations of its children.
+	Variables Variables
 }
 
 // NewProcessor will return a new workload config processor given a path.  An error is returned if the workload config
@@ -33,6 +39,22 @@ func NewProcessor(configPath string) (*Processor, error) {
 	return &Processor{Path: configPath}, nil
 }
 
+// GetTopLevelProcessors gets the processor along with each of its peers.
+func (processor *Processor) GetTopLevelProcessors() []*Processor {
+	return append([]*Processor{processor}, processor.Peers...)
+}
+
+// GetTopLevelWorkloads gets the workload of the processor along with the workloads of each of its peers.
+func (processor *Processor) GetTopLevelWorkloads() []kinds.WorkloadBuilder {
+	workloads := []kinds.WorkloadBuilder{processor.Workload}
+
+	for i := range processor.Peers {
+		workloads = append(workloads, processor.Peers[i].Workload)
+	}
+
+	return workloads
+}
+
 // GetWorkloads gets all of the workloads for a config processor in a flattened
 // fashion.
 func (processor *Processor) GetWorkloads() []kinds.WorkloadBuilder {
@@ -0,0 +1,222 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+//go:generate go run ./schemagen workload-config.schema.json
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/nukleros/operator-builder/internal/workload/v1/kinds"
+)
+
+// Schema is the JSON Schema for workload config files.  It is generated from the workload
+// types with `go generate` and may be used by editors for validation and completion.
+//
+//go:embed workload-config.schema.json
+var Schema []byte
+
+const (
+	schemaDraft = "http://json-schema.org/draft-07/schema#"
+	schemaTitle = "Operator Builder Workload Config"
+
+	schemaTagName            = "jsonschema"
+	schemaDescriptionTagName = "jsonschema_description"
+	schemaRequired           = "required"
+)
+
+// schemaObject represents a JSON Schema object.  Keys are sorted when the object is
+// marshaled, so the generated schema is deterministic.
+type schemaObject map[string]interface{}
+
+// schemaGenerator generates JSON Schema definitions from go types.
+type schemaGenerator struct {
+	definitions schemaObject
+}
+
+// GenerateSchema generates the JSON Schema for workload config files from the workload types.  A
+// document of a workload config file must match one of the workload kinds.
+func GenerateSchema() ([]byte, error) {
+	generator := &schemaGenerator{definitions: schemaObject{}}
+
+	workloads := []struct {
+		kind     kinds.WorkloadKind
+		workload interface{}
+	}{
+		{kind: kinds.WorkloadKindStandalone, workload: kinds.StandaloneWorkload{}},
+		{kind: kinds.WorkloadKindCollection, workload: kinds.WorkloadCollection{}},
+		{kind: kinds.WorkloadKindComponent, workload: kinds.ComponentWorkload{}},
+	}
+
+	oneOf := make([]schemaObject, len(workloads))
+
+	for i, workload := range workloads {
+		workloadType := reflect.TypeOf(workload.workload)
+
+		oneOf[i] = generator.typeSchema(workloadType)
+
+		// each workload definition only accepts its own kind and the known versions
+		definition, ok := generator.definitions[workloadType.Name()].(schemaObject)
+		if !ok {
+			return nil, fmt.Errorf("missing schema definition for workload kind %s", workload.kind)
+		}
+
+		properties, ok := definition["properties"].(schemaObject)
+		if !ok {
+			return nil, fmt.Errorf("missing schema properties for workload kind %s", workload.kind)
+		}
+
+		properties["apiVersion"] = schemaObject{
+			"description": "The version of the workload config format.",
+			"enum":        kinds.APIVersions(),
+		}
+
+		properties["kind"] = schemaObject{
+			"description": "The kind of workload.",
+			"const":       workload.kind.String(),
+		}
+	}
+
+	schema := schemaObject{
+		"$schema":     schemaDraft,
+		"title":       schemaTitle,
+		"oneOf":       oneOf,
+		"definitions": generator.definitions,
+	}
+
+	buf := new(bytes.Buffer)
+	encoder := json.NewEncoder(buf)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+
+	if err := encoder.Encode(schema); err != nil {
+		return nil, fmt.Errorf("%w; unable to encode workload config schema", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// typeSchema returns the schema for a go type.  Structs are added as definitions and returned as
+// a reference to their definition.
+func (generator *schemaGenerator) typeSchema(goType reflect.Type) schemaObject {
+	if goType.Kind() == reflect.Ptr {
+		goType = goType.Elem()
+	}
+
+	if goType == reflect.TypeOf(kinds.WorkloadKind(0)) {
+		return schemaObject{
+			"type": "string",
+			"enum": []string{
+				kinds.WorkloadKindStandalone.String(),
+				kinds.WorkloadKindCollection.String(),
+				kinds.WorkloadKindComponent.String(),
+			},
+		}
+	}
+
+	//nolint:exhaustive
+	switch goType.Kind() {
+	case reflect.Struct:
+		if _, found := generator.definitions[goType.Name()]; !found {
+			// reserve the definition before generating it to support recursive types
+			generator.definitions[goType.Name()] = schemaObject{}
+			generator.definitions[goType.Name()] = generator.structSchema(goType)
+		}
+
+		return schemaObject{"$ref": "#/definitions/" + goType.Name()}
+	case reflect.Slice, reflect.Array:
+		return schemaObject{"type": "array", "items": generator.typeSchema(goType.Elem())}
+	case reflect.Map:
+		return schemaObject{"type": "object", "additionalProperties": generator.typeSchema(goType.Elem())}
+	case reflect.String:
+		return schemaObject{"type": "string"}
+	case reflect.Bool:
+		return schemaObject{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return schemaObject{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return schemaObject{"type": "number"}
+	}
+
+	return schemaObject{}
+}
+
+// structSchema returns the schema for a struct.  The properties of the struct follow the rules
+// that are used when decoding a workload config, with the exception that fields which are only
+// used internally, those tagged without an explicit name, are excluded.
+func (generator *schemaGenerator) structSchema(goType reflect.Type) schemaObject {
+	properties := schemaObject{}
+	required := []string{}
+
+	generator.addProperties(goType, properties, &required)
+
+	schema := schemaObject{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+
+	return schema
+}
+
+// addProperties adds the properties and required properties of a struct to a schema.  Inline
+// structs have their properties added directly.
+func (generator *schemaGenerator) addProperties(goType reflect.Type, properties schemaObject, required *[]string) {
+	for i := 0; i < goType.NumField(); i++ {
+		field := goType.Field(i)
+
+		if !field.IsExported() {
+			continue
+		}
+
+		name, options, tagged := strings.Cut(field.Tag.Get("yaml"), ",")
+		if name == "-" {
+			continue
+		}
+
+		if strings.Contains(options, "inline") {
+			generator.addProperties(field.Type, properties, required)
+
+			continue
+		}
+
+		// fields which are tagged without an explicit name are only used internally
+		if name == "" {
+			if tagged || field.Tag.Get("json") != "" {
+				continue
+			}
+
+			name = strings.ToLower(field.Name)
+		}
+
+		property := generator.typeSchema(field.Type)
+
+		if description := field.Tag.Get(schemaDescriptionTagName); description != "" {
+			// references may not have sibling keywords in draft-07, so they are wrapped
+			if _, isRef := property["$ref"]; isRef {
+				property = schemaObject{"allOf": []schemaObject{property}}
+			}
+
+			property["description"] = description
+		}
+
+		properties[name] = property
+
+		for _, option := range strings.Split(field.Tag.Get(schemaTagName), ",") {
+			if option == schemaRequired {
+				*required = append(*required, name)
+			}
+		}
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestGenerateSchema(t *testing.T) {
+	t.Parallel()
+
+	schema, err := GenerateSchema()
+	if err != nil {
+		t.Fatalf("GenerateSchema() error = %v", err)
+	}
+
+	if string(schema) != string(Schema) {
+		t.Fatalf("embedded workload config schema is out of date; run `go generate ./internal/workload/v1/config`")
+	}
+
+	var parsed struct {
+		OneOf       []map[string]string               `json:"oneOf"`
+		Definitions map[string]map[string]interface{} `json:"definitions"`
+	}
+
+	if err := json.Unmarshal(schema, &parsed); err != nil {
+		t.Fatalf("unable to unmarshal schema: %v", err)
+	}
+
+	if len(parsed.OneOf) != 3 {
+		t.Errorf("GenerateSchema() has %d workload kinds, want 3", len(parsed.OneOf))
+	}
+
+	for _, definition := range []string{"StandaloneWorkload", "WorkloadCollection", "ComponentWorkload", "WorkloadAPISpec"} {
+		properties, ok := parsed.Definitions[definition]["properties"].(map[string]interface{})
+		if !ok {
+			t.Errorf("GenerateSchema() missing definition %s", definition)
+
+			continue
+		}
+
+		// internal fields must not be exposed in the schema
+		for _, internal := range []string{"manifests", "fieldmarkers", "packagename", "components"} {
+			if _, found := properties[internal]; found {
+				t.Errorf("GenerateSchema() definition %s exposes internal field %s", definition, internal)
+			}
+		}
+	}
+}
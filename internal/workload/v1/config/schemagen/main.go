@@ -0,0 +1,31 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+// Command schemagen generates the JSON Schema for workload config files.  It is run with
+// `go generate` from the config package.
+package main
+
+import (
+	"os"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/nukleros/operator-builder/internal/workload/v1/config"
+)
+
+const permissions = 0644
+
+func main() {
+	if len(os.Args) != 2 {
+		log.Fatalf("usage: %s <output file>", os.Args[0])
+	}
+
+	schema, err := config.GenerateSchema()
+	if err != nil {
+		log.Fatalf("unable to generate workload config schema: %s", err)
+	}
+
+	if err := os.WriteFile(os.Args[1], schema, permissions); err != nil {
+		log.Fatalf("unable to write workload config schema: %s", err)
+	}
+}
@@ -4,17 +4,25 @@
 package config
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 
 	structvalidator "github.com/go-playground/validator"
+	"gopkg.in/yaml.v3"
 
+	"github.com/nukleros/operator-builder/internal/utils"
 	"github.com/nukleros/operator-builder/internal/workload/v1/kinds"
+	"github.com/nukleros/operator-builder/internal/workload/v1/manifests"
 )
 
 var (
-	ErrUniqueNames = errors.New("each workload name must be unique")
-	ErrUniqueKinds = errors.New("each kind within a group must be unique")
+	ErrUniqueNames        = errors.New("each workload name must be unique")
+	ErrUniqueKinds        = errors.New("each kind within a group must be unique")
+	ErrRecursiveComponent = errors.New("component file is referenced by one of its own collections")
 )
 
 type inlineValidator struct {
@@ -75,3 +83,206 @@ func (validator *inlineValidator) validateKind(group, kind string) error {
 
 	return nil
 }
+
+// bundleValidator validates a workload config bundle, which is a workload config and all of the
+// component configs that it references, collecting all errors rather than failing fast.
+type bundleValidator struct {
+	inlineValidator
+	variables  Variables
+	components []*kinds.ComponentWorkload
+	paths      map[string]bool
+	errs       []error
+}
+
+// ValidateBundle validates a workload config and all of the component configs which it references.
+// Unlike Parse, which returns the first error found, all errors within the bundle are returned
+// together.  The variables are substituted for references to variables within the workload configs.
+func ValidateBundle(configPath string, variables Variables) error {
+	if configPath == "" {
+		return ErrConfigMustExist
+	}
+
+	bundle := &bundleValidator{
+		inlineValidator: inlineValidator{
+			names:         make(map[string]bool),
+			kindsInGroups: make(map[string][]string),
+		},
+		variables: variables,
+		paths:     make(map[string]bool),
+	}
+
+	for _, workload := range bundle.validateFile(configPath, nil) {
+		if workload.IsComponent() {
+			bundle.addError(configPath, fmt.Errorf(
+				"%w; found %s %s",
+				ErrCollectionRequired, kinds.WorkloadKindComponent, workload.GetName(),
+			))
+		}
+	}
+
+	// ensure that the dependencies of each component exist within the bundle
+	for _, component := range bundle.components {
+		for _, dependency := range component.Spec.Dependencies {
+			if !bundle.names[dependency] {
+				bundle.addError(component.Spec.ConfigPath, fmt.Errorf(
+					"%w; missing [%s] for component: [%s]",
+					ErrMissingDependencies, dependency, component.Name,
+				))
+			}
+		}
+	}
+
+	return errors.Join(bundle.errs...)
+}
+
+// addError adds an error for a particular workload config file.
+func (bundle *bundleValidator) addError(path string, err error) {
+	bundle.errs = append(bundle.errs, fmt.Errorf("%s: %w", path, err))
+}
+
+// validateFile validates each of the workloads within a workload config file and returns the
+// workloads which could be decoded.  The collection is the collection which references the file
+// as a component file, if any, and is set as the parent of any collections within the file.
+func (bundle *bundleValidator) validateFile(path string, collection *kinds.WorkloadCollection) []kinds.WorkloadBuilder {
+	// a file which references itself through nested collections would otherwise be validated
+	// endlessly
+	if bundle.paths[path] {
+		bundle.addError(path, ErrRecursiveComponent)
+
+		return nil
+	}
+
+	bundle.paths[path] = true
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		bundle.addError(path, err)
+
+		return nil
+	}
+
+	if content, err = bundle.variables.Expand(content); err != nil {
+		bundle.addError(path, err)
+
+		return nil
+	}
+
+	sharedDecoder := yaml.NewDecoder(bytes.NewReader(content))
+	kindDecoder := yaml.NewDecoder(bytes.NewReader(content))
+
+	kindDecoder.KnownFields(true)
+
+	var workloads []kinds.WorkloadBuilder
+
+	for {
+		var document yaml.Node
+
+		if err := sharedDecoder.Decode(&document); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			// a syntax error prevents the remainder of the file from being read
+			bundle.addError(path, err)
+
+			break
+		}
+
+		var workloadID kinds.WorkloadShared
+
+		if err := document.Decode(&workloadID); err != nil || workloadID.Kind == kinds.WorkloadKindUnknown {
+			bundle.addError(path, fmt.Errorf("line %d: %w", document.Line, kinds.ErrInvalidKind))
+
+			// advance the kind decoder past the document
+			if err := kindDecoder.Decode(&yaml.Node{}); err != nil {
+				break
+			}
+
+			continue
+		}
+
+		workload, err := kinds.Decode(workloadID.APIVersion, workloadID.Kind, kindDecoder)
+		if err != nil {
+			bundle.addError(path, err)
+
+			continue
+		}
+
+		if nested, ok := workload.(*kinds.WorkloadCollection); ok && collection != nil {
+			nested.Spec.ParentCollection = collection
+			nested.Spec.API.Domain = collection.Spec.API.Domain
+		}
+
+		bundle.validateWorkload(path, workload)
+
+		workloads = append(workloads, workload)
+	}
+
+	return workloads
+}
+
+// validateWorkload validates a single workload and, for collections, its component configs.
+func (bundle *bundleValidator) validateWorkload(path string, workload kinds.WorkloadBuilder) {
+	if err := structvalidator.New().Struct(workload); err != nil {
+		bundle.addError(path, err)
+	}
+
+	if err := bundle.validateName(workload.GetName()); err != nil {
+		bundle.addError(path, err)
+	}
+
+	if err := workload.Validate(); err != nil {
+		bundle.addError(path, err)
+	}
+
+	if err := bundle.validateKind(workload.GetAPIGroup(), workload.GetAPIKind()); err != nil {
+		bundle.addError(path, err)
+	}
+
+	bundle.names[workload.GetName()] = true
+	bundle.kindsInGroups[workload.GetAPIGroup()] = append(bundle.kindsInGroups[workload.GetAPIGroup()], workload.GetAPIKind())
+
+	var resources []string
+
+	switch typed := workload.(type) {
+	case *kinds.StandaloneWorkload:
+		resources = typed.Spec.Resources
+	case *kinds.ComponentWorkload:
+		resources = typed.Spec.Resources
+		typed.Spec.ConfigPath = path
+
+		bundle.components = append(bundle.components, typed)
+	case *kinds.WorkloadCollection:
+		resources = typed.Spec.Resources
+
+		bundle.validateComponentFiles(path, typed)
+	}
+
+	// ensure that each of the resources can be found
+	for _, resource := range resources {
+		if _, err := manifests.ExpandManifests(filepath.Dir(path), []string{resource}); err != nil {
+			bundle.addError(path, err)
+		}
+	}
+}
+
+// validateComponentFiles validates each of the component configs which are referenced by a collection.
+func (bundle *bundleValidator) validateComponentFiles(path string, collection *kinds.WorkloadCollection) {
+	for _, componentFile := range collection.Spec.ComponentFiles {
+		componentPaths, err := utils.Glob(filepath.Join(filepath.Dir(path), componentFile))
+		if err != nil {
+			bundle.addError(path, fmt.Errorf("%w; error globbing workload config at path %s", err, componentFile))
+
+			continue
+		}
+
+		for _, componentPath := range componentPaths {
+			for _, workload := range bundle.validateFile(componentPath, collection) {
+				if !workload.IsComponent() {
+					bundle.addError(componentPath, fmt.Errorf(
+						"%w for workload %s; component files may only contain %s or %s workloads",
+						ErrConvertComponent, workload.GetName(), kinds.WorkloadKindComponent, kinds.WorkloadKindCollection,
+					))
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"os"
+	"testing"
+)
+
+func TestValidateBundle(t *testing.T) {
+	t.Parallel()
+
+	wd, err := os.Getwd()
+	if err != nil {
+		panic("unable to get working directory for `validate_internal_test.go` test")
+	}
+
+	testPath := wd + "/../../../../test/"
+
+	tests := []struct {
+		name       string
+		configPath string
+		wantErrs   int
+	}{
+		{
+			name:       "ensure valid simple standalone does not return an error",
+			configPath: testPath + "cases/standalone/.workloadConfig/workload.yaml",
+			wantErrs:   0,
+		},
+		{
+			name:       "ensure valid complex collection does not return an error",
+			configPath: testPath + "cases/edge-collection/.workloadConfig/workload.yaml",
+			wantErrs:   0,
+		},
+		{
+			name:       "ensure passing a component workload as the parent returns an error",
+			configPath: testPath + "configs/component/valid.yaml",
+			wantErrs:   2,
+		},
+		{
+			name:       "ensure file with invalid yaml returns an error",
+			configPath: testPath + "configs/component/invalid-yaml.yaml",
+			wantErrs:   1,
+		},
+		{
+			name:       "ensure workload with invalid type returns an error",
+			configPath: testPath + "configs/invalid-type.yaml",
+			wantErrs:   1,
+		},
+		{
+			name:       "ensure collection with overlapping names and missing resources returns each error",
+			configPath: testPath + "configs/collection/invalid-overlapping-names.yaml",
+			wantErrs:   3,
+		},
+		{
+			name:       "ensure valid nested collections do not return an error",
+			configPath: testPath + "configs/collection/valid-nested.yaml",
+			wantErrs:   0,
+		},
+		{
+			name:       "ensure nested collection which references itself returns an error",
+			configPath: testPath + "configs/collection/invalid-nested-recursive.yaml",
+			wantErrs:   1,
+		},
+		{
+			name:       "ensure all errors within a bundle are returned together",
+			configPath: testPath + "configs/collection/invalid-multiple-errors.yaml",
+			wantErrs:   4,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := ValidateBundle(tt.configPath, nil)
+
+			var gotErrs int
+
+			if joined, ok := err.(interface{ Unwrap() []error }); ok {
+				gotErrs = len(joined.Unwrap())
+			} else if err != nil {
+				gotErrs = 1
+			}
+
+			if gotErrs != tt.wantErrs {
+				t.Errorf("ValidateBundle() returned %d errors, want %d; error = %v", gotErrs, tt.wantErrs, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	ErrInvalidVariable   = errors.New("invalid config variable - must be in the format key=value")
+	ErrUndefinedVariable = errors.New("undefined variables in workload config")
+)
+
+// variablePattern matches a reference to a variable in the form of ${VAR} or ${VAR:-default}.  A
+// reference which is escaped in the form of $${VAR} is not expanded.
+var variablePattern = regexp.MustCompile(`\$(\$?)\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+var variableNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// blockScalarPattern matches the end of a line which begins a literal or folded block scalar, whose
+// content continues on the lines which are indented further.
+var blockScalarPattern = regexp.MustCompile(`(^|[\s:-])[|>][0-9+-]*$`)
+
+// Variables are the values which are substituted for references to variables within a workload
+// config.  References to variables which are not defined are substituted from the environment.
+type Variables map[string]string
+
+// ParseVariables parses a set of variables which are given in the format of key=value.
+func ParseVariables(assignments []string) (Variables, error) {
+	variables := Variables{}
+
+	for _, assignment := range assignments {
+		key, value, found := strings.Cut(assignment, "=")
+		if !found || !variableNamePattern.MatchString(key) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidVariable, assignment)
+		}
+
+		variables[key] = value
+	}
+
+	return variables, nil
+}
+
+// lookup returns the value of a variable, first from the set of variables and then from the
+// environment.
+func (variables Variables) lookup(key string) (string, bool) {
+	if value, found := variables[key]; found {
+		return value, true
+	}
+
+	return os.LookupEnv(key)
+}
+
+// Expand substitutes the references to variables within the content of a workload config.  A
+// reference in the form of ${VAR:-default} is substituted with its default when the variable is
+// undefined or empty.  References within comments are not substituted.  An error is returned listing
+// each variable which is referenced without a default and is undefined.
+func (variables Variables) Expand(content []byte) ([]byte, error) {
+	undefined := map[string]bool{}
+
+	var expanded bytes.Buffer
+
+	// the indentation of the line which begins a block scalar, or -1 when outside of a block scalar
+	blockIndent := -1
+
+	for _, line := range bytes.SplitAfter(content, []byte("\n")) {
+		indent := len(line) - len(bytes.TrimLeft(line, " \t"))
+
+		// the lines of a block scalar are content, even when they begin with a #
+		if blockIndent >= 0 {
+			if len(bytes.TrimSpace(line)) == 0 || indent > blockIndent {
+				expanded.Write(variables.expandReferences(line, undefined))
+
+				continue
+			}
+
+			blockIndent = -1
+		}
+
+		code := line[:commentStart(line)]
+
+		expanded.Write(variables.expandReferences(code, undefined))
+		expanded.Write(line[len(code):])
+
+		if blockScalarPattern.Match(bytes.TrimRight(code, " \t\r\n")) {
+			blockIndent = indent
+		}
+	}
+
+	if len(undefined) > 0 {
+		keys := make([]string, 0, len(undefined))
+		for key := range undefined {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		return nil, fmt.Errorf("%w: %s", ErrUndefinedVariable, strings.Join(keys, ", "))
+	}
+
+	return expanded.Bytes(), nil
+}
+
+// expandReferences substitutes the references to variables within a part of a workload config,
+// recording the variables which are referenced without a default and are undefined.
+func (variables Variables) expandReferences(text []byte, undefined map[string]bool) []byte {
+	return variablePattern.ReplaceAllFunc(text, func(reference []byte) []byte {
+		match := variablePattern.FindSubmatch(reference)
+
+		// an escaped reference is kept as a literal reference
+		if len(match[1]) > 0 {
+			return reference[1:]
+		}
+
+		key, hasDefault, defaultValue := string(match[2]), len(match[3]) > 0, match[4]
+
+		value, found := variables.lookup(key)
+
+		switch {
+		case hasDefault && value == "":
+			return defaultValue
+		case !found:
+			undefined[key] = true
+
+			return reference
+		}
+
+		return []byte(value)
+	})
+}
+
+// commentStart returns the index at which the comment of a line of a workload config begins, or the
+// length of the line if it has no comment.  A # begins a comment when it is at the start of the line
+// or follows whitespace, and is not within a quoted string.
+func commentStart(line []byte) int {
+	var quote byte
+
+	for i := 0; i < len(line); i++ {
+		char := line[i]
+
+		switch {
+		case quote == '"' && char == '\\':
+			// the next character of a double quoted string is escaped
+			i++
+		case quote == '\'' && char == '\'' && i+1 < len(line) && line[i+1] == '\'':
+			// a single quote within a single quoted string is escaped by another single quote
+			i++
+		case quote != 0:
+			if char == quote {
+				quote = 0
+			}
+		case (char == '\'' || char == '"') && (i == 0 || strings.IndexByte(" \t:-[{,", line[i-1]) >= 0):
+			quote = char
+		case char == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return i
+		}
+	}
+
+	return len(line)
+}
@@ -0,0 +1,138 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVariables(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		name        string
+		assignments []string
+		want        Variables
+		wantErr     bool
+	}{
+		{
+			name:        "ensure variables are parsed",
+			assignments: []string{"DOMAIN=acme.com", "CLI_NAME=", "QUERY=a=b"},
+			want:        Variables{"DOMAIN": "acme.com", "CLI_NAME": "", "QUERY": "a=b"},
+		},
+		{
+			name:        "ensure missing value returns an error",
+			assignments: []string{"DOMAIN"},
+			wantErr:     true,
+		},
+		{
+			name:        "ensure invalid name returns an error",
+			assignments: []string{"1DOMAIN=acme.com"},
+			wantErr:     true,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseVariables(tt.assignments)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidVariable)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestVariablesExpand(t *testing.T) {
+	t.Parallel()
+
+	variables := Variables{
+		"DOMAIN":   "acme.com",
+		"EMPTY":    "",
+		"CLI_NAME": "acmectl",
+	}
+
+	for _, tt := range []struct {
+		name    string
+		content string
+		want    string
+		wantErr string
+	}{
+		{
+			name:    "ensure variables are expanded",
+			content: "domain: ${DOMAIN}\nname: ${CLI_NAME}-admin",
+			want:    "domain: acme.com\nname: acmectl-admin",
+		},
+		{
+			name:    "ensure defaults are used for undefined and empty variables",
+			content: "group: ${GROUP:-apps}\nkind: ${EMPTY:-MyApp}\ndomain: ${DOMAIN:-example.com}",
+			want:    "group: apps\nkind: MyApp\ndomain: acme.com",
+		},
+		{
+			name:    "ensure empty defaults are allowed",
+			content: "description: '${DESCRIPTION:-}'",
+			want:    "description: ''",
+		},
+		{
+			name:    "ensure escaped references and other dollar signs are kept",
+			content: "value: $${DOMAIN} costs $5 $DOMAIN",
+			want:    "value: ${DOMAIN} costs $5 $DOMAIN",
+		},
+		{
+			name:    "ensure references within comments are not expanded",
+			content: "# requires ${OPERATOR_BUILDER_TEST_UNDEFINED_A}\ndomain: ${DOMAIN} # or ${OPERATOR_BUILDER_TEST_UNDEFINED_B}\n",
+			want:    "# requires ${OPERATOR_BUILDER_TEST_UNDEFINED_A}\ndomain: acme.com # or ${OPERATOR_BUILDER_TEST_UNDEFINED_B}\n",
+		},
+		{
+			name:    "ensure a # within a quoted string or a value does not begin a comment",
+			content: "a: \"x # ${DOMAIN}\"\nb: 'it''s # ${DOMAIN}'\nc: \"\\\" # ${DOMAIN}\"\nd: it's#${DOMAIN} # ${OPERATOR_BUILDER_TEST_UNDEFINED_A}\n",
+			want:    "a: \"x # acme.com\"\nb: 'it''s # acme.com'\nc: \"\\\" # acme.com\"\nd: it's#acme.com # ${OPERATOR_BUILDER_TEST_UNDEFINED_A}\n",
+		},
+		{
+			name:    "ensure a # within a block scalar does not begin a comment",
+			content: "script: |\n  # ${DOMAIN}\n\n  echo ${CLI_NAME}\n# ${OPERATOR_BUILDER_TEST_UNDEFINED_A}\nname: ${CLI_NAME}\n",
+			want:    "script: |\n  # acme.com\n\n  echo acmectl\n# ${OPERATOR_BUILDER_TEST_UNDEFINED_A}\nname: acmectl\n",
+		},
+		{
+			name:    "ensure undefined variables without defaults return an error",
+			content: "group: ${OPERATOR_BUILDER_TEST_UNDEFINED_B}\nkind: ${OPERATOR_BUILDER_TEST_UNDEFINED_A}",
+			wantErr: "OPERATOR_BUILDER_TEST_UNDEFINED_A, OPERATOR_BUILDER_TEST_UNDEFINED_B",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := variables.Expand([]byte(tt.content))
+			if tt.wantErr != "" {
+				require.ErrorIs(t, err, ErrUndefinedVariable)
+				assert.Contains(t, err.Error(), tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestVariablesExpandEnvironment(t *testing.T) {
+	t.Setenv("OPERATOR_BUILDER_TEST_GROUP", "platform")
+	t.Setenv("OPERATOR_BUILDER_TEST_KIND", "Environment")
+
+	variables := Variables{"OPERATOR_BUILDER_TEST_KIND": "Flag"}
+
+	got, err := variables.Expand([]byte("group: ${OPERATOR_BUILDER_TEST_GROUP}\nkind: ${OPERATOR_BUILDER_TEST_KIND}"))
+	require.NoError(t, err)
+	assert.Equal(t, "group: platform\nkind: Flag", string(got))
+}
@@ -0,0 +1,218 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/nukleros/operator-builder/internal/workload/v1/config"
+	"github.com/nukleros/operator-builder/internal/workload/v1/kinds"
+	"github.com/nukleros/operator-builder/internal/workload/v1/manifests"
+)
+
+var ErrDependencyCycle = errors.New("dependency cycle detected")
+
+// Graph represents the workloads of a workload config along with the relationships between them
+// and the resources which each of them manage.
+type Graph struct {
+	Workloads []*Workload `json:"workloads"`
+
+	// Cycles contains the path of each of the dependency cycles between the components.  Each path
+	// begins and ends with the same component.
+	Cycles [][]string `json:"cycles,omitempty"`
+}
+
+// Workload represents a single workload within the graph.  Workloads refer to one another by name.
+type Workload struct {
+	Name                 string   `json:"name"`
+	Kind                 string   `json:"kind"`
+	APIKind              string   `json:"apiKind"`
+	Collection           string   `json:"collection,omitempty"`
+	Components           []string `json:"components,omitempty"`
+	Dependencies         []string `json:"dependencies,omitempty"`
+	ExternalDependencies []string `json:"externalDependencies,omitempty"`
+	Resources            []string `json:"resources,omitempty"`
+}
+
+// New returns the graph of the workloads for a processor which has been parsed.  The manifests of
+// each workload are loaded in order to determine the child resources of the workload.
+func New(processor *config.Processor) (*Graph, error) {
+	graph := &Graph{Workloads: []*Workload{}}
+
+	for _, topLevelProcessor := range processor.GetTopLevelProcessors() {
+		if err := graph.add(topLevelProcessor, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	graph.Cycles = graph.FindCycles()
+
+	return graph, nil
+}
+
+// CycleError returns an error which reports the path of each of the dependency cycles within the
+// graph, or nil if there are no dependency cycles.
+func (graph *Graph) CycleError() error {
+	if len(graph.Cycles) == 0 {
+		return nil
+	}
+
+	paths := make([]string, len(graph.Cycles))
+	for i, cycle := range graph.Cycles {
+		paths[i] = strings.Join(cycle, " -> ")
+	}
+
+	return fmt.Errorf("%w; found cycles [%s]", ErrDependencyCycle, strings.Join(paths, ", "))
+}
+
+// add adds the workload of a processor, along with the workloads of its children, to the graph.
+func (graph *Graph) add(processor *config.Processor, collection string) error {
+	workload := processor.Workload
+
+	if err := workload.LoadManifests(filepath.Dir(processor.Path)); err != nil {
+		return fmt.Errorf("%w; error loading manifests for workload %s", err, workload.GetName())
+	}
+
+	resources, err := childResources(workload)
+	if err != nil {
+		return fmt.Errorf("%w; error determining child resources for workload %s", err, workload.GetName())
+	}
+
+	node := &Workload{
+		Name:       workload.GetName(),
+		Kind:       workload.GetWorkloadKind().String(),
+		APIKind:    workload.GetAPIKind(),
+		Collection: collection,
+		Resources:  resources,
+	}
+
+	for _, dependency := range workload.GetDependencies() {
+		node.Dependencies = append(node.Dependencies, dependency.GetName())
+	}
+
+	for _, dependency := range workload.GetExternalDependencies() {
+		node.ExternalDependencies = append(node.ExternalDependencies, externalName(dependency))
+	}
+
+	for _, child := range processor.Children {
+		node.Components = append(node.Components, child.Workload.GetName())
+	}
+
+	graph.Workloads = append(graph.Workloads, node)
+
+	for _, child := range processor.Children {
+		if err := graph.add(child, node.Name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// childResources returns the names of the resources which are managed by a workload whose
+// manifests have been loaded.
+func childResources(workload kinds.WorkloadBuilder) ([]string, error) {
+	resources := []string{}
+
+	for _, manifest := range *workload.GetManifests() {
+		references, err := manifest.References()
+		if err != nil {
+			return nil, fmt.Errorf("%w", err)
+		}
+
+		for _, provided := range references.Provides {
+			// a reference without a name is a kind which is defined by a custom resource definition
+			if provided.Name == "" {
+				continue
+			}
+
+			resources = append(resources, provided.String())
+		}
+	}
+
+	return resources, nil
+}
+
+// externalName returns the name of an external dependency as it is displayed in the graph.
+func externalName(dependency *kinds.ExternalDependency) string {
+	name := manifests.ObjectReference{
+		Group:     dependency.Group,
+		Kind:      dependency.Kind,
+		Namespace: dependency.Namespace,
+		Name:      dependency.Name,
+	}.String()
+
+	if dependency.Condition == "" {
+		return name
+	}
+
+	return fmt.Sprintf("%s (%s)", name, dependency.Condition)
+}
+
+// cycleFinder finds the dependency cycles of a graph with a depth-first search.
+type cycleFinder struct {
+	workloads map[string]*Workload
+	visited   map[string]bool
+	path      []string
+	cycles    [][]string
+}
+
+// FindCycles returns the path of each of the dependency cycles within the graph.  The workloads
+// are searched in the order in which they appear so that the results are stable.
+func (graph *Graph) FindCycles() [][]string {
+	finder := &cycleFinder{
+		workloads: map[string]*Workload{},
+		visited:   map[string]bool{},
+		cycles:    [][]string{},
+	}
+
+	for _, workload := range graph.Workloads {
+		finder.workloads[workload.Name] = workload
+	}
+
+	for _, workload := range graph.Workloads {
+		if !finder.visited[workload.Name] {
+			finder.visit(workload.Name)
+		}
+	}
+
+	return finder.cycles
+}
+
+// visit searches the dependencies of a workload.  A dependency upon a workload which is already
+// within the current path of the search completes a cycle.
+func (finder *cycleFinder) visit(name string) {
+	finder.visited[name] = true
+	finder.path = append(finder.path, name)
+
+	for _, dependency := range finder.workloads[name].Dependencies {
+		if start := finder.indexOf(dependency); start >= 0 {
+			cycle := append([]string{}, finder.path[start:]...)
+			finder.cycles = append(finder.cycles, append(cycle, dependency))
+
+			continue
+		}
+
+		if _, ok := finder.workloads[dependency]; ok && !finder.visited[dependency] {
+			finder.visit(dependency)
+		}
+	}
+
+	finder.path = finder.path[:len(finder.path)-1]
+}
+
+// indexOf returns the index of a workload within the current path of the search, or -1 if the
+// workload is not within the path.
+func (finder *cycleFinder) indexOf(name string) int {
+	for i := range finder.path {
+		if finder.path[i] == name {
+			return i
+		}
+	}
+
+	return -1
+}
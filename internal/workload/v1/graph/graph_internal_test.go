@@ -0,0 +1,252 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nukleros/operator-builder/internal/workload/v1/config"
+)
+
+func newTestGraph(t *testing.T, path string) *Graph {
+	t.Helper()
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+
+	processor, err := config.Parse(wd+"/../../../../test/configs/graph/"+path, nil)
+	require.NoError(t, err)
+
+	graph, err := New(processor)
+	require.NoError(t, err)
+
+	return graph
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	graph := newTestGraph(t, "valid.yaml")
+
+	assert.Equal(t, []*Workload{
+		{
+			Name:       "graph-platform",
+			Kind:       "WorkloadCollection",
+			APIKind:    "Platform",
+			Components: []string{"graph-ingress", "graph-metrics"},
+			Resources:  []string{"Namespace/platform"},
+		},
+		{
+			Name:                 "graph-ingress",
+			Kind:                 "ComponentWorkload",
+			APIKind:              "Ingress",
+			Collection:           "graph-platform",
+			ExternalDependencies: []string{"ClusterIssuer.cert-manager.io/letsencrypt (Ready)"},
+			Resources: []string{
+				"ServiceAccount/platform/ingress-controller",
+				"Deployment.apps/platform/ingress-controller",
+			},
+		},
+		{
+			Name:         "graph-metrics",
+			Kind:         "ComponentWorkload",
+			APIKind:      "Metrics",
+			Collection:   "graph-platform",
+			Dependencies: []string{"graph-ingress"},
+			Resources:    []string{},
+		},
+	}, graph.Workloads)
+	assert.Empty(t, graph.Cycles)
+	assert.NoError(t, graph.CycleError())
+}
+
+func TestNewWithCycle(t *testing.T) {
+	t.Parallel()
+
+	graph := newTestGraph(t, "cycle.yaml")
+
+	assert.Equal(t, [][]string{
+		{"graph-frontend", "graph-backend", "graph-database", "graph-frontend"},
+	}, graph.Cycles)
+
+	err := graph.CycleError()
+	require.ErrorIs(t, err, ErrDependencyCycle)
+	assert.Contains(t, err.Error(), "graph-frontend -> graph-backend -> graph-database -> graph-frontend")
+}
+
+func TestGraph_FindCycles(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		workloads []*Workload
+		expected  [][]string
+	}{
+		{
+			name: "no dependencies",
+			workloads: []*Workload{
+				{Name: "a"},
+				{Name: "b"},
+			},
+			expected: [][]string{},
+		},
+		{
+			name: "shared dependency is not a cycle",
+			workloads: []*Workload{
+				{Name: "a", Dependencies: []string{"b", "c"}},
+				{Name: "b", Dependencies: []string{"c"}},
+				{Name: "c"},
+			},
+			expected: [][]string{},
+		},
+		{
+			name: "self dependency",
+			workloads: []*Workload{
+				{Name: "a", Dependencies: []string{"a"}},
+			},
+			expected: [][]string{{"a", "a"}},
+		},
+		{
+			name: "cycle which does not include the first workload",
+			workloads: []*Workload{
+				{Name: "a", Dependencies: []string{"b"}},
+				{Name: "b", Dependencies: []string{"c"}},
+				{Name: "c", Dependencies: []string{"b"}},
+			},
+			expected: [][]string{{"b", "c", "b"}},
+		},
+		{
+			name: "multiple cycles",
+			workloads: []*Workload{
+				{Name: "a", Dependencies: []string{"b"}},
+				{Name: "b", Dependencies: []string{"a"}},
+				{Name: "c", Dependencies: []string{"d"}},
+				{Name: "d", Dependencies: []string{"c"}},
+			},
+			expected: [][]string{{"a", "b", "a"}, {"c", "d", "c"}},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			graph := &Graph{Workloads: tt.workloads}
+			assert.Equal(t, tt.expected, graph.FindCycles())
+		})
+	}
+}
+
+func TestGraph_Write(t *testing.T) {
+	t.Parallel()
+
+	graph := &Graph{
+		Workloads: []*Workload{
+			{
+				Name:       "platform",
+				Kind:       "WorkloadCollection",
+				APIKind:    "Platform",
+				Components: []string{"frontend", "backend"},
+			},
+			{
+				Name:                 "frontend",
+				Kind:                 "ComponentWorkload",
+				APIKind:              "Frontend",
+				Collection:           "platform",
+				Dependencies:         []string{"backend"},
+				ExternalDependencies: []string{"ClusterIssuer.cert-manager.io/letsencrypt (Ready)"},
+				Resources:            []string{"Deployment.apps/frontend"},
+			},
+			{
+				Name:         "backend",
+				Kind:         "ComponentWorkload",
+				APIKind:      "Backend",
+				Collection:   "platform",
+				Dependencies: []string{"frontend"},
+			},
+		},
+		Cycles: [][]string{{"frontend", "backend", "frontend"}},
+	}
+
+	tests := []struct {
+		name     string
+		format   string
+		expected string
+	}{
+		{
+			name:   "dot",
+			format: FormatDOT,
+			expected: `digraph workloads {
+  rankdir=LR;
+  w0 [label="platform\nPlatform", shape=box3d];
+  w1 [label="frontend\nFrontend", shape=box];
+  e0 [label="ClusterIssuer.cert-manager.io/letsencrypt (Ready)", shape=hexagon];
+  w1r0 [label="Deployment.apps/frontend", shape=note];
+  w2 [label="backend\nBackend", shape=box];
+  w0 -> w1 [label="contains"];
+  w0 -> w2 [label="contains"];
+  w1 -> w2 [label="depends on", color=red];
+  w1 -> e0 [label="depends on"];
+  w1 -> w1r0 [label="owns"];
+  w2 -> w1 [label="depends on", color=red];
+}
+`,
+		},
+		{
+			name:   "mermaid",
+			format: FormatMermaid,
+			expected: `flowchart LR
+  w0[["platform<br>Platform"]]
+  w1["frontend<br>Frontend"]
+  e0{{"ClusterIssuer.cert-manager.io/letsencrypt (Ready)"}}
+  w1r0("Deployment.apps/frontend")
+  w2["backend<br>Backend"]
+  w0 -->|contains| w1
+  w0 -->|contains| w2
+  w1 -->|depends on| w2
+  w1 -->|depends on| e0
+  w1 -->|owns| w1r0
+  w2 -->|depends on| w1
+  linkStyle 2,5 stroke:red
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var output bytes.Buffer
+
+			require.NoError(t, graph.Write(&output, tt.format))
+			assert.Equal(t, tt.expected, output.String())
+		})
+	}
+
+	t.Run("json", func(t *testing.T) {
+		t.Parallel()
+
+		var output bytes.Buffer
+
+		require.NoError(t, graph.Write(&output, FormatJSON))
+
+		decoded := &Graph{}
+		require.NoError(t, json.Unmarshal(output.Bytes(), decoded))
+		assert.Equal(t, graph, decoded)
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		t.Parallel()
+
+		assert.ErrorIs(t, graph.Write(&bytes.Buffer{}, "svg"), ErrUnsupportedFormat)
+	})
+}
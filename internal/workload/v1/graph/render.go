@@ -0,0 +1,213 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/nukleros/operator-builder/internal/workload/v1/kinds"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported graph format")
+
+const (
+	FormatDOT     = "dot"
+	FormatMermaid = "mermaid"
+	FormatJSON    = "json"
+)
+
+// Formats returns the formats in which a graph may be written.
+func Formats() []string {
+	return []string{FormatDOT, FormatMermaid, FormatJSON}
+}
+
+type nodeType int
+
+const (
+	nodeTypeWorkload nodeType = iota
+	nodeTypeCollection
+	nodeTypeExternal
+	nodeTypeResource
+)
+
+type node struct {
+	id       string
+	label    []string
+	nodeType nodeType
+}
+
+type edge struct {
+	from  string
+	to    string
+	label string
+	cycle bool
+}
+
+// Write writes the graph to a writer in the requested format.
+func (graph *Graph) Write(w io.Writer, format string) error {
+	var content string
+
+	switch format {
+	case FormatDOT:
+		content = graph.dot()
+	case FormatMermaid:
+		content = graph.mermaid()
+	case FormatJSON:
+		data, err := json.MarshalIndent(graph, "", "  ")
+		if err != nil {
+			return fmt.Errorf("%w; unable to marshal graph to json", err)
+		}
+
+		content = string(data) + "\n"
+	default:
+		return fmt.Errorf("%w [%s]; supported formats are [%s]", ErrUnsupportedFormat, format, strings.Join(Formats(), ", "))
+	}
+
+	if _, err := io.WriteString(w, content); err != nil {
+		return fmt.Errorf("%w; unable to write graph", err)
+	}
+
+	return nil
+}
+
+// dot returns the graph in the DOT language of Graphviz.
+func (graph *Graph) dot() string {
+	nodes, edges := graph.elements()
+
+	shapes := map[nodeType]string{
+		nodeTypeWorkload:   "box",
+		nodeTypeCollection: "box3d",
+		nodeTypeExternal:   "hexagon",
+		nodeTypeResource:   "note",
+	}
+
+	var content strings.Builder
+
+	content.WriteString("digraph workloads {\n")
+	content.WriteString("  rankdir=LR;\n")
+
+	for _, n := range nodes {
+		fmt.Fprintf(&content, "  %s [label=%s, shape=%s];\n", n.id, strconv.Quote(strings.Join(n.label, "\n")), shapes[n.nodeType])
+	}
+
+	for _, e := range edges {
+		attributes := "label=" + strconv.Quote(e.label)
+		if e.cycle {
+			attributes += ", color=red"
+		}
+
+		fmt.Fprintf(&content, "  %s -> %s [%s];\n", e.from, e.to, attributes)
+	}
+
+	content.WriteString("}\n")
+
+	return content.String()
+}
+
+// mermaid returns the graph as a Mermaid flowchart.
+func (graph *Graph) mermaid() string {
+	nodes, edges := graph.elements()
+
+	shapes := map[nodeType][2]string{
+		nodeTypeWorkload:   {"[", "]"},
+		nodeTypeCollection: {"[[", "]]"},
+		nodeTypeExternal:   {"{{", "}}"},
+		nodeTypeResource:   {"(", ")"},
+	}
+
+	var content strings.Builder
+
+	content.WriteString("flowchart LR\n")
+
+	for _, n := range nodes {
+		label := strings.ReplaceAll(strings.Join(n.label, "<br>"), `"`, "#quot;")
+		fmt.Fprintf(&content, "  %s%s\"%s\"%s\n", n.id, shapes[n.nodeType][0], label, shapes[n.nodeType][1])
+	}
+
+	cycleEdges := []string{}
+
+	for i, e := range edges {
+		fmt.Fprintf(&content, "  %s -->|%s| %s\n", e.from, e.label, e.to)
+
+		if e.cycle {
+			cycleEdges = append(cycleEdges, strconv.Itoa(i))
+		}
+	}
+
+	if len(cycleEdges) > 0 {
+		fmt.Fprintf(&content, "  linkStyle %s stroke:red\n", strings.Join(cycleEdges, ","))
+	}
+
+	return content.String()
+}
+
+// elements returns the nodes and edges of the graph.  Workloads, external dependencies and
+// resources are each given a unique identifier so that they may be rendered in any format.
+func (graph *Graph) elements() (nodes []node, edges []edge) {
+	workloadIDs := map[string]string{}
+
+	for i, workload := range graph.Workloads {
+		workloadIDs[workload.Name] = fmt.Sprintf("w%d", i)
+	}
+
+	cycleEdges := map[[2]string]bool{}
+
+	for _, cycle := range graph.Cycles {
+		for i := 1; i < len(cycle); i++ {
+			cycleEdges[[2]string{cycle[i-1], cycle[i]}] = true
+		}
+	}
+
+	externalIDs := map[string]string{}
+
+	for _, workload := range graph.Workloads {
+		id := workloadIDs[workload.Name]
+
+		n := node{id: id, label: []string{workload.Name, workload.APIKind}, nodeType: nodeTypeWorkload}
+		if workload.Kind == kinds.WorkloadKindCollectionString {
+			n.nodeType = nodeTypeCollection
+		}
+
+		nodes = append(nodes, n)
+
+		for _, component := range workload.Components {
+			edges = append(edges, edge{from: id, to: workloadIDs[component], label: "contains"})
+		}
+
+		for _, dependency := range workload.Dependencies {
+			edges = append(edges, edge{
+				from:  id,
+				to:    workloadIDs[dependency],
+				label: "depends on",
+				cycle: cycleEdges[[2]string{workload.Name, dependency}],
+			})
+		}
+
+		for _, dependency := range workload.ExternalDependencies {
+			externalID, ok := externalIDs[dependency]
+			if !ok {
+				externalID = fmt.Sprintf("e%d", len(externalIDs))
+				externalIDs[dependency] = externalID
+
+				nodes = append(nodes, node{id: externalID, label: []string{dependency}, nodeType: nodeTypeExternal})
+			}
+
+			edges = append(edges, edge{from: id, to: externalID, label: "depends on"})
+		}
+
+		for i, resource := range workload.Resources {
+			resourceID := fmt.Sprintf("%sr%d", id, i)
+
+			nodes = append(nodes, node{id: resourceID, label: []string{resource}, nodeType: nodeTypeResource})
+			edges = append(edges, edge{from: id, to: resourceID, label: "owns"})
+		}
+	}
+
+	return nodes, edges
+}
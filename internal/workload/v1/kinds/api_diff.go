@@ -0,0 +1,202 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package kinds
+
+import (
+	"fmt"
+	"strings"
+)
+
+// APIChangeType is the classification of a change to an API spec field between two generations
+// of an API.
+type APIChangeType string
+
+const (
+	APIChangeAdditive  APIChangeType = "additive"
+	APIChangeDefaulted APIChangeType = "defaulted"
+	APIChangeNarrowed  APIChangeType = "narrowed type"
+	APIChangeRemoved   APIChangeType = "removed"
+	APIChangeRenamed   APIChangeType = "renamed"
+)
+
+// APIChange is a change to an API spec field between a previous and the current generation of an
+// API.  A breaking change is one which may render existing custom resources invalid or change
+// their meaning.
+type APIChange struct {
+	Type         APIChangeType
+	Path         string
+	PreviousPath string
+	Breaking     bool
+	Detail       string
+}
+
+// String returns the description of the change.
+func (change *APIChange) String() string {
+	path := change.Path
+	if change.PreviousPath != "" && change.PreviousPath != change.Path {
+		path = fmt.Sprintf("%s -> %s", change.PreviousPath, change.Path)
+	}
+
+	if change.Detail == "" {
+		return fmt.Sprintf("%s %s", change.Type, path)
+	}
+
+	return fmt.Sprintf("%s %s (%s)", change.Type, path, change.Detail)
+}
+
+// DiffAPIFields compares the API spec fields of a previous generation of an API with those of the
+// current generation and classifies each of the changes.  Fields which were renamed may be given
+// as mappings from the current field to the previous field, otherwise a removed and an added field
+// of the same type and default, which share either a name or a parent, are treated as renamed.
+func DiffAPIFields(previous, current *APIFields, mappings []*FieldMapping) ([]*APIChange, error) {
+	previousLeaves, currentLeaves := previous.leaves(), current.leaves()
+
+	if err := validateFieldMappings(mappings, currentLeaves, previousLeaves); err != nil {
+		return nil, err
+	}
+
+	previousByPath := map[string]*apiLeaf{}
+	for _, leaf := range previousLeaves {
+		previousByPath[leaf.path] = leaf
+	}
+
+	changes := []*APIChange{}
+	matched := map[string]bool{}
+	added := []*apiLeaf{}
+
+	for _, currentLeaf := range currentLeaves {
+		previousLeaf, found := previousByPath[mapFieldPath(currentLeaf.path, mappings)]
+		if !found || matched[previousLeaf.path] {
+			added = append(added, currentLeaf)
+
+			continue
+		}
+
+		matched[previousLeaf.path] = true
+
+		changes = append(changes, diffAPILeaf(previousLeaf, currentLeaf)...)
+	}
+
+	removed := []*apiLeaf{}
+
+	for _, previousLeaf := range previousLeaves {
+		if !matched[previousLeaf.path] {
+			removed = append(removed, previousLeaf)
+		}
+	}
+
+	renamed := map[*apiLeaf]bool{}
+
+	for _, currentLeaf := range added {
+		for _, previousLeaf := range removed {
+			if renamed[previousLeaf] || !isRenamed(previousLeaf, currentLeaf) {
+				continue
+			}
+
+			renamed[previousLeaf], renamed[currentLeaf] = true, true
+
+			changes = append(changes, &APIChange{
+				Type:         APIChangeRenamed,
+				Path:         currentLeaf.path,
+				PreviousPath: previousLeaf.path,
+				Breaking:     true,
+			})
+
+			break
+		}
+	}
+
+	for _, currentLeaf := range added {
+		if renamed[currentLeaf] {
+			continue
+		}
+
+		change := &APIChange{Type: APIChangeAdditive, Path: currentLeaf.path}
+
+		if currentLeaf.field.Default == "" {
+			change.Breaking = true
+			change.Detail = "new required field"
+		}
+
+		changes = append(changes, change)
+	}
+
+	for _, previousLeaf := range removed {
+		if !renamed[previousLeaf] {
+			changes = append(changes, &APIChange{Type: APIChangeRemoved, Path: previousLeaf.path, Breaking: true})
+		}
+	}
+
+	return changes, nil
+}
+
+// diffAPILeaf returns the changes between the previous and current generation of a field.
+func diffAPILeaf(previous, current *apiLeaf) []*APIChange {
+	changes := []*APIChange{}
+
+	if previous.path != current.path {
+		changes = append(changes, &APIChange{
+			Type:         APIChangeRenamed,
+			Path:         current.path,
+			PreviousPath: previous.path,
+			Breaking:     true,
+		})
+	}
+
+	if previous.field.Type != current.field.Type {
+		return append(changes, &APIChange{
+			Type:     APIChangeNarrowed,
+			Path:     current.path,
+			Breaking: true,
+			Detail: fmt.Sprintf("%s to %s",
+				previous.field.Type.GoTypeName(), current.field.Type.GoTypeName()),
+		})
+	}
+
+	switch {
+	case previous.field.Default == current.field.Default:
+	case previous.field.Default == "":
+		// a required field which now has a default accepts all of the resources it accepted before
+		changes = append(changes, &APIChange{
+			Type:   APIChangeDefaulted,
+			Path:   current.path,
+			Detail: fmt.Sprintf("default %s added", current.field.Default),
+		})
+	case current.field.Default == "":
+		changes = append(changes, &APIChange{
+			Type:     APIChangeDefaulted,
+			Path:     current.path,
+			Breaking: true,
+			Detail:   fmt.Sprintf("default %s removed", previous.field.Default),
+		})
+	default:
+		changes = append(changes, &APIChange{
+			Type:     APIChangeDefaulted,
+			Path:     current.path,
+			Breaking: true,
+			Detail:   fmt.Sprintf("default changed from %s to %s", previous.field.Default, current.field.Default),
+		})
+	}
+
+	return changes
+}
+
+// isRenamed returns whether an added field is likely to be a removed field which was renamed.
+func isRenamed(previous, current *apiLeaf) bool {
+	if previous.field.Type != current.field.Type || previous.field.Default != current.field.Default {
+		return false
+	}
+
+	return previous.field.manifestName == current.field.manifestName ||
+		parentFieldPath(previous.path) == parentFieldPath(current.path)
+}
+
+func parentFieldPath(path string) string {
+	index := strings.LastIndex(path, ".")
+	if index < 0 {
+		return ""
+	}
+
+	return path[:index]
+}
@@ -0,0 +1,145 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package kinds
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nukleros/operator-builder/internal/workload/v1/markers"
+)
+
+// requiredField is a field without a default of the given type.
+type requiredField markers.FieldType
+
+func newTestDiffAPIFields(t *testing.T, fields map[string]interface{}) *APIFields {
+	t.Helper()
+
+	api := &APIFields{}
+
+	for _, path := range []string{"replicas", "image", "web.port", "web.host", "debug"} {
+		sample, found := fields[path]
+		if !found {
+			continue
+		}
+
+		fieldType := markers.FieldString
+
+		switch value := sample.(type) {
+		case requiredField:
+			fieldType, sample = markers.FieldType(value), nil
+		case int:
+			fieldType = markers.FieldInt
+		case bool:
+			fieldType = markers.FieldBool
+		}
+
+		require.NoError(t, api.AddField(path, fieldType, nil, sample, sample != nil))
+	}
+
+	return api
+}
+
+func TestDiffAPIFields(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		previous map[string]interface{}
+		current  map[string]interface{}
+		mappings []*FieldMapping
+		want     []*APIChange
+	}{
+		{
+			name:     "no changes",
+			previous: map[string]interface{}{"replicas": 1, "image": "nginx"},
+			current:  map[string]interface{}{"replicas": 1, "image": "nginx"},
+			want:     []*APIChange{},
+		},
+		{
+			name:     "additive fields",
+			previous: map[string]interface{}{"replicas": 1},
+			current:  map[string]interface{}{"replicas": 1, "debug": false, "web.port": requiredField(markers.FieldInt)},
+			want: []*APIChange{
+				{Type: APIChangeAdditive, Path: "web.port", Breaking: true, Detail: "new required field"},
+				{Type: APIChangeAdditive, Path: "debug"},
+			},
+		},
+		{
+			name:     "defaulted fields",
+			previous: map[string]interface{}{"replicas": requiredField(markers.FieldInt), "image": "nginx", "debug": false},
+			current:  map[string]interface{}{"replicas": 1, "image": "httpd", "debug": requiredField(markers.FieldBool)},
+			want: []*APIChange{
+				{Type: APIChangeDefaulted, Path: "replicas", Detail: "default 1 added"},
+				{Type: APIChangeDefaulted, Path: "image", Breaking: true, Detail: `default changed from "nginx" to "httpd"`},
+				{Type: APIChangeDefaulted, Path: "debug", Breaking: true, Detail: "default false removed"},
+			},
+		},
+		{
+			name:     "narrowed type",
+			previous: map[string]interface{}{"replicas": "1"},
+			current:  map[string]interface{}{"replicas": 1},
+			want: []*APIChange{
+				{Type: APIChangeNarrowed, Path: "replicas", Breaking: true, Detail: "string to int"},
+			},
+		},
+		{
+			name:     "removed field",
+			previous: map[string]interface{}{"replicas": 1, "debug": false},
+			current:  map[string]interface{}{"replicas": 1},
+			want: []*APIChange{
+				{Type: APIChangeRemoved, Path: "debug", Breaking: true},
+			},
+		},
+		{
+			name:     "renamed field",
+			previous: map[string]interface{}{"web.port": 80},
+			current:  map[string]interface{}{"web.host": 80},
+			want: []*APIChange{
+				{Type: APIChangeRenamed, Path: "web.host", PreviousPath: "web.port", Breaking: true},
+			},
+		},
+		{
+			name:     "mapped field",
+			previous: map[string]interface{}{"image": "nginx"},
+			current:  map[string]interface{}{"web.host": "httpd"},
+			mappings: []*FieldMapping{{Hub: "web.host", Spoke: "image"}},
+			want: []*APIChange{
+				{Type: APIChangeRenamed, Path: "web.host", PreviousPath: "image", Breaking: true},
+				{Type: APIChangeDefaulted, Path: "web.host", Breaking: true, Detail: `default changed from "nginx" to "httpd"`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := DiffAPIFields(
+				newTestDiffAPIFields(t, tt.previous),
+				newTestDiffAPIFields(t, tt.current),
+				tt.mappings,
+			)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAPIChange_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "removed debug", (&APIChange{Type: APIChangeRemoved, Path: "debug"}).String())
+	assert.Equal(t,
+		"renamed image -> web.image",
+		(&APIChange{Type: APIChangeRenamed, Path: "web.image", PreviousPath: "image"}).String(),
+	)
+	assert.Equal(t,
+		"narrowed type replicas (string to int)",
+		(&APIChange{Type: APIChangeNarrowed, Path: "replicas", Detail: "string to int"}).String(),
+	)
+}
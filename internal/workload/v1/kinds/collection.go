@@ -20,18 +20,19 @@ var ErrMissingRequiredFields = errors.New("missing required fields")
 
 // WorkloadCollectionSpec defines the attributes for a workload collection.
 type WorkloadCollectionSpec struct {
-	API                 WorkloadAPISpec      `json:"api" yaml:"api"`
-	CompanionCliRootcmd companion.CLI        `json:"companionCliRootcmd,omitempty" yaml:"companionCliRootcmd,omitempty" validate:"omitempty"`
-	CompanionCliSubcmd  companion.CLI        `json:"companionCliSubcmd,omitempty" yaml:"companionCliSubcmd,omitempty" validate:"omitempty"`
-	ComponentFiles      []string             `json:"componentFiles" yaml:"componentFiles"`
-	Components          []*ComponentWorkload `json:",omitempty" yaml:",omitempty" validate:"omitempty"`
+	API                 WorkloadAPISpec       `json:"api" yaml:"api" jsonschema:"required" jsonschema_description:"The API which is generated for the collection."`
+	CompanionCliRootcmd companion.CLI         `json:"companionCliRootcmd,omitempty" yaml:"companionCliRootcmd,omitempty" validate:"omitempty" jsonschema_description:"The root command of the companion CLI."`
+	CompanionCliSubcmd  companion.CLI         `json:"companionCliSubcmd,omitempty" yaml:"companionCliSubcmd,omitempty" validate:"omitempty" jsonschema_description:"The subcommand of the companion CLI for the collection."`
+	ComponentFiles      []string              `json:"componentFiles" yaml:"componentFiles" jsonschema_description:"The component workload config files or glob patterns, relative to the collection config."`
+	Components          []*ComponentWorkload  `json:",omitempty" yaml:",omitempty" validate:"omitempty"`
+	Collections         []*WorkloadCollection `json:",omitempty" yaml:",omitempty" validate:"omitempty"`
 	WorkloadSpec        `yaml:",inline"`
 }
 
 // WorkloadCollection defines a workload collection.
 type WorkloadCollection struct {
 	WorkloadShared `yaml:",inline"`
-	Spec           WorkloadCollectionSpec `json:"spec" yaml:"spec" validate:"required"`
+	Spec           WorkloadCollectionSpec `json:"spec" yaml:"spec" validate:"required" jsonschema:"required"`
 }
 
 // NewWorkloadCollection returns a new workload collection object.
@@ -42,8 +43,9 @@ func NewWorkloadCollection(
 ) *WorkloadCollection {
 	return &WorkloadCollection{
 		WorkloadShared: WorkloadShared{
-			Kind: WorkloadKindCollection,
-			Name: name,
+			APIVersion: APIVersionCurrent,
+			Kind:       WorkloadKindCollection,
+			Name:       name,
 		},
 		Spec: WorkloadCollectionSpec{
 			API:            spec,
@@ -80,7 +82,11 @@ func (c *WorkloadCollection) Validate() error {
 		return fmt.Errorf("%w: %s", ErrMissingRequiredFields, missingFields)
 	}
 
-	return nil
+	if err := validateConversions(&c.Spec.API); err != nil {
+		return err
+	}
+
+	return c.Spec.validate(c.Spec.API.ClusterScoped)
 }
 
 func (c *WorkloadCollection) GetWorkloadKind() WorkloadKind {
@@ -93,11 +99,11 @@ func (c *WorkloadCollection) GetDomain() string {
 }
 
 func (c *WorkloadCollection) HasRootCmdName() bool {
-	return c.Spec.CompanionCliRootcmd.HasName()
+	return c.GetRootCommand().HasName()
 }
 
 func (c *WorkloadCollection) HasRootCmdDescription() bool {
-	return c.Spec.CompanionCliRootcmd.HasDescription()
+	return c.GetRootCommand().HasDescription()
 }
 
 func (c *WorkloadCollection) HasSubCmdName() bool {
@@ -133,6 +139,14 @@ func (c *WorkloadCollection) GetAPIKind() string {
 	return c.Spec.API.Kind
 }
 
+func (c *WorkloadCollection) GetAPIConversions() []*APIConversion {
+	return c.Spec.API.Conversion
+}
+
+func (c *WorkloadCollection) GetWebhook() *Webhook {
+	return c.Spec.Webhook
+}
+
 func (c *WorkloadCollection) IsClusterScoped() bool {
 	return c.Spec.API.ClusterScoped
 }
@@ -141,8 +155,10 @@ func (c *WorkloadCollection) IsStandalone() bool {
 	return false
 }
 
+// IsComponent returns whether the collection is nested within a parent collection, in
+// which case it is managed as a component of its parent collection.
 func (c *WorkloadCollection) IsComponent() bool {
-	return false
+	return c.Spec.ParentCollection != nil
 }
 
 func (c *WorkloadCollection) IsCollection() bool {
@@ -150,17 +166,40 @@ func (c *WorkloadCollection) IsCollection() bool {
 }
 
 func (c *WorkloadCollection) SetRBAC() {
-	c.Spec.RBACRules.Add(rbac.ForWorkloads(c))
+	if c.IsComponent() {
+		c.Spec.addRBACRules("api", rbac.ForWorkloads(c, c.Spec.ParentCollection))
+	} else {
+		c.Spec.addRBACRules("api", rbac.ForWorkloads(c))
+	}
+
+	c.Spec.setRBACOptions()
 }
 
 func (c *WorkloadCollection) SetResources(workloadPath string) error {
-	err := c.Spec.processManifests(markers.FieldMarkerType, markers.CollectionMarkerType)
+	// the collection markers of a nested collection are resolved against its parent
+	// collection, which processes them when setting its own resources
+	markerTypes := []markers.MarkerType{markers.FieldMarkerType, markers.CollectionMarkerType, markers.WebhookMarkerType}
+	if c.IsComponent() {
+		markerTypes = []markers.MarkerType{markers.FieldMarkerType, markers.WebhookMarkerType}
+	}
+
+	err := c.Spec.processManifests(markerTypes...)
 	if err != nil {
 		return err
 	}
 
+	children := []*WorkloadSpec{}
+
 	for _, cpt := range c.Spec.Components {
-		for _, csr := range *cpt.Spec.Manifests {
+		children = append(children, &cpt.Spec.WorkloadSpec)
+	}
+
+	for _, nested := range c.Spec.Collections {
+		children = append(children, &nested.Spec.WorkloadSpec)
+	}
+
+	for _, child := range children {
+		for _, csr := range *child.Manifests {
 			// add to spec fields if not present
 			err := c.Spec.processMarkers(csr, markers.CollectionMarkerType)
 			if err != nil {
@@ -169,13 +208,33 @@ func (c *WorkloadCollection) SetResources(workloadPath string) error {
 		}
 	}
 
-	return nil
+	return c.Spec.processWebhookMarkers(c.Spec.API.ClusterScoped)
 }
 
 func (c *WorkloadCollection) GetDependencies() []*ComponentWorkload {
 	return []*ComponentWorkload{}
 }
 
+func (c *WorkloadCollection) GetExternalDependencies() []*ExternalDependency {
+	return []*ExternalDependency{}
+}
+
+func (c *WorkloadCollection) GetControllerOptions() *ControllerOptions {
+	return c.Spec.getControllerOptions()
+}
+
+func (c *WorkloadCollection) GetPhases() []*Phase {
+	return c.Spec.Phases
+}
+
+func (c *WorkloadCollection) GetCommonMetadata() *CommonMetadata {
+	return c.Spec.getCommonMetadata()
+}
+
+func (c *WorkloadCollection) GetRBACOptions() *RBACOptions {
+	return c.Spec.getRBACOptions()
+}
+
 func (c *WorkloadCollection) SetComponents(components []*ComponentWorkload) error {
 	c.Spec.Components = components
 
@@ -186,7 +245,14 @@ func (c *WorkloadCollection) HasChildResources() bool {
 	return len(*c.Spec.Manifests) > 0
 }
 
+// GetCollection returns the collection which the collection belongs to.  A nested
+// collection belongs to its parent collection while a top-level collection is
+// still a collection to itself.
 func (c *WorkloadCollection) GetCollection() *WorkloadCollection {
+	if c.IsComponent() {
+		return c.Spec.ParentCollection
+	}
+
 	return c.Spec.Collection
 }
 
@@ -236,6 +302,14 @@ func (c *WorkloadCollection) GetComponentResource(domain, repo string, clusterSc
 func (c *WorkloadCollection) SetNames() {
 	c.PackageName = utils.ToPackageName(c.Name)
 
+	// a nested collection is managed from a subcommand of the companion CLI of
+	// its parent collection
+	if c.IsComponent() {
+		c.Spec.CompanionCliSubcmd.SetCommonValues(c, true)
+
+		return
+	}
+
 	// only set the names if we have specified the root command name else none
 	// of the following values will matter as the code for the cli will not be
 	// generated
@@ -249,6 +323,10 @@ func (c *WorkloadCollection) SetNames() {
 }
 
 func (c *WorkloadCollection) GetRootCommand() *companion.CLI {
+	if c.IsComponent() {
+		return c.Spec.ParentCollection.GetRootCommand()
+	}
+
 	return &c.Spec.CompanionCliRootcmd
 }
 
@@ -264,7 +342,9 @@ func (c *WorkloadCollection) LoadManifests(workloadPath string) error {
 
 	c.Spec.Manifests = expanded
 	for _, manifest := range *c.Spec.Manifests {
-		if err := manifest.LoadContent(c.IsCollection()); err != nil {
+		// the collection markers of a nested collection refer to its parent collection rather
+		// than to itself, so they are kept as collection markers
+		if err := manifest.LoadContent(!c.IsComponent()); err != nil {
 			return fmt.Errorf("%w; %s for collection %s", err, ErrLoadManifests.Error(), c.Name)
 		}
 	}
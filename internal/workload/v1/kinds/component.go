@@ -21,19 +21,56 @@ var ErrNoComponentsOnComponent = errors.New("cannot set component workloads on a
 // ComponentWorkloadSpec defines the attributes for a workload that is a
 // component of a collection.
 type ComponentWorkloadSpec struct {
-	API                   WorkloadAPISpec      `json:"api" yaml:"api"`
-	CompanionCliSubcmd    companion.CLI        `json:"companionCliSubcmd" yaml:"companionCliSubcmd" validate:"omitempty"`
-	CompanionCliRootcmd   companion.CLI        `json:"-" yaml:"-" validate:"omitempty"`
-	Dependencies          []string             `json:"dependencies" yaml:"dependencies"`
-	ConfigPath            string               `json:"-" yaml:"-" validate:"omitempty"`
-	ComponentDependencies []*ComponentWorkload `json:"-" yaml:"-" validate:"omitempty"`
+	API                   WorkloadAPISpec       `json:"api" yaml:"api" jsonschema:"required" jsonschema_description:"The API which is generated for the component."`
+	CompanionCliSubcmd    companion.CLI         `json:"companionCliSubcmd" yaml:"companionCliSubcmd" validate:"omitempty" jsonschema_description:"The subcommand of the companion CLI for the component."`
+	CompanionCliRootcmd   companion.CLI         `json:"-" yaml:"-" validate:"omitempty"`
+	Dependencies          []string              `json:"dependencies" yaml:"dependencies" jsonschema_description:"The names of the components which must be ready before the component is deployed."`
+	ExternalDependencies  []*ExternalDependency `json:"externalDependencies,omitempty" yaml:"externalDependencies,omitempty" validate:"omitempty" jsonschema_description:"The resources, which are not managed by the project, that must exist before the component is deployed."`
+	ConfigPath            string                `json:"-" yaml:"-" validate:"omitempty"`
+	ComponentDependencies []*ComponentWorkload  `json:"-" yaml:"-" validate:"omitempty"`
 	WorkloadSpec          `yaml:",inline"`
 }
 
+// ExternalDependency defines a dependency upon a resource which is not managed by the project, such
+// as a resource of an API which is installed by another operator.
+type ExternalDependency struct {
+	Group     string `json:"group,omitempty" yaml:"group,omitempty" jsonschema_description:"The API group of the resource, which is empty for the core group."`
+	Version   string `json:"version" yaml:"version" jsonschema:"required" jsonschema_description:"The API version of the resource."`
+	Kind      string `json:"kind" yaml:"kind" jsonschema:"required" jsonschema_description:"The kind of the resource."`
+	Name      string `json:"name,omitempty" yaml:"name,omitempty" jsonschema_description:"The name of the resource.  If omitted, the dependency is satisfied once the API for the kind exists."`
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty" jsonschema_description:"The namespace of the resource, which is omitted for cluster scoped resources."`
+	Condition string `json:"condition,omitempty" yaml:"condition,omitempty" jsonschema_description:"The type of a status condition, such as Ready, which must be True for the resource.  Requires a name."`
+}
+
+// isClusterScoped returns whether the external dependency is a named resource without a namespace,
+// which is a cluster scoped resource.
+func (dependency *ExternalDependency) isClusterScoped() bool {
+	return dependency.Name != "" && dependency.Namespace == ""
+}
+
+// missingFields returns the required fields which are missing from the external dependency.
+func (dependency *ExternalDependency) missingFields(path string) []string {
+	missingFields := []string{}
+
+	if dependency.Version == "" {
+		missingFields = append(missingFields, path+".version")
+	}
+
+	if dependency.Kind == "" {
+		missingFields = append(missingFields, path+".kind")
+	}
+
+	if dependency.Condition != "" && dependency.Name == "" {
+		missingFields = append(missingFields, path+".name")
+	}
+
+	return missingFields
+}
+
 // ComponentWorkload defines a workload that is a component of a collection.
 type ComponentWorkload struct {
 	WorkloadShared `yaml:",inline"`
-	Spec           ComponentWorkloadSpec `json:"spec" yaml:"spec" validate:"required"`
+	Spec           ComponentWorkloadSpec `json:"spec" yaml:"spec" validate:"required" jsonschema:"required"`
 }
 
 // NewComponentWorkload returns a new component workload object.
@@ -44,11 +81,12 @@ func NewComponentWorkload(
 ) *ComponentWorkload {
 	return &ComponentWorkload{
 		WorkloadShared: WorkloadShared{
-			Kind: WorkloadKindComponent,
-			Name: name,
+			APIVersion: APIVersionCurrent,
+			Kind:       WorkloadKindComponent,
+			Name:       name,
 		},
 		Spec: ComponentWorkloadSpec{
-			API: *NewSampleAPISpec(),
+			API: spec,
 			WorkloadSpec: WorkloadSpec{
 				Manifests: manifests.FromFiles(manifestFiles),
 			},
@@ -77,11 +115,19 @@ func (c *ComponentWorkload) Validate() error {
 		missingFields = append(missingFields, "spec.api.kind")
 	}
 
+	for i, dependency := range c.Spec.ExternalDependencies {
+		missingFields = append(missingFields, dependency.missingFields(fmt.Sprintf("spec.externalDependencies[%d]", i))...)
+	}
+
 	if len(missingFields) > 0 {
 		return fmt.Errorf("%w: %s", ErrMissingRequiredFields, missingFields)
 	}
 
-	return nil
+	if err := validateConversions(&c.Spec.API); err != nil {
+		return err
+	}
+
+	return c.Spec.validate(c.Spec.API.ClusterScoped)
 }
 
 func (c *ComponentWorkload) GetWorkloadKind() WorkloadKind {
@@ -121,6 +167,14 @@ func (c *ComponentWorkload) GetAPIKind() string {
 	return c.Spec.API.Kind
 }
 
+func (c *ComponentWorkload) GetAPIConversions() []*APIConversion {
+	return c.Spec.API.Conversion
+}
+
+func (c *ComponentWorkload) GetWebhook() *Webhook {
+	return c.Spec.Webhook
+}
+
 func (c *ComponentWorkload) IsClusterScoped() bool {
 	return c.Spec.API.ClusterScoped
 }
@@ -138,22 +192,57 @@ func (*ComponentWorkload) IsCollection() bool {
 }
 
 func (c *ComponentWorkload) SetRBAC() {
-	c.Spec.RBACRules.Add(rbac.ForWorkloads(c, c.Spec.Collection))
+	c.Spec.addRBACRules("api", rbac.ForWorkloads(c, c.Spec.Collection))
+
+	for i, dependency := range c.Spec.ExternalDependencies {
+		source := fmt.Sprintf("spec.externalDependencies[%d]", i)
+		rules := rbac.ForDependency(dependency.Group, dependency.Kind)
+
+		if dependency.isClusterScoped() {
+			c.Spec.addClusterRBACRules(source, rules)
+
+			continue
+		}
+
+		c.Spec.addRBACRules(source, rules)
+	}
+
+	c.Spec.setRBACOptions()
 }
 
 func (c *ComponentWorkload) SetResources(workloadPath string) error {
-	err := c.Spec.processManifests(markers.FieldMarkerType)
+	err := c.Spec.processManifests(markers.FieldMarkerType, markers.WebhookMarkerType)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return c.Spec.processWebhookMarkers(c.Spec.API.ClusterScoped)
 }
 
 func (c *ComponentWorkload) GetDependencies() []*ComponentWorkload {
 	return c.Spec.ComponentDependencies
 }
 
+func (c *ComponentWorkload) GetExternalDependencies() []*ExternalDependency {
+	return c.Spec.ExternalDependencies
+}
+
+func (c *ComponentWorkload) GetControllerOptions() *ControllerOptions {
+	return c.Spec.getControllerOptions()
+}
+
+func (c *ComponentWorkload) GetPhases() []*Phase {
+	return c.Spec.Phases
+}
+
+func (c *ComponentWorkload) GetCommonMetadata() *CommonMetadata {
+	return c.Spec.getCommonMetadata()
+}
+
+func (c *ComponentWorkload) GetRBACOptions() *RBACOptions {
+	return c.Spec.getRBACOptions()
+}
+
 func (*ComponentWorkload) SetComponents(components []*ComponentWorkload) error {
 	return ErrNoComponentsOnComponent
 }
@@ -224,7 +313,7 @@ func (c *ComponentWorkload) SetNames() {
 }
 
 func (c *ComponentWorkload) GetRootCommand() *companion.CLI {
-	return &c.Spec.Collection.Spec.CompanionCliRootcmd
+	return c.Spec.Collection.GetRootCommand()
 }
 
 func (c *ComponentWorkload) GetSubCommand() *companion.CLI {
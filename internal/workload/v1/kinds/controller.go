@@ -0,0 +1,207 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package kinds
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrInvalidControllerOptions = errors.New("invalid controller options")
+
+const (
+	defaultRequeueDelay = 5 * time.Second
+
+	// the default delays match the exponential failure rate limiter of controller-runtime.
+	defaultRateLimiterBaseDelay = 5 * time.Millisecond
+	defaultRateLimiterMaxDelay  = 1000 * time.Second
+)
+
+// ControllerOptions defines the options which tune the controller of a workload.  Durations are
+// expressed as Go duration strings, such as 30s or 5m.
+type ControllerOptions struct {
+	MaxConcurrentReconciles int                 `json:"maxConcurrentReconciles,omitempty" yaml:"maxConcurrentReconciles,omitempty" jsonschema_description:"The maximum number of concurrent reconciles for the controller, which defaults to 1."`
+	RateLimiter             *RateLimiterOptions `json:"rateLimiter,omitempty" yaml:"rateLimiter,omitempty" validate:"omitempty" jsonschema_description:"The exponential backoff of the controller when a reconcile fails."`
+	ResyncPeriod            string              `json:"resyncPeriod,omitempty" yaml:"resyncPeriod,omitempty" jsonschema_description:"The period after which a workload is reconciled again once it has been reconciled successfully.  If omitted, workloads are only reconciled upon changes."`
+	DependencyRequeueDelay  string              `json:"dependencyRequeueDelay,omitempty" yaml:"dependencyRequeueDelay,omitempty" jsonschema_description:"The delay before the dependencies of a workload are checked again when they are not satisfied, which defaults to 5s."`
+	CheckReadyRequeueDelay  string              `json:"checkReadyRequeueDelay,omitempty" yaml:"checkReadyRequeueDelay,omitempty" jsonschema_description:"The delay before the child resources of a workload are checked again when they are not ready, which defaults to 5s."`
+}
+
+// RateLimiterOptions defines the exponential backoff of a controller when a reconcile fails.
+type RateLimiterOptions struct {
+	BaseDelay string `json:"baseDelay,omitempty" yaml:"baseDelay,omitempty" jsonschema_description:"The delay after the first failure, which is doubled upon each consecutive failure, which defaults to 5ms."`
+	MaxDelay  string `json:"maxDelay,omitempty" yaml:"maxDelay,omitempty" jsonschema_description:"The maximum delay after consecutive failures, which defaults to 1000s."`
+}
+
+// validate returns an error if any of the controller options are invalid.
+func (options *ControllerOptions) validate() error {
+	if options == nil {
+		return nil
+	}
+
+	invalidFields := []string{}
+
+	if options.MaxConcurrentReconciles < 0 {
+		invalidFields = append(invalidFields, "spec.controller.maxConcurrentReconciles")
+	}
+
+	durations := [][2]string{
+		{"spec.controller.resyncPeriod", options.ResyncPeriod},
+		{"spec.controller.dependencyRequeueDelay", options.DependencyRequeueDelay},
+		{"spec.controller.checkReadyRequeueDelay", options.CheckReadyRequeueDelay},
+	}
+
+	if options.RateLimiter != nil {
+		durations = append(durations,
+			[2]string{"spec.controller.rateLimiter.baseDelay", options.RateLimiter.BaseDelay},
+			[2]string{"spec.controller.rateLimiter.maxDelay", options.RateLimiter.MaxDelay},
+		)
+	}
+
+	for _, duration := range durations {
+		if _, err := parseDuration(duration[1], 0); err != nil {
+			invalidFields = append(invalidFields, duration[0])
+		}
+	}
+
+	// a requeue delay of zero would result in the workload never being requeued
+	if options.DependencyRequeueDelay != "" && options.dependencyRequeueDelay() == 0 {
+		invalidFields = append(invalidFields, "spec.controller.dependencyRequeueDelay")
+	}
+
+	if options.CheckReadyRequeueDelay != "" && options.checkReadyRequeueDelay() == 0 {
+		invalidFields = append(invalidFields, "spec.controller.checkReadyRequeueDelay")
+	}
+
+	if len(invalidFields) == 0 && options.RateLimiter != nil &&
+		options.RateLimiter.baseDelay() > options.RateLimiter.maxDelay() {
+		invalidFields = append(invalidFields, "spec.controller.rateLimiter.baseDelay")
+	}
+
+	if len(invalidFields) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidControllerOptions, invalidFields)
+	}
+
+	return nil
+}
+
+// IsSet returns whether any of the controller options are set.
+func (options *ControllerOptions) IsSet() bool {
+	return *options != ControllerOptions{}
+}
+
+// HasControllerOptions returns whether the controller is built with options other than the
+// defaults of controller-runtime.
+func (options *ControllerOptions) HasControllerOptions() bool {
+	return options.MaxConcurrentReconciles > 0 || options.RateLimiter != nil
+}
+
+// HasResyncPeriod returns whether a workload is periodically reconciled.
+func (options *ControllerOptions) HasResyncPeriod() bool {
+	return options.resyncPeriod() > 0
+}
+
+// ResyncPeriodCode returns the resync period as Go source code.
+func (options *ControllerOptions) ResyncPeriodCode() string {
+	return durationCode(options.resyncPeriod())
+}
+
+// DependencyRequeueDelayCode returns the delay before the dependencies of a workload are checked
+// again as Go source code.
+func (options *ControllerOptions) DependencyRequeueDelayCode() string {
+	return durationCode(options.dependencyRequeueDelay())
+}
+
+// CheckReadyRequeueDelayCode returns the delay before the child resources of a workload are
+// checked again as Go source code.
+func (options *ControllerOptions) CheckReadyRequeueDelayCode() string {
+	return durationCode(options.checkReadyRequeueDelay())
+}
+
+// BaseDelayCode returns the base delay of the rate limiter as Go source code.
+func (options *RateLimiterOptions) BaseDelayCode() string {
+	return durationCode(options.baseDelay())
+}
+
+// MaxDelayCode returns the maximum delay of the rate limiter as Go source code.
+func (options *RateLimiterOptions) MaxDelayCode() string {
+	return durationCode(options.maxDelay())
+}
+
+func (options *ControllerOptions) resyncPeriod() time.Duration {
+	period, _ := parseDuration(options.ResyncPeriod, 0)
+
+	return period
+}
+
+func (options *ControllerOptions) dependencyRequeueDelay() time.Duration {
+	delay, _ := parseDuration(options.DependencyRequeueDelay, defaultRequeueDelay)
+
+	return delay
+}
+
+func (options *ControllerOptions) checkReadyRequeueDelay() time.Duration {
+	delay, _ := parseDuration(options.CheckReadyRequeueDelay, defaultRequeueDelay)
+
+	return delay
+}
+
+func (options *RateLimiterOptions) baseDelay() time.Duration {
+	delay, _ := parseDuration(options.BaseDelay, defaultRateLimiterBaseDelay)
+
+	return delay
+}
+
+func (options *RateLimiterOptions) maxDelay() time.Duration {
+	delay, _ := parseDuration(options.MaxDelay, defaultRateLimiterMaxDelay)
+
+	return delay
+}
+
+// parseDuration parses a duration string, returning the default value if the string is empty.
+// Negative durations are invalid.
+func parseDuration(value string, defaultValue time.Duration) (time.Duration, error) {
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return defaultValue, fmt.Errorf("%w", err)
+	}
+
+	if duration < 0 {
+		return defaultValue, fmt.Errorf("%w; negative duration %s", ErrInvalidControllerOptions, value)
+	}
+
+	return duration, nil
+}
+
+// durationCode returns a duration as Go source code, using the largest unit which represents
+// the duration exactly.
+func durationCode(duration time.Duration) string {
+	units := []struct {
+		unit time.Duration
+		name string
+	}{
+		{unit: time.Hour, name: "time.Hour"},
+		{unit: time.Minute, name: "time.Minute"},
+		{unit: time.Second, name: "time.Second"},
+		{unit: time.Millisecond, name: "time.Millisecond"},
+		{unit: time.Microsecond, name: "time.Microsecond"},
+	}
+
+	if duration == 0 {
+		return "0"
+	}
+
+	for _, u := range units {
+		if duration%u.unit == 0 {
+			return fmt.Sprintf("%d * %s", duration/u.unit, u.name)
+		}
+	}
+
+	return fmt.Sprintf("%d * time.Nanosecond", duration)
+}
@@ -0,0 +1,134 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package kinds
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestControllerOptions_validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		options *ControllerOptions
+		wantErr bool
+	}{
+		{
+			name:    "nil options",
+			options: nil,
+			wantErr: false,
+		},
+		{
+			name: "valid options",
+			options: &ControllerOptions{
+				MaxConcurrentReconciles: 3,
+				RateLimiter:             &RateLimiterOptions{BaseDelay: "1s", MaxDelay: "5m"},
+				ResyncPeriod:            "10m",
+				DependencyRequeueDelay:  "30s",
+				CheckReadyRequeueDelay:  "10s",
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid rate limiter with default max delay",
+			options: &ControllerOptions{
+				RateLimiter: &RateLimiterOptions{BaseDelay: "1s"},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "negative max concurrent reconciles",
+			options: &ControllerOptions{MaxConcurrentReconciles: -1},
+			wantErr: true,
+		},
+		{
+			name:    "invalid duration",
+			options: &ControllerOptions{ResyncPeriod: "ten minutes"},
+			wantErr: true,
+		},
+		{
+			name:    "negative duration",
+			options: &ControllerOptions{DependencyRequeueDelay: "-5s"},
+			wantErr: true,
+		},
+		{
+			name:    "zero requeue delay",
+			options: &ControllerOptions{CheckReadyRequeueDelay: "0s"},
+			wantErr: true,
+		},
+		{
+			name: "base delay greater than max delay",
+			options: &ControllerOptions{
+				RateLimiter: &RateLimiterOptions{BaseDelay: "10m", MaxDelay: "1m"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.options.validate()
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidControllerOptions)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestControllerOptions_Code(t *testing.T) {
+	t.Parallel()
+
+	defaults := &ControllerOptions{}
+	assert.False(t, defaults.IsSet())
+	assert.False(t, defaults.HasControllerOptions())
+	assert.False(t, defaults.HasResyncPeriod())
+	assert.Equal(t, "5 * time.Second", defaults.DependencyRequeueDelayCode())
+	assert.Equal(t, "5 * time.Second", defaults.CheckReadyRequeueDelayCode())
+
+	options := &ControllerOptions{
+		RateLimiter:            &RateLimiterOptions{},
+		ResyncPeriod:           "1h30m",
+		DependencyRequeueDelay: "1m",
+	}
+	assert.True(t, options.IsSet())
+	assert.True(t, options.HasControllerOptions())
+	assert.True(t, options.HasResyncPeriod())
+	assert.Equal(t, "90 * time.Minute", options.ResyncPeriodCode())
+	assert.Equal(t, "1 * time.Minute", options.DependencyRequeueDelayCode())
+	assert.Equal(t, "5 * time.Millisecond", options.RateLimiter.BaseDelayCode())
+	assert.Equal(t, "1000 * time.Second", options.RateLimiter.MaxDelayCode())
+}
+
+func Test_durationCode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		duration time.Duration
+		expected string
+	}{
+		{duration: 0, expected: "0"},
+		{duration: 2 * time.Hour, expected: "2 * time.Hour"},
+		{duration: 1500 * time.Millisecond, expected: "1500 * time.Millisecond"},
+		{duration: 250 * time.Microsecond, expected: "250 * time.Microsecond"},
+		{duration: 7, expected: "7 * time.Nanosecond"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.expected, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, durationCode(tt.duration))
+		})
+	}
+}
@@ -0,0 +1,240 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package kinds
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nukleros/operator-builder/internal/workload/v1/markers"
+)
+
+var (
+	ErrInvalidConversion   = errors.New("invalid api conversion")
+	ErrInvalidFieldMapping = errors.New("invalid api conversion field mapping")
+)
+
+// APIConversion defines the conversion between the version of an API which is generated for a
+// workload, which is the hub version, and a previous version of the API, which is a spoke version.
+type APIConversion struct {
+	Version        string          `json:"version" yaml:"version" jsonschema:"required" jsonschema_description:"The spoke version of the API which is converted to and from the version of the API in this workload config."`
+	WorkloadConfig string          `json:"workloadConfig" yaml:"workloadConfig" jsonschema:"required" jsonschema_description:"The workload config from which the spoke version of the API was generated, relative to this workload config."`
+	Fields         []*FieldMapping `json:"fields,omitempty" yaml:"fields,omitempty" validate:"omitempty" jsonschema_description:"The API spec fields which were renamed between the spoke version and this version of the API."`
+}
+
+// FieldMapping maps an API spec field of the hub version of an API to the field of a spoke version
+// which it was renamed from.  Fields are named by the path from the field markers, such as
+// web.replicas, and mapping a parent field maps each of the fields which are nested within it.
+type FieldMapping struct {
+	Hub   string `json:"hub" yaml:"hub" jsonschema:"required" jsonschema_description:"The path of the field in this version of the API."`
+	Spoke string `json:"spoke" yaml:"spoke" jsonschema:"required" jsonschema_description:"The path of the field in the spoke version of the API."`
+}
+
+// Conversion is the conversion between the API spec fields of the hub version of an API and a
+// spoke version of the API.
+type Conversion struct {
+	// Fields are the fields which are copied between the versions.
+	Fields []*ConvertedField
+
+	// HubOnly and SpokeOnly are the fields which cannot be copied from the other version and
+	// must be converted by the user.
+	HubOnly   []*UnconvertedField
+	SpokeOnly []*UnconvertedField
+}
+
+// ConvertedField is a field which is copied between versions, named by its selector from the root
+// of each version of the API, such as Spec.Web.Replicas.
+type ConvertedField struct {
+	Hub   string
+	Spoke string
+}
+
+// UnconvertedField is a field which must be converted by the user.
+type UnconvertedField struct {
+	Path     string
+	Selector string
+	Reason   string
+}
+
+// validateConversions returns an error if any of the conversions of an API are invalid.
+func validateConversions(api *WorkloadAPISpec) error {
+	versions := map[string]bool{}
+
+	for i, conversion := range api.Conversion {
+		field := fmt.Sprintf("spec.api.conversion[%d]", i)
+
+		switch {
+		case conversion.Version == "":
+			return fmt.Errorf("%w: %s.version is required", ErrInvalidConversion, field)
+		case conversion.Version == api.Version:
+			return fmt.Errorf("%w: %s.version must differ from spec.api.version %s", ErrInvalidConversion, field, api.Version)
+		case versions[conversion.Version]:
+			return fmt.Errorf("%w: %s.version %s is duplicated", ErrInvalidConversion, field, conversion.Version)
+		case conversion.WorkloadConfig == "":
+			return fmt.Errorf("%w: %s.workloadConfig is required", ErrInvalidConversion, field)
+		}
+
+		versions[conversion.Version] = true
+
+		for j, mapping := range conversion.Fields {
+			if mapping.Hub == "" || mapping.Spoke == "" {
+				return fmt.Errorf("%w: %s.fields[%d] requires both hub and spoke", ErrInvalidConversion, field, j)
+			}
+		}
+	}
+
+	return nil
+}
+
+// apiLeaf is an API spec field which has no nested fields, along with its path and selector.
+type apiLeaf struct {
+	path     string
+	selector string
+	field    *APIFields
+}
+
+// NewConversion compares the API spec fields of the hub and spoke versions of an API.  Fields
+// which have the same path and type in both versions, or which are mapped to one another, are
+// copied.  The remaining fields of each version are returned so that they may be converted by
+// the user.
+func NewConversion(hub, spoke *APIFields, mappings []*FieldMapping) (*Conversion, error) {
+	hubLeaves, spokeLeaves := hub.leaves(), spoke.leaves()
+
+	spokeByPath := map[string]*apiLeaf{}
+	for _, leaf := range spokeLeaves {
+		spokeByPath[leaf.path] = leaf
+	}
+
+	if err := validateFieldMappings(mappings, hubLeaves, spokeLeaves); err != nil {
+		return nil, err
+	}
+
+	conversion := &Conversion{}
+	converted := map[string]bool{}
+
+	for _, hubLeaf := range hubLeaves {
+		spokePath := mapFieldPath(hubLeaf.path, mappings)
+
+		spokeLeaf, found := spokeByPath[spokePath]
+		if !found {
+			conversion.HubOnly = append(conversion.HubOnly, hubLeaf.unconverted("no matching field in the spoke version"))
+
+			continue
+		}
+
+		if spokeLeaf.field.Type != hubLeaf.field.Type {
+			conversion.HubOnly = append(conversion.HubOnly, hubLeaf.unconverted(fmt.Sprintf(
+				"type %s differs from spoke field %s of type %s",
+				hubLeaf.field.Type.GoTypeName(), spokeLeaf.path, spokeLeaf.field.Type.GoTypeName(),
+			)))
+
+			continue
+		}
+
+		converted[spokeLeaf.path] = true
+
+		conversion.Fields = append(conversion.Fields, &ConvertedField{
+			Hub:   hubLeaf.selector,
+			Spoke: spokeLeaf.selector,
+		})
+	}
+
+	for _, spokeLeaf := range spokeLeaves {
+		if !converted[spokeLeaf.path] {
+			conversion.SpokeOnly = append(conversion.SpokeOnly, spokeLeaf.unconverted("no matching field in the hub version"))
+		}
+	}
+
+	return conversion, nil
+}
+
+// validateFieldMappings returns an error if a field mapping does not refer to a field in each
+// version of an API.
+func validateFieldMappings(mappings []*FieldMapping, hubLeaves, spokeLeaves []*apiLeaf) error {
+	for _, mapping := range mappings {
+		if !hasFieldPath(hubLeaves, mapping.Hub) {
+			return fmt.Errorf("%w: no hub field found at %s", ErrInvalidFieldMapping, mapping.Hub)
+		}
+
+		if !hasFieldPath(spokeLeaves, mapping.Spoke) {
+			return fmt.Errorf("%w: no spoke field found at %s", ErrInvalidFieldMapping, mapping.Spoke)
+		}
+	}
+
+	return nil
+}
+
+// mapFieldPath returns the path of the spoke field which a hub field is mapped to.  The most
+// specific mapping is used, so that a field nested within a mapped parent may be mapped again.
+func mapFieldPath(path string, mappings []*FieldMapping) string {
+	var match *FieldMapping
+
+	for _, mapping := range mappings {
+		if isFieldPathWithin(path, mapping.Hub) && (match == nil || len(mapping.Hub) > len(match.Hub)) {
+			match = mapping
+		}
+	}
+
+	if match == nil {
+		return path
+	}
+
+	return match.Spoke + strings.TrimPrefix(path, match.Hub)
+}
+
+func hasFieldPath(leaves []*apiLeaf, path string) bool {
+	for _, leaf := range leaves {
+		if isFieldPathWithin(leaf.path, path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isFieldPathWithin returns whether a field path is the same as, or is nested within, a parent
+// field path.
+func isFieldPathWithin(path, parent string) bool {
+	return path == parent || strings.HasPrefix(path, parent+".")
+}
+
+// leaves returns each of the API spec fields which have no nested fields, in the order in which
+// they are generated.
+func (api *APIFields) leaves() []*apiLeaf {
+	leaves := []*apiLeaf{}
+
+	if api == nil {
+		return leaves
+	}
+
+	for _, child := range api.Children {
+		child.appendLeaves(&leaves, "", "Spec")
+	}
+
+	return leaves
+}
+
+func (api *APIFields) appendLeaves(leaves *[]*apiLeaf, parentPath, parentSelector string) {
+	path := api.manifestName
+	if parentPath != "" {
+		path = parentPath + "." + api.manifestName
+	}
+
+	selector := parentSelector + "." + api.Name
+
+	if api.Type != markers.FieldStruct {
+		*leaves = append(*leaves, &apiLeaf{path: path, selector: selector, field: api})
+
+		return
+	}
+
+	for _, child := range api.Children {
+		child.appendLeaves(leaves, path, selector)
+	}
+}
+
+func (leaf *apiLeaf) unconverted(reason string) *UnconvertedField {
+	return &UnconvertedField{Path: leaf.path, Selector: leaf.selector, Reason: reason}
+}
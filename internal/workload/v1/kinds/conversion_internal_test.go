@@ -0,0 +1,207 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package kinds
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nukleros/operator-builder/internal/workload/v1/markers"
+)
+
+type testAPIField struct {
+	path      string
+	fieldType markers.FieldType
+}
+
+func newTestAPIFields(t *testing.T, fields ...testAPIField) *APIFields {
+	t.Helper()
+
+	api := &APIFields{}
+
+	for _, field := range fields {
+		require.NoError(t, api.AddField(field.path, field.fieldType, nil, nil, false))
+	}
+
+	return api
+}
+
+func TestNewConversion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		hub       []testAPIField
+		spoke     []testAPIField
+		mappings  []*FieldMapping
+		want      *Conversion
+		wantError error
+	}{
+		{
+			name: "matching fields",
+			hub: []testAPIField{
+				{path: "replicas", fieldType: markers.FieldInt},
+				{path: "web.image", fieldType: markers.FieldString},
+			},
+			spoke: []testAPIField{
+				{path: "replicas", fieldType: markers.FieldInt},
+				{path: "web.image", fieldType: markers.FieldString},
+			},
+			want: &Conversion{
+				Fields: []*ConvertedField{
+					{Hub: "Spec.Replicas", Spoke: "Spec.Replicas"},
+					{Hub: "Spec.Web.Image", Spoke: "Spec.Web.Image"},
+				},
+			},
+		},
+		{
+			name: "unmatched fields",
+			hub: []testAPIField{
+				{path: "replicas", fieldType: markers.FieldInt},
+				{path: "debug", fieldType: markers.FieldBool},
+			},
+			spoke: []testAPIField{
+				{path: "replicas", fieldType: markers.FieldString},
+				{path: "logLevel", fieldType: markers.FieldString},
+			},
+			want: &Conversion{
+				HubOnly: []*UnconvertedField{
+					{Path: "replicas", Selector: "Spec.Replicas", Reason: "type int differs from spoke field replicas of type string"},
+					{Path: "debug", Selector: "Spec.Debug", Reason: "no matching field in the spoke version"},
+				},
+				SpokeOnly: []*UnconvertedField{
+					{Path: "replicas", Selector: "Spec.Replicas", Reason: "no matching field in the hub version"},
+					{Path: "logLevel", Selector: "Spec.LogLevel", Reason: "no matching field in the hub version"},
+				},
+			},
+		},
+		{
+			name: "renamed fields",
+			hub: []testAPIField{
+				{path: "frontend.image", fieldType: markers.FieldString},
+				{path: "frontend.tag", fieldType: markers.FieldString},
+			},
+			spoke: []testAPIField{
+				{path: "web.image", fieldType: markers.FieldString},
+				{path: "web.version", fieldType: markers.FieldString},
+			},
+			mappings: []*FieldMapping{
+				{Hub: "frontend", Spoke: "web"},
+				{Hub: "frontend.tag", Spoke: "web.version"},
+			},
+			want: &Conversion{
+				Fields: []*ConvertedField{
+					{Hub: "Spec.Frontend.Image", Spoke: "Spec.Web.Image"},
+					{Hub: "Spec.Frontend.Tag", Spoke: "Spec.Web.Version"},
+				},
+			},
+		},
+		{
+			name: "mapping to a missing field",
+			hub: []testAPIField{
+				{path: "image", fieldType: markers.FieldString},
+			},
+			spoke: []testAPIField{
+				{path: "image", fieldType: markers.FieldString},
+			},
+			mappings: []*FieldMapping{
+				{Hub: "image", Spoke: "containerImage"},
+			},
+			wantError: ErrInvalidFieldMapping,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := NewConversion(newTestAPIFields(t, tt.hub...), newTestAPIFields(t, tt.spoke...), tt.mappings)
+			if tt.wantError != nil {
+				assert.ErrorIs(t, err, tt.wantError)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_validateConversions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		conversion []*APIConversion
+		wantErr    bool
+	}{
+		{
+			name:       "no conversions",
+			conversion: nil,
+			wantErr:    false,
+		},
+		{
+			name: "valid conversion",
+			conversion: []*APIConversion{
+				{
+					Version:        "v1alpha1",
+					WorkloadConfig: "v1alpha1/workload.yaml",
+					Fields:         []*FieldMapping{{Hub: "image", Spoke: "containerImage"}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:       "hub version",
+			conversion: []*APIConversion{{Version: "v1alpha2", WorkloadConfig: "workload.yaml"}},
+			wantErr:    true,
+		},
+		{
+			name: "duplicate version",
+			conversion: []*APIConversion{
+				{Version: "v1alpha1", WorkloadConfig: "v1alpha1/workload.yaml"},
+				{Version: "v1alpha1", WorkloadConfig: "v1alpha1/workload.yaml"},
+			},
+			wantErr: true,
+		},
+		{
+			name:       "missing workload config",
+			conversion: []*APIConversion{{Version: "v1alpha1"}},
+			wantErr:    true,
+		},
+		{
+			name: "incomplete field mapping",
+			conversion: []*APIConversion{
+				{
+					Version:        "v1alpha1",
+					WorkloadConfig: "v1alpha1/workload.yaml",
+					Fields:         []*FieldMapping{{Hub: "image"}},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			api := &WorkloadAPISpec{Version: "v1alpha2", Conversion: tt.conversion}
+
+			err := validateConversions(api)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidConversion)
+
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
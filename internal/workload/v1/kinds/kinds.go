@@ -26,7 +26,12 @@ const (
 	WorkloadKindComponentString  = "ComponentWorkload"
 )
 
-func Decode(wk WorkloadKind, dc *yaml.Decoder) (WorkloadBuilder, error) {
+func Decode(apiVersion string, wk WorkloadKind, dc *yaml.Decoder) (WorkloadBuilder, error) {
+	dc, err := decodeVersion(apiVersion, dc)
+	if err != nil {
+		return nil, err
+	}
+
 	switch wk {
 	case WorkloadKindStandalone:
 		return decodeStandalone(dc)
@@ -92,6 +97,8 @@ func decodeStandalone(dc *yaml.Decoder) (*StandaloneWorkload, error) {
 		return nil, fmt.Errorf("%w", err)
 	}
 
+	v.APIVersion = APIVersionCurrent
+
 	return v, nil
 }
 
@@ -101,6 +108,8 @@ func decodeCollection(dc *yaml.Decoder) (*WorkloadCollection, error) {
 		return nil, fmt.Errorf("%w", err)
 	}
 
+	v.APIVersion = APIVersionCurrent
+
 	return v, nil
 }
 
@@ -110,5 +119,7 @@ func decodeComponent(dc *yaml.Decoder) (*ComponentWorkload, error) {
 		return nil, fmt.Errorf("%w", err)
 	}
 
+	v.APIVersion = APIVersionCurrent
+
 	return v, nil
 }
@@ -0,0 +1,163 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package kinds
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/nukleros/operator-builder/internal/utils"
+	"github.com/nukleros/operator-builder/internal/workload/v1/markers"
+)
+
+var ErrInvalidCommonMetadata = errors.New("invalid common metadata")
+
+var (
+	namePrefixRegex    = regexp.MustCompile(`^[a-z0-9][-a-z0-9.]*$`)
+	metadataFieldRegex = regexp.MustCompile(`^[a-z][A-Za-z0-9]*$`)
+)
+
+// CommonMetadata defines the metadata which is applied to all of the child resources of a
+// workload.
+type CommonMetadata struct {
+	Labels           map[string]string
+	Annotations      map[string]string
+	NamePrefix       string
+	LabelsField      string
+	AnnotationsField string
+}
+
+// IsSet returns whether any of the common metadata is set.
+func (metadata *CommonMetadata) IsSet() bool {
+	return len(metadata.Labels) > 0 ||
+		len(metadata.Annotations) > 0 ||
+		metadata.NamePrefix != "" ||
+		metadata.LabelsField != "" ||
+		metadata.AnnotationsField != ""
+}
+
+// LabelsCode returns the common labels as Go source code.
+func (metadata *CommonMetadata) LabelsCode() string {
+	return stringMapCode(metadata.Labels)
+}
+
+// AnnotationsCode returns the common annotations as Go source code.
+func (metadata *CommonMetadata) AnnotationsCode() string {
+	return stringMapCode(metadata.Annotations)
+}
+
+// LabelsFieldName returns the name of the API spec field which holds the extra labels.
+func (metadata *CommonMetadata) LabelsFieldName() string {
+	return utils.ToTitle(metadata.LabelsField)
+}
+
+// AnnotationsFieldName returns the name of the API spec field which holds the extra annotations.
+func (metadata *CommonMetadata) AnnotationsFieldName() string {
+	return utils.ToTitle(metadata.AnnotationsField)
+}
+
+// PrefixName returns the name of a child resource with the name prefix applied.  The names of
+// namespaces and custom resource definitions are never prefixed, as the former are referenced by
+// other resources and the latter must match the API which they define.
+func (metadata *CommonMetadata) PrefixName(kind, name string) string {
+	if kind == "Namespace" || kind == "CustomResourceDefinition" {
+		return name
+	}
+
+	return metadata.NamePrefix + name
+}
+
+// validate returns an error if any of the common metadata is invalid.
+func (metadata *CommonMetadata) validate() error {
+	invalidFields := []string{}
+
+	for key, value := range metadata.Labels {
+		if len(validation.IsQualifiedName(key)) > 0 || len(validation.IsValidLabelValue(value)) > 0 {
+			invalidFields = append(invalidFields, fmt.Sprintf("spec.commonLabels[%s]", key))
+		}
+	}
+
+	for key := range metadata.Annotations {
+		if len(validation.IsQualifiedName(key)) > 0 {
+			invalidFields = append(invalidFields, fmt.Sprintf("spec.commonAnnotations[%s]", key))
+		}
+	}
+
+	if metadata.NamePrefix != "" && !namePrefixRegex.MatchString(metadata.NamePrefix) {
+		invalidFields = append(invalidFields, "spec.namePrefix")
+	}
+
+	if metadata.LabelsField != "" && !metadataFieldRegex.MatchString(metadata.LabelsField) {
+		invalidFields = append(invalidFields, "spec.commonLabelsField")
+	}
+
+	if metadata.AnnotationsField != "" && (!metadataFieldRegex.MatchString(metadata.AnnotationsField) ||
+		metadata.AnnotationsField == metadata.LabelsField) {
+		invalidFields = append(invalidFields, "spec.commonAnnotationsField")
+	}
+
+	if len(invalidFields) > 0 {
+		sort.Strings(invalidFields)
+
+		return fmt.Errorf("%w: %s", ErrInvalidCommonMetadata, invalidFields)
+	}
+
+	return nil
+}
+
+// appendFields appends the API spec fields which hold the extra labels and annotations that are
+// applied to all of the child resources of a workload.
+func (metadata *CommonMetadata) appendFields(api *APIFields) error {
+	fields := []struct {
+		name    string
+		comment string
+	}{
+		{
+			name:    metadata.LabelsField,
+			comment: "Extra labels which are applied to all child resources, along with the common labels.",
+		},
+		{
+			name:    metadata.AnnotationsField,
+			comment: "Extra annotations which are applied to all child resources, along with the common annotations.",
+		},
+	}
+
+	for _, field := range fields {
+		if field.name == "" {
+			continue
+		}
+
+		if err := api.AddField(field.name, markers.FieldStringMap, []string{field.comment}, nil, false); err != nil {
+			return fmt.Errorf("%w; %s", err, ErrInvalidCommonMetadata.Error())
+		}
+	}
+
+	return nil
+}
+
+// stringMapCode returns a map of strings as Go source code, with the keys in sorted order.
+func stringMapCode(values map[string]string) string {
+	if len(values) == 0 {
+		return "nil"
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = fmt.Sprintf("%q: %q", key, values[key])
+	}
+
+	return "map[string]string{" + strings.Join(pairs, ", ") + "}"
+}
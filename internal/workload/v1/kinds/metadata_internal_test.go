@@ -0,0 +1,116 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package kinds
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nukleros/operator-builder/internal/workload/v1/markers"
+)
+
+func TestCommonMetadata_validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		metadata *CommonMetadata
+		wantErr  bool
+	}{
+		{
+			name:     "empty metadata",
+			metadata: &CommonMetadata{},
+			wantErr:  false,
+		},
+		{
+			name: "valid metadata",
+			metadata: &CommonMetadata{
+				Labels:           map[string]string{"app.kubernetes.io/part-of": "acme"},
+				Annotations:      map[string]string{"acme.com/contact": "platform team"},
+				NamePrefix:       "acme-",
+				LabelsField:      "extraLabels",
+				AnnotationsField: "extraAnnotations",
+			},
+			wantErr: false,
+		},
+		{
+			name:     "invalid label value",
+			metadata: &CommonMetadata{Labels: map[string]string{"owner": "platform team"}},
+			wantErr:  true,
+		},
+		{
+			name:     "invalid annotation key",
+			metadata: &CommonMetadata{Annotations: map[string]string{"acme.com/": "platform"}},
+			wantErr:  true,
+		},
+		{
+			name:     "invalid name prefix",
+			metadata: &CommonMetadata{NamePrefix: "Acme_"},
+			wantErr:  true,
+		},
+		{
+			name:     "invalid labels field",
+			metadata: &CommonMetadata{LabelsField: "extra.labels"},
+			wantErr:  true,
+		},
+		{
+			name:     "same labels and annotations field",
+			metadata: &CommonMetadata{LabelsField: "extra", AnnotationsField: "extra"},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.metadata.validate()
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidCommonMetadata)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCommonMetadata_Code(t *testing.T) {
+	t.Parallel()
+
+	empty := &CommonMetadata{}
+	assert.False(t, empty.IsSet())
+	assert.Equal(t, "nil", empty.LabelsCode())
+	assert.Equal(t, "my-app", empty.PrefixName("Deployment", "my-app"))
+
+	metadata := &CommonMetadata{
+		Labels:      map[string]string{"team": "platform", "app.kubernetes.io/part-of": "acme"},
+		NamePrefix:  "acme-",
+		LabelsField: "extraLabels",
+	}
+	assert.True(t, metadata.IsSet())
+	assert.Equal(t, `map[string]string{"app.kubernetes.io/part-of": "acme", "team": "platform"}`, metadata.LabelsCode())
+	assert.Equal(t, "ExtraLabels", metadata.LabelsFieldName())
+	assert.Equal(t, "acme-my-app", metadata.PrefixName("Deployment", "my-app"))
+	assert.Equal(t, "my-namespace", metadata.PrefixName("Namespace", "my-namespace"))
+}
+
+func TestCommonMetadata_appendFields(t *testing.T) {
+	t.Parallel()
+
+	api := &APIFields{Name: "Spec", Type: markers.FieldStruct}
+
+	metadata := &CommonMetadata{LabelsField: "extraLabels", AnnotationsField: "extraAnnotations"}
+	require.NoError(t, metadata.appendFields(api))
+	require.Len(t, api.Children, 2)
+	assert.Equal(t, "ExtraLabels", api.Children[0].Name)
+	assert.Equal(t, markers.FieldStringMap, api.Children[0].Type)
+	assert.Equal(t, "ExtraAnnotations", api.Children[1].Name)
+
+	conflicting := &APIFields{Name: "Spec", Type: markers.FieldStruct}
+	require.NoError(t, conflicting.AddField("extraLabels", markers.FieldString, nil, "value", false))
+	assert.Error(t, metadata.appendFields(conflicting))
+}
@@ -0,0 +1,207 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package kinds
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/nukleros/operator-builder/internal/utils"
+)
+
+var ErrInvalidPhases = errors.New("invalid phases")
+
+const (
+	PhaseEventCreate = "create"
+	PhaseEventUpdate = "update"
+	PhaseEventDelete = "delete"
+
+	PhaseDependency       = "Dependency"
+	PhaseCreateResources  = "Create-Resources"
+	PhaseCheckReady       = "Check-Ready"
+	PhaseComplete         = "Complete"
+	PhaseDeletionComplete = "DeletionComplete"
+)
+
+var phaseNameRegex = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]*(-[A-Za-z0-9]+)*$`)
+
+// Phase defines a custom phase of the controller for a workload, which is executed along with the
+// phases that are generated for every workload.
+type Phase struct {
+	Name         string        `json:"name" yaml:"name" jsonschema:"required" jsonschema_description:"The name of the phase, such as Pre-Create-Validation, which is used for the phase condition and the name of the phase function."`
+	Position     PhasePosition `json:"position" yaml:"position" jsonschema:"required" jsonschema_description:"The position of the phase relative to another phase."`
+	Events       []string      `json:"events,omitempty" yaml:"events,omitempty" jsonschema:"enum=create,enum=update,enum=delete" jsonschema_description:"The events upon which the phase is executed, which defaults to create and update."`
+	RequeueDelay string        `json:"requeueDelay,omitempty" yaml:"requeueDelay,omitempty" jsonschema_description:"The delay before the phase is executed again when it does not proceed.  If omitted, the workload is requeued immediately."`
+}
+
+// PhasePosition defines the position of a custom phase as either before or after another phase.
+type PhasePosition struct {
+	Before string `json:"before,omitempty" yaml:"before,omitempty" jsonschema_description:"The name of the phase before which the phase is executed."`
+	After  string `json:"after,omitempty" yaml:"after,omitempty" jsonschema_description:"The name of the phase after which the phase is executed."`
+}
+
+// PhaseEvents returns the events for which phases are registered, in the order in which they
+// are registered.
+func PhaseEvents() []string {
+	return []string{PhaseEventCreate, PhaseEventUpdate, PhaseEventDelete}
+}
+
+// defaultPhases returns the names of the phases which are generated for every workload for an
+// event, in the order in which they are executed.
+func defaultPhases(event string) []string {
+	if event == PhaseEventDelete {
+		return []string{PhaseDeletionComplete}
+	}
+
+	return []string{PhaseDependency, PhaseCreateResources, PhaseCheckReady, PhaseComplete}
+}
+
+// FuncName returns the name of the function which executes the phase for a kind.
+func (phase *Phase) FuncName(kind string) string {
+	return kind + utils.ToPascalCase(phase.Name) + "Phase"
+}
+
+// RequeueDelayName returns the name of the constant for the requeue delay of the phase for a kind.
+func (phase *Phase) RequeueDelayName(kind string) string {
+	return kind + utils.ToPascalCase(phase.Name) + "RequeueDelay"
+}
+
+// RequeueDelayCode returns the requeue delay of the phase as Go source code.
+func (phase *Phase) RequeueDelayCode() string {
+	delay, _ := parseDuration(phase.RequeueDelay, 0)
+
+	return durationCode(delay)
+}
+
+// HasRequeueDelay returns whether the phase is executed again after a delay when it does not
+// proceed.
+func (phase *Phase) HasRequeueDelay() bool {
+	return phase.RequeueDelay != ""
+}
+
+// HasEvent returns whether the phase is executed upon an event.
+func (phase *Phase) HasEvent(event string) bool {
+	events := phase.Events
+	if len(events) == 0 {
+		events = []string{PhaseEventCreate, PhaseEventUpdate}
+	}
+
+	for i := range events {
+		if events[i] == event {
+			return true
+		}
+	}
+
+	return false
+}
+
+// OrderPhases returns the names of all of the phases which are executed upon an event, in the
+// order in which they are executed.  Custom phases are positioned in the order in which they are
+// declared, so that a custom phase may be positioned relative to a custom phase which precedes it.
+func OrderPhases(phases []*Phase, event string) ([]string, error) {
+	ordered := defaultPhases(event)
+
+	// track the anchor of the custom phases which are positioned after another phase, so that
+	// custom phases which are positioned after the same phase remain in the order declared
+	afterAnchors := map[string]string{}
+
+	for _, phase := range phases {
+		if !phase.HasEvent(event) {
+			continue
+		}
+
+		anchor := phase.Position.Before
+		if anchor == "" {
+			anchor = phase.Position.After
+		}
+
+		index := indexOfPhase(ordered, anchor)
+		if index < 0 {
+			return nil, fmt.Errorf(
+				"%w; phase %s is positioned relative to phase %s which is not executed upon the %s event",
+				ErrInvalidPhases, phase.Name, anchor, event,
+			)
+		}
+
+		if phase.Position.After != "" {
+			index++
+
+			for index < len(ordered) && afterAnchors[ordered[index]] == anchor {
+				index++
+			}
+
+			afterAnchors[phase.Name] = anchor
+		}
+
+		ordered = append(ordered[:index], append([]string{phase.Name}, ordered[index:]...)...)
+	}
+
+	return ordered, nil
+}
+
+// validatePhases returns an error if any of the custom phases are invalid.
+func validatePhases(phases []*Phase) error {
+	invalidFields := []string{}
+
+	names := map[string]bool{}
+	for _, event := range PhaseEvents() {
+		for _, name := range defaultPhases(event) {
+			names[name] = true
+		}
+	}
+
+	for i, phase := range phases {
+		path := fmt.Sprintf("spec.phases[%d]", i)
+
+		if !phaseNameRegex.MatchString(phase.Name) || names[phase.Name] {
+			invalidFields = append(invalidFields, path+".name")
+		}
+
+		names[phase.Name] = true
+
+		if (phase.Position.Before == "") == (phase.Position.After == "") {
+			invalidFields = append(invalidFields, path+".position")
+		}
+
+		for _, event := range phase.Events {
+			if event != PhaseEventCreate && event != PhaseEventUpdate && event != PhaseEventDelete {
+				invalidFields = append(invalidFields, path+".events")
+
+				break
+			}
+		}
+
+		// a requeue delay of zero would result in the workload never being requeued
+		if delay, err := parseDuration(phase.RequeueDelay, time.Second); err != nil || delay == 0 {
+			invalidFields = append(invalidFields, path+".requeueDelay")
+		}
+	}
+
+	if len(invalidFields) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidPhases, invalidFields)
+	}
+
+	// ensure that each of the phases may be positioned for each of its events
+	for _, event := range PhaseEvents() {
+		if _, err := OrderPhases(phases, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// indexOfPhase returns the index of a phase within a list of phase names, or -1 if the phase is
+// not within the list.
+func indexOfPhase(phases []string, name string) int {
+	for i := range phases {
+		if phases[i] == name {
+			return i
+		}
+	}
+
+	return -1
+}
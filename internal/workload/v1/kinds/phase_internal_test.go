@@ -0,0 +1,188 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package kinds
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderPhases(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		phases   []*Phase
+		event    string
+		expected []string
+		wantErr  bool
+	}{
+		{
+			name:     "no custom phases",
+			phases:   []*Phase{},
+			event:    PhaseEventCreate,
+			expected: []string{"Dependency", "Create-Resources", "Check-Ready", "Complete"},
+		},
+		{
+			name: "custom phases before and after default phases",
+			phases: []*Phase{
+				{Name: "Validate", Position: PhasePosition{Before: "Create-Resources"}},
+				{Name: "Smoke-Test", Position: PhasePosition{After: "Check-Ready"}},
+			},
+			event:    PhaseEventUpdate,
+			expected: []string{"Dependency", "Validate", "Create-Resources", "Check-Ready", "Smoke-Test", "Complete"},
+		},
+		{
+			name: "custom phases after the same phase remain in the order declared",
+			phases: []*Phase{
+				{Name: "First", Position: PhasePosition{After: "Dependency"}},
+				{Name: "Second", Position: PhasePosition{After: "Dependency"}},
+				{Name: "Third", Position: PhasePosition{Before: "Create-Resources"}},
+			},
+			event:    PhaseEventCreate,
+			expected: []string{"Dependency", "First", "Second", "Third", "Create-Resources", "Check-Ready", "Complete"},
+		},
+		{
+			name: "custom phase positioned relative to a custom phase",
+			phases: []*Phase{
+				{Name: "Backup", Position: PhasePosition{Before: "Create-Resources"}, Events: []string{"update"}},
+				{Name: "Verify-Backup", Position: PhasePosition{After: "Backup"}, Events: []string{"update"}},
+			},
+			event:    PhaseEventUpdate,
+			expected: []string{"Dependency", "Backup", "Verify-Backup", "Create-Resources", "Check-Ready", "Complete"},
+		},
+		{
+			name: "custom phase for another event is skipped",
+			phases: []*Phase{
+				{Name: "Backup", Position: PhasePosition{Before: "Create-Resources"}, Events: []string{"update"}},
+			},
+			event:    PhaseEventCreate,
+			expected: []string{"Dependency", "Create-Resources", "Check-Ready", "Complete"},
+		},
+		{
+			name: "custom delete phase",
+			phases: []*Phase{
+				{Name: "Cleanup", Position: PhasePosition{Before: "DeletionComplete"}, Events: []string{"delete"}},
+			},
+			event:    PhaseEventDelete,
+			expected: []string{"Cleanup", "DeletionComplete"},
+		},
+		{
+			name: "custom phase positioned relative to a phase which is not executed for the event",
+			phases: []*Phase{
+				{Name: "Cleanup", Position: PhasePosition{Before: "Complete"}, Events: []string{"delete"}},
+			},
+			event:   PhaseEventDelete,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ordered, err := OrderPhases(tt.phases, tt.event)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidPhases)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, ordered)
+		})
+	}
+}
+
+func Test_validatePhases(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		phases  []*Phase
+		wantErr bool
+	}{
+		{
+			name: "valid phases",
+			phases: []*Phase{
+				{Name: "Pre-Create-Validation", Position: PhasePosition{Before: "Create-Resources"}, Events: []string{"create"}},
+				{Name: "Smoke-Test", Position: PhasePosition{After: "Check-Ready"}, RequeueDelay: "30s"},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "invalid name",
+			phases:  []*Phase{{Name: "smoke test", Position: PhasePosition{After: "Check-Ready"}}},
+			wantErr: true,
+		},
+		{
+			name:    "name of a default phase",
+			phases:  []*Phase{{Name: "Complete", Position: PhasePosition{After: "Check-Ready"}}},
+			wantErr: true,
+		},
+		{
+			name: "duplicate names",
+			phases: []*Phase{
+				{Name: "Smoke-Test", Position: PhasePosition{After: "Check-Ready"}},
+				{Name: "Smoke-Test", Position: PhasePosition{After: "Complete"}},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "missing position",
+			phases:  []*Phase{{Name: "Smoke-Test"}},
+			wantErr: true,
+		},
+		{
+			name:    "both before and after",
+			phases:  []*Phase{{Name: "Smoke-Test", Position: PhasePosition{Before: "Complete", After: "Check-Ready"}}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid event",
+			phases:  []*Phase{{Name: "Smoke-Test", Position: PhasePosition{After: "Check-Ready"}, Events: []string{"upgrade"}}},
+			wantErr: true,
+		},
+		{
+			name:    "zero requeue delay",
+			phases:  []*Phase{{Name: "Smoke-Test", Position: PhasePosition{After: "Check-Ready"}, RequeueDelay: "0s"}},
+			wantErr: true,
+		},
+		{
+			name:    "unknown phase in position",
+			phases:  []*Phase{{Name: "Smoke-Test", Position: PhasePosition{After: "Ready"}}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validatePhases(tt.phases)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidPhases)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPhase_Names(t *testing.T) {
+	t.Parallel()
+
+	phase := &Phase{Name: "Pre-Create-Validation", RequeueDelay: "30s"}
+
+	assert.Equal(t, "WebAppPreCreateValidationPhase", phase.FuncName("WebApp"))
+	assert.Equal(t, "WebAppPreCreateValidationRequeueDelay", phase.RequeueDelayName("WebApp"))
+	assert.Equal(t, "30 * time.Second", phase.RequeueDelayCode())
+	assert.True(t, phase.HasRequeueDelay())
+	assert.True(t, phase.HasEvent(PhaseEventCreate))
+	assert.False(t, phase.HasEvent(PhaseEventDelete))
+}
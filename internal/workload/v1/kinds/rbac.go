@@ -0,0 +1,146 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package kinds
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/nukleros/operator-builder/internal/workload/v1/rbac"
+)
+
+var ErrInvalidRBACOptions = errors.New("invalid rbac options")
+
+const (
+	RBACScopeCluster   = "cluster"
+	RBACScopeNamespace = "namespace"
+
+	// rbacNamespace is the namespace of the controller manager in the generated kustomize
+	// manifests, which is replaced with the namespace of the project when they are built.
+	rbacNamespace = "system"
+)
+
+// RBACOptions defines the options for the RBAC of the controller of a workload, in addition to
+// the RBAC which is derived from its child resources.
+type RBACOptions struct {
+	Scope      string      `json:"scope,omitempty" yaml:"scope,omitempty" jsonschema:"enum=cluster,enum=namespace" jsonschema_description:"The scope of the RBAC for the workload, which defaults to cluster.  When namespace, the resource rules are granted by a role in the namespace of the controller manager rather than by a cluster role."`
+	ExtraRules []*RBACRule `json:"extraRules,omitempty" yaml:"extraRules,omitempty" validate:"omitempty" jsonschema_description:"The rules which are granted to the controller in addition to the rules which are derived from the child resources."`
+	Minimal    bool        `json:"minimal,omitempty" yaml:"minimal,omitempty" jsonschema_description:"Whether the rules for the child resources only include the verbs which the generated controller uses, rather than all verbs.  The rules which are requested by child roles and cluster roles are unchanged."`
+}
+
+// RBACRule defines a rule which is granted to the controller of a workload.  The fields match
+// those of a Kubernetes policy rule.
+type RBACRule struct {
+	APIGroups       []string `json:"apiGroups,omitempty" yaml:"apiGroups,omitempty" jsonschema_description:"The API groups of the resources, where an empty string is the core group."`
+	Resources       []string `json:"resources,omitempty" yaml:"resources,omitempty" jsonschema_description:"The resources to which the rule applies, such as deployments or deployments/status."`
+	Verbs           []string `json:"verbs" yaml:"verbs" jsonschema:"required" jsonschema_description:"The verbs which are granted on the resources or non-resource URLs."`
+	NonResourceURLs []string `json:"nonResourceURLs,omitempty" yaml:"nonResourceURLs,omitempty" jsonschema_description:"The non-resource URLs to which the rule applies, such as /metrics."`
+}
+
+// IsNamespaced returns whether the RBAC for the workload is granted by a role in the namespace of
+// the controller manager.
+func (options *RBACOptions) IsNamespaced() bool {
+	return options != nil && options.Scope == RBACScopeNamespace
+}
+
+// validate returns an error if any of the rbac options are invalid.  A workload with a cluster
+// scoped API may not have namespace scoped RBAC, as its custom resources exist outside of the
+// namespace of the controller manager.
+func (options *RBACOptions) validate(clusterScoped bool) error {
+	if options == nil {
+		return nil
+	}
+
+	invalidFields := []string{}
+
+	switch options.Scope {
+	case "", RBACScopeCluster:
+	case RBACScopeNamespace:
+		if clusterScoped {
+			invalidFields = append(invalidFields, "spec.rbac.scope")
+		}
+	default:
+		invalidFields = append(invalidFields, "spec.rbac.scope")
+	}
+
+	for i, rule := range options.ExtraRules {
+		field := fmt.Sprintf("spec.rbac.extraRules[%d]", i)
+
+		switch {
+		case len(rule.Verbs) == 0:
+			invalidFields = append(invalidFields, field+".verbs")
+		case len(rule.NonResourceURLs) > 0 && (len(rule.APIGroups) > 0 || len(rule.Resources) > 0):
+			// a rule may either grant access to resources or to non-resource URLs, but not both
+			invalidFields = append(invalidFields, field)
+		case len(rule.NonResourceURLs) > 0:
+			// non-resource URLs may only be granted by a cluster role
+			if options.IsNamespaced() {
+				invalidFields = append(invalidFields, field+".nonResourceURLs")
+			}
+		case len(rule.APIGroups) == 0:
+			invalidFields = append(invalidFields, field+".apiGroups")
+		case len(rule.Resources) == 0:
+			invalidFields = append(invalidFields, field+".resources")
+		}
+	}
+
+	if len(invalidFields) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidRBACOptions, invalidFields)
+	}
+
+	return nil
+}
+
+// clusterScopedKinds returns the kinds of the Kubernetes APIs which are cluster scoped.
+func clusterScopedKinds() []string {
+	return []string{
+		"APIService",
+		"CertificateSigningRequest",
+		"ClusterRole",
+		"ClusterRoleBinding",
+		"CSIDriver",
+		"CSINode",
+		"CustomResourceDefinition",
+		"FlowSchema",
+		"IngressClass",
+		"MutatingWebhookConfiguration",
+		"Namespace",
+		"Node",
+		"PersistentVolume",
+		"PriorityClass",
+		"PriorityLevelConfiguration",
+		"RuntimeClass",
+		"StorageClass",
+		"ValidatingAdmissionPolicy",
+		"ValidatingAdmissionPolicyBinding",
+		"ValidatingWebhookConfiguration",
+		"VolumeAttachment",
+	}
+}
+
+// isClusterScopedKind returns whether a kind of the Kubernetes APIs is cluster scoped.
+func isClusterScopedKind(kind string) bool {
+	for _, clusterScopedKind := range clusterScopedKinds() {
+		if kind == clusterScopedKind {
+			return true
+		}
+	}
+
+	return false
+}
+
+// toRules converts an extra rule into a set of rules, which are processed in the same way as the
+// rules of the role and cluster role child resources.
+func (rule *RBACRule) toRules() *rbac.Rules {
+	rules := &rbac.Rules{}
+
+	rules.Add(&rbac.RoleRule{
+		Groups:    rule.APIGroups,
+		Resources: rule.Resources,
+		Verbs:     rule.Verbs,
+		URLs:      rule.NonResourceURLs,
+	})
+
+	return rules
+}
@@ -0,0 +1,164 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package kinds
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nukleros/operator-builder/internal/workload/v1/manifests"
+	"github.com/nukleros/operator-builder/internal/workload/v1/rbac"
+)
+
+func TestRBACOptions_validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		options       *RBACOptions
+		clusterScoped bool
+		wantErr       bool
+	}{
+		{
+			name:    "nil options",
+			options: nil,
+			wantErr: false,
+		},
+		{
+			name: "valid options",
+			options: &RBACOptions{
+				Scope: RBACScopeCluster,
+				ExtraRules: []*RBACRule{
+					{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"get", "list"}},
+					{NonResourceURLs: []string{"/metrics"}, Verbs: []string{"get"}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid namespace scope",
+			options: &RBACOptions{
+				Scope:      RBACScopeNamespace,
+				ExtraRules: []*RBACRule{{APIGroups: []string{"apps"}, Resources: []string{"deployments"}, Verbs: []string{"get"}}},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "invalid scope",
+			options: &RBACOptions{Scope: "cluster-wide"},
+			wantErr: true,
+		},
+		{
+			name:          "namespace scope with cluster scoped api",
+			options:       &RBACOptions{Scope: RBACScopeNamespace},
+			clusterScoped: true,
+			wantErr:       true,
+		},
+		{
+			name:    "missing verbs",
+			options: &RBACOptions{ExtraRules: []*RBACRule{{APIGroups: []string{""}, Resources: []string{"secrets"}}}},
+			wantErr: true,
+		},
+		{
+			name:    "missing resources",
+			options: &RBACOptions{ExtraRules: []*RBACRule{{APIGroups: []string{""}, Verbs: []string{"get"}}}},
+			wantErr: true,
+		},
+		{
+			name: "resources and non-resource urls",
+			options: &RBACOptions{ExtraRules: []*RBACRule{{
+				APIGroups:       []string{""},
+				Resources:       []string{"secrets"},
+				NonResourceURLs: []string{"/metrics"},
+				Verbs:           []string{"get"},
+			}}},
+			wantErr: true,
+		},
+		{
+			name: "non-resource urls with namespace scope",
+			options: &RBACOptions{
+				Scope:      RBACScopeNamespace,
+				ExtraRules: []*RBACRule{{NonResourceURLs: []string{"/metrics"}, Verbs: []string{"get"}}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.options.validate(tt.clusterScoped)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidRBACOptions)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestWorkloadSpec_setRBACOptions(t *testing.T) {
+	t.Parallel()
+
+	childRules := &rbac.Rules{{Group: "apps", Resource: "deployments", Verbs: []string{"get"}}}
+
+	ws := &WorkloadSpec{
+		RBAC: &RBACOptions{
+			Scope: RBACScopeNamespace,
+			ExtraRules: []*RBACRule{
+				{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"get", "list"}},
+			},
+		},
+		Manifests: &manifests.Manifests{
+			{
+				Filename:       "app.yaml",
+				ChildResources: []manifests.ChildResource{{Kind: "Deployment", Name: "webapp", RBAC: childRules}},
+			},
+		},
+		RBACRules: &rbac.Rules{},
+	}
+
+	// an external dependency upon a cluster scoped object is granted before the rbac options are set
+	ws.addClusterRBACRules("spec.externalDependencies[0]", rbac.ForDependency("cert-manager.io", "ClusterIssuer"))
+
+	ws.setRBACOptions()
+
+	// the rules to read cluster scoped objects are not scoped to the namespace
+	assert.Equal(t, rbac.Rules{
+		{
+			Group:    "cert-manager.io",
+			Resource: "clusterissuers",
+			Verbs:    []string{"get", "list", "watch"},
+		},
+		{
+			Group:     "core",
+			Resource:  "secrets",
+			Namespace: "system",
+			Verbs:     []string{"get", "list"},
+		},
+	}, *ws.RBACRules)
+	assert.Equal(t, "system", (*childRules)[0].Namespace)
+
+	require.Len(t, ws.RBACGrants, 3)
+	assert.Equal(t, "spec.externalDependencies[0]", ws.RBACGrants[0].Source)
+	assert.True(t, ws.RBACGrants[0].ClusterScoped)
+	assert.Empty(t, (*ws.RBACGrants[0].Rules)[0].Namespace)
+	assert.Equal(t, "spec.rbac.extraRules[0]", ws.RBACGrants[1].Source)
+	assert.Equal(t, "Deployment/webapp", ws.RBACGrants[2].Source)
+	assert.Equal(t, "app.yaml", ws.RBACGrants[2].Manifest)
+	assert.Same(t, childRules, ws.RBACGrants[2].Rules)
+}
+
+func Test_isClusterScopedKind(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, isClusterScopedKind("Namespace"))
+	assert.True(t, isClusterScopedKind("ClusterRole"))
+	assert.False(t, isClusterScopedKind("Deployment"))
+	assert.False(t, isClusterScopedKind("Role"))
+}
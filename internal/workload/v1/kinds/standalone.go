@@ -20,15 +20,16 @@ var ErrNoComponentsOnStandalone = errors.New("cannot set component workloads on
 
 // StandaloneWorkloadSpec defines the attributes for a standalone workload.
 type StandaloneWorkloadSpec struct {
-	API                 WorkloadAPISpec `json:"api" yaml:"api"`
-	CompanionCliRootcmd companion.CLI   `json:"companionCliRootcmd" yaml:"companionCliRootcmd" validate:"omitempty"`
+	API                 WorkloadAPISpec `json:"api" yaml:"api" jsonschema:"required" jsonschema_description:"The API which is generated for the workload."`
+	CompanionCliRootcmd companion.CLI   `json:"companionCliRootcmd" yaml:"companionCliRootcmd" validate:"omitempty" jsonschema_description:"The root command of the companion CLI."`
+	CompanionCliSubcmd  companion.CLI   `json:"companionCliSubcmd,omitempty" yaml:"companionCliSubcmd,omitempty" validate:"omitempty" jsonschema_description:"The subcommand of the companion CLI for the workload, used when the companion CLI is shared with other workloads."`
 	WorkloadSpec        `yaml:",inline"`
 }
 
 // StandaloneWorkload defines a standalone workload.
 type StandaloneWorkload struct {
 	WorkloadShared `yaml:",inline"`
-	Spec           StandaloneWorkloadSpec `json:"spec" yaml:"spec" validate:"required"`
+	Spec           StandaloneWorkloadSpec `json:"spec" yaml:"spec" validate:"required" jsonschema:"required"`
 }
 
 // NewStandaloneWorkload returns a new standalone workload object.
@@ -39,8 +40,9 @@ func NewStandaloneWorkload(
 ) *StandaloneWorkload {
 	return &StandaloneWorkload{
 		WorkloadShared: WorkloadShared{
-			Kind: WorkloadKindStandalone,
-			Name: name,
+			APIVersion: APIVersionCurrent,
+			Kind:       WorkloadKindStandalone,
+			Name:       name,
 		},
 		Spec: StandaloneWorkloadSpec{
 			API: spec,
@@ -79,7 +81,11 @@ func (s *StandaloneWorkload) Validate() error {
 		return fmt.Errorf("%w: %s", ErrMissingRequiredFields, missingFields)
 	}
 
-	return nil
+	if err := validateConversions(&s.Spec.API); err != nil {
+		return err
+	}
+
+	return s.Spec.validate(s.Spec.API.ClusterScoped)
 }
 
 func (s *StandaloneWorkload) GetWorkloadKind() WorkloadKind {
@@ -99,9 +105,9 @@ func (s *StandaloneWorkload) HasRootCmdDescription() bool {
 	return s.Spec.CompanionCliRootcmd.HasDescription()
 }
 
-func (*StandaloneWorkload) HasSubCmdName() bool {
-	// standalone workloads never have subcommands
-	return false
+func (s *StandaloneWorkload) HasSubCmdName() bool {
+	// standalone workloads only have subcommands when they share a companion CLI with other workloads
+	return s.Spec.CompanionCliSubcmd.HasName()
 }
 
 // methods that implement WorkloadAPIBuilder.
@@ -129,6 +135,14 @@ func (s *StandaloneWorkload) GetAPIKind() string {
 	return s.Spec.API.Kind
 }
 
+func (s *StandaloneWorkload) GetAPIConversions() []*APIConversion {
+	return s.Spec.API.Conversion
+}
+
+func (s *StandaloneWorkload) GetWebhook() *Webhook {
+	return s.Spec.Webhook
+}
+
 func (s *StandaloneWorkload) IsClusterScoped() bool {
 	return s.Spec.API.ClusterScoped
 }
@@ -146,22 +160,43 @@ func (*StandaloneWorkload) IsCollection() bool {
 }
 
 func (s *StandaloneWorkload) SetRBAC() {
-	s.Spec.RBACRules.Add(rbac.ForWorkloads(s))
+	s.Spec.addRBACRules("api", rbac.ForWorkloads(s))
+	s.Spec.setRBACOptions()
 }
 
 func (s *StandaloneWorkload) SetResources(workloadPath string) error {
-	err := s.Spec.processManifests(markers.FieldMarkerType)
+	err := s.Spec.processManifests(markers.FieldMarkerType, markers.WebhookMarkerType)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return s.Spec.processWebhookMarkers(s.Spec.API.ClusterScoped)
 }
 
 func (*StandaloneWorkload) GetDependencies() []*ComponentWorkload {
 	return []*ComponentWorkload{}
 }
 
+func (*StandaloneWorkload) GetExternalDependencies() []*ExternalDependency {
+	return []*ExternalDependency{}
+}
+
+func (s *StandaloneWorkload) GetControllerOptions() *ControllerOptions {
+	return s.Spec.getControllerOptions()
+}
+
+func (s *StandaloneWorkload) GetPhases() []*Phase {
+	return s.Spec.Phases
+}
+
+func (s *StandaloneWorkload) GetCommonMetadata() *CommonMetadata {
+	return s.Spec.getCommonMetadata()
+}
+
+func (s *StandaloneWorkload) GetRBACOptions() *RBACOptions {
+	return s.Spec.getRBACOptions()
+}
+
 func (*StandaloneWorkload) SetComponents(components []*ComponentWorkload) error {
 	return ErrNoComponentsOnStandalone
 }
@@ -193,8 +228,29 @@ func (s *StandaloneWorkload) GetRBACRules() *[]rbac.Rule {
 	return &rules
 }
 
-func (*StandaloneWorkload) GetComponentResource(domain, repo string, clusterScoped bool) *resource.Resource {
-	return &resource.Resource{}
+func (s *StandaloneWorkload) GetComponentResource(domain, repo string, clusterScoped bool) *resource.Resource {
+	api := resource.API{
+		CRDVersion: "v1",
+		Namespaced: !clusterScoped,
+	}
+
+	return &resource.Resource{
+		GVK: resource.GVK{
+			Domain:  domain,
+			Group:   s.Spec.API.Group,
+			Version: s.Spec.API.Version,
+			Kind:    s.Spec.API.Kind,
+		},
+		Plural: resource.RegularPlural(s.Spec.API.Kind),
+		Path: fmt.Sprintf(
+			"%s/apis/%s/%s",
+			repo,
+			s.Spec.API.Group,
+			s.Spec.API.Version,
+		),
+		API:        &api,
+		Controller: true,
+	}
 }
 
 func (s *StandaloneWorkload) SetNames() {
@@ -206,6 +262,11 @@ func (s *StandaloneWorkload) SetNames() {
 	if s.HasRootCmdName() {
 		// set the root command values
 		s.Spec.CompanionCliRootcmd.SetCommonValues(s, false)
+
+		// set the subcommand values if the companion CLI is shared with other workloads
+		if s.HasSubCmdName() {
+			s.Spec.CompanionCliSubcmd.SetCommonValues(s, true)
+		}
 	}
 }
 
@@ -214,8 +275,7 @@ func (s *StandaloneWorkload) GetRootCommand() *companion.CLI {
 }
 
 func (s *StandaloneWorkload) GetSubCommand() *companion.CLI {
-	// no subcommands for a standalone workload
-	return &companion.CLI{}
+	return &s.Spec.CompanionCliSubcmd
 }
 
 func (s *StandaloneWorkload) LoadManifests(workloadPath string) error {
@@ -0,0 +1,364 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package kinds
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var ErrInvalidAPIVersion = errors.New("unrecognized apiVersion in workload config")
+
+// APIVersionLegacy is the version of a workload config document which does not declare an
+// apiVersion.  Such documents were written before workload configs were versioned.
+const APIVersionLegacy = ""
+
+// APIVersionV1Alpha1 is the first versioned format of a workload config document.
+const APIVersionV1Alpha1 = "operator-builder.nukleros.io/v1alpha1"
+
+// APIVersionCurrent is the version of a workload config document which matches the workload types.
+// Documents of all other versions are converted to this version when they are decoded.
+const APIVersionCurrent = APIVersionV1Alpha1
+
+const apiVersionField = "apiVersion"
+
+// conversion converts a workload config document from one version to the next.
+type conversion struct {
+	to string
+
+	// convert modifies the object node of a document of the previous version in place so that it
+	// has the structure of the next version.
+	convert func(object *yaml.Node) error
+}
+
+// conversions are the conversions from each previous version of a workload config document to its
+// next version.  Converting a document through each conversion results in the current version.
+//
+//nolint:gochecknoglobals
+var conversions = map[string]conversion{
+	APIVersionLegacy: {to: APIVersionV1Alpha1, convert: convertLegacy},
+}
+
+// convertLegacy converts a document which does not declare an apiVersion to v1alpha1.  The first
+// versioned format kept the structure of legacy documents, so only the declared version changes.
+func convertLegacy(_ *yaml.Node) error {
+	return nil
+}
+
+// APIVersions returns the versions of a workload config document which may be declared.
+func APIVersions() []string {
+	versions := []string{APIVersionCurrent}
+
+	for version := range conversions {
+		if version != APIVersionLegacy {
+			versions = append(versions, version)
+		}
+	}
+
+	sort.Strings(versions)
+
+	return versions
+}
+
+// conversionsFrom returns the conversions which are needed to convert a document from a version to
+// the current version.
+func conversionsFrom(version string) ([]conversion, error) {
+	var chain []conversion
+
+	for version != APIVersionCurrent {
+		next, ok := conversions[version]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s - valid versions: %v", ErrInvalidAPIVersion, version, APIVersions())
+		}
+
+		chain = append(chain, next)
+		version = next.to
+	}
+
+	return chain, nil
+}
+
+// convertDocument converts the object node of a document through a set of conversions in place.  It
+// returns whether any of the conversions changed the structure of the document.
+func convertDocument(object *yaml.Node, chain []conversion) (bool, error) {
+	before, err := yaml.Marshal(object)
+	if err != nil {
+		return false, fmt.Errorf("%w; unable to encode workload config", err)
+	}
+
+	for _, next := range chain {
+		if err := next.convert(object); err != nil {
+			return false, fmt.Errorf("%w; unable to convert workload config to version %s", err, next.to)
+		}
+	}
+
+	after, err := yaml.Marshal(object)
+	if err != nil {
+		return false, fmt.Errorf("%w; unable to encode converted workload config", err)
+	}
+
+	return !bytes.Equal(before, after), nil
+}
+
+// Migrate converts a workload config document node to the current version in place.  Comments on the
+// document are kept.  It returns whether the document was changed.
+func Migrate(document *yaml.Node) (bool, error) {
+	changed, _, err := migrate(document)
+
+	return changed, err
+}
+
+// migrate converts a workload config document node to the current version in place.  It returns
+// whether the document was changed and whether its structure was changed.
+func migrate(document *yaml.Node) (bool, bool, error) {
+	object := document
+	if object.Kind == yaml.DocumentNode && len(object.Content) > 0 {
+		object = object.Content[0]
+	}
+
+	if object.Kind != yaml.MappingNode {
+		return false, false, fmt.Errorf("%w; workload config document at line %d is not a mapping", ErrInvalidKind, object.Line)
+	}
+
+	version := APIVersionLegacy
+
+	versionIndex := -1
+
+	for i := 0; i < len(object.Content)-1; i += 2 {
+		if object.Content[i].Value == apiVersionField {
+			version, versionIndex = object.Content[i+1].Value, i
+
+			break
+		}
+	}
+
+	chain, err := conversionsFrom(version)
+	if err != nil {
+		return false, false, err
+	}
+
+	if len(chain) == 0 {
+		return false, false, nil
+	}
+
+	structural, err := convertDocument(object, chain)
+	if err != nil {
+		return false, false, err
+	}
+
+	// the version is declared as the first field of the document
+	if versionIndex < 0 {
+		versionKey := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: apiVersionField}
+		versionValue := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: APIVersionCurrent}
+
+		// documents written as json are kept as json
+		if object.Style&yaml.FlowStyle != 0 {
+			versionKey.Style, versionValue.Style = yaml.DoubleQuotedStyle, yaml.DoubleQuotedStyle
+		}
+
+		// comments at the top of the document stay at the top of the document
+		if len(object.Content) > 0 {
+			versionKey.HeadComment, object.Content[0].HeadComment = object.Content[0].HeadComment, ""
+		}
+
+		object.Content = append([]*yaml.Node{versionKey, versionValue}, object.Content...)
+	} else {
+		object.Content[versionIndex+1].Value = APIVersionCurrent
+	}
+
+	return true, structural, nil
+}
+
+// versionEdit is an edit to a single line of a workload config file which declares the current
+// version of a document.
+type versionEdit struct {
+	line    int
+	replace bool
+	text    string
+}
+
+// MigrateContent converts each of the documents within the content of a workload config file to the
+// current version.  When the documents only need their declared version changed, the content is
+// edited line by line so that its formatting and comments are kept exactly.  Otherwise, the documents
+// are encoded again, which keeps comments but may change formatting.  It returns the migrated content
+// and whether any of the documents were changed.
+func MigrateContent(content []byte) ([]byte, bool, error) {
+	var documents []*yaml.Node
+
+	var edits []versionEdit
+
+	changed, encode := false, false
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+
+	for {
+		document := &yaml.Node{}
+
+		if err := decoder.Decode(document); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, false, fmt.Errorf("%w", err)
+		}
+
+		edit, needsEncoding, err := planVersionEdit(document)
+		if err != nil {
+			return nil, false, err
+		}
+
+		documentChanged, structural, err := migrate(document)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if documentChanged {
+			changed, encode = true, encode || structural || needsEncoding
+			edits = append(edits, edit)
+		}
+
+		documents = append(documents, document)
+	}
+
+	if !changed {
+		return content, false, nil
+	}
+
+	if encode {
+		return encodeDocuments(documents)
+	}
+
+	return applyVersionEdits(content, edits), true, nil
+}
+
+// planVersionEdit returns the line edit which declares the current version of a document, prior to
+// the document being migrated.  It also returns whether the document must be encoded again instead,
+// because it is not written in block style.
+func planVersionEdit(document *yaml.Node) (versionEdit, bool, error) {
+	object := document
+	if object.Kind == yaml.DocumentNode && len(object.Content) > 0 {
+		object = object.Content[0]
+	}
+
+	// documents are only edited line by line when their fields start at the beginning of a line
+	if object.Kind != yaml.MappingNode || len(object.Content) == 0 || object.Content[0].Column != 1 {
+		return versionEdit{}, true, nil
+	}
+
+	for i := 0; i < len(object.Content)-1; i += 2 {
+		if object.Content[i].Value != apiVersionField {
+			continue
+		}
+
+		key, value := object.Content[i], object.Content[i+1]
+
+		if _, err := conversionsFrom(value.Value); err != nil {
+			return versionEdit{}, false, err
+		}
+
+		text := fmt.Sprintf("%s%s: %s", strings.Repeat(" ", key.Column-1), apiVersionField, APIVersionCurrent)
+		if value.LineComment != "" {
+			text = fmt.Sprintf("%s %s", text, value.LineComment)
+		}
+
+		return versionEdit{line: key.Line, replace: true, text: text}, key.Line != value.Line, nil
+	}
+
+	first := object.Content[0]
+
+	return versionEdit{
+		line: first.Line,
+		text: fmt.Sprintf("%s%s: %s", strings.Repeat(" ", first.Column-1), apiVersionField, APIVersionCurrent),
+	}, false, nil
+}
+
+// applyVersionEdits applies a set of line edits to the content of a workload config file.
+func applyVersionEdits(content []byte, edits []versionEdit) []byte {
+	lines := strings.SplitAfter(string(content), "\n")
+
+	editsByLine := map[int]versionEdit{}
+	for _, edit := range edits {
+		editsByLine[edit.line] = edit
+	}
+
+	var buf strings.Builder
+
+	for i, line := range lines {
+		edit, found := editsByLine[i+1]
+
+		switch {
+		case !found:
+			buf.WriteString(line)
+		case edit.replace:
+			buf.WriteString(edit.text)
+
+			if strings.HasSuffix(line, "\n") {
+				buf.WriteString("\n")
+			}
+		default:
+			buf.WriteString(edit.text + "\n")
+			buf.WriteString(line)
+		}
+	}
+
+	return []byte(buf.String())
+}
+
+// encodeDocuments encodes a set of workload config documents.
+func encodeDocuments(documents []*yaml.Node) ([]byte, bool, error) {
+	buf := new(bytes.Buffer)
+
+	encoder := yaml.NewEncoder(buf)
+	encoder.SetIndent(2)
+
+	for _, document := range documents {
+		if err := encoder.Encode(document); err != nil {
+			return nil, false, fmt.Errorf("%w; unable to encode workload config", err)
+		}
+	}
+
+	if err := encoder.Close(); err != nil {
+		return nil, false, fmt.Errorf("%w; unable to encode workload config", err)
+	}
+
+	return buf.Bytes(), true, nil
+}
+
+// decodeVersion decodes the next document of a stream, given its declared version, and converts it
+// to the current version.  It returns a decoder for the converted document, which is decoded into
+// the workload types as the current version.
+func decodeVersion(version string, dc *yaml.Decoder) (*yaml.Decoder, error) {
+	var document yaml.Node
+	if err := dc.Decode(&document); err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	chain, err := conversionsFrom(version)
+	if err != nil {
+		return nil, err
+	}
+
+	object := &document
+	if object.Kind == yaml.DocumentNode && len(object.Content) > 0 {
+		object = object.Content[0]
+	}
+
+	if _, err := convertDocument(object, chain); err != nil {
+		return nil, err
+	}
+
+	converted, err := yaml.Marshal(&document)
+	if err != nil {
+		return nil, fmt.Errorf("%w; unable to encode converted workload config", err)
+	}
+
+	convertedDecoder := yaml.NewDecoder(bytes.NewReader(converted))
+	convertedDecoder.KnownFields(true)
+
+	return convertedDecoder, nil
+}
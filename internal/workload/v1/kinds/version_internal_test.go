@@ -0,0 +1,296 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package kinds
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestMigrate(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		name        string
+		input       string
+		want        string
+		wantChanged bool
+		wantErr     bool
+	}{
+		{
+			name: "legacy config is migrated with comments kept",
+			input: `# the webapp workload
+name: webapp  # the name
+kind: StandaloneWorkload
+spec:
+  resources:
+    - deploy.yaml
+`,
+			want: `# the webapp workload
+apiVersion: ` + APIVersionCurrent + `
+name: webapp # the name
+kind: StandaloneWorkload
+spec:
+  resources:
+    - deploy.yaml
+`,
+			wantChanged: true,
+		},
+		{
+			name: "current config is unchanged",
+			input: `apiVersion: ` + APIVersionCurrent + `
+name: webapp
+kind: StandaloneWorkload
+`,
+			want: `apiVersion: ` + APIVersionCurrent + `
+name: webapp
+kind: StandaloneWorkload
+`,
+			wantChanged: false,
+		},
+		{
+			name: "unknown version returns an error",
+			input: `apiVersion: operator-builder.nukleros.io/v9
+name: webapp
+kind: StandaloneWorkload
+`,
+			wantErr: true,
+		},
+		{
+			name:    "non-mapping document returns an error",
+			input:   "- webapp\n",
+			wantErr: true,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var document yaml.Node
+			require.NoError(t, yaml.Unmarshal([]byte(tt.input), &document))
+
+			changed, err := Migrate(&document)
+			if tt.wantErr {
+				assert.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantChanged, changed)
+
+			var output strings.Builder
+
+			encoder := yaml.NewEncoder(&output)
+			encoder.SetIndent(2)
+
+			require.NoError(t, encoder.Encode(&document))
+			assert.Equal(t, tt.want, output.String())
+		})
+	}
+}
+
+func TestConvertDocument(t *testing.T) {
+	t.Parallel()
+
+	// renameField is a conversion which renames a field of the spec of a document
+	renameField := conversion{
+		to: APIVersionCurrent,
+		convert: func(object *yaml.Node) error {
+			for i := 0; i < len(object.Content)-1; i += 2 {
+				if object.Content[i].Value != "spec" {
+					continue
+				}
+
+				spec := object.Content[i+1]
+				for j := 0; j < len(spec.Content)-1; j += 2 {
+					if spec.Content[j].Value == "apiSpec" {
+						spec.Content[j].Value = "api"
+					}
+				}
+			}
+
+			return nil
+		},
+	}
+
+	for _, tt := range []struct {
+		name           string
+		chain          []conversion
+		want           string
+		wantStructural bool
+	}{
+		{
+			name:  "legacy conversion keeps the structure of the document",
+			chain: []conversion{conversions[APIVersionLegacy]},
+			want: `name: webapp
+spec:
+    apiSpec:
+        kind: WebApp
+`,
+		},
+		{
+			name:  "structural conversion changes the document",
+			chain: []conversion{renameField},
+			want: `name: webapp
+spec:
+    api:
+        kind: WebApp
+`,
+			wantStructural: true,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var document yaml.Node
+			require.NoError(t, yaml.Unmarshal([]byte("name: webapp\nspec:\n  apiSpec:\n    kind: WebApp\n"), &document))
+
+			structural, err := convertDocument(document.Content[0], tt.chain)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStructural, structural)
+
+			output, err := yaml.Marshal(&document)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(output))
+		})
+	}
+}
+
+func TestDecodeVersions(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		name       string
+		apiVersion string
+		input      string
+		wantErr    bool
+	}{
+		{
+			name:       "legacy config is decoded as the current version",
+			apiVersion: APIVersionLegacy,
+			input: `name: webapp
+kind: StandaloneWorkload
+spec:
+  api:
+    kind: WebApp
+`,
+		},
+		{
+			name:       "current config is decoded",
+			apiVersion: APIVersionCurrent,
+			input: `apiVersion: ` + APIVersionCurrent + `
+name: webapp
+kind: StandaloneWorkload
+spec:
+  api:
+    kind: WebApp
+`,
+		},
+		{
+			name:       "unknown version returns an error",
+			apiVersion: "operator-builder.nukleros.io/v9",
+			input: `apiVersion: operator-builder.nukleros.io/v9
+name: webapp
+kind: StandaloneWorkload
+`,
+			wantErr: true,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			decoder := yaml.NewDecoder(strings.NewReader(tt.input))
+			decoder.KnownFields(true)
+
+			workload, err := Decode(tt.apiVersion, WorkloadKindStandalone, decoder)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidAPIVersion)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			standalone, ok := workload.(*StandaloneWorkload)
+			require.True(t, ok)
+			assert.Equal(t, APIVersionCurrent, standalone.APIVersion)
+			assert.Equal(t, "WebApp", standalone.GetAPIKind())
+		})
+	}
+}
+
+func TestMigrateContent(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		name        string
+		input       string
+		want        string
+		wantChanged bool
+	}{
+		{
+			name: "legacy documents keep their formatting",
+			input: `# the collection
+name: platform
+kind: WorkloadCollection
+spec:
+  componentFiles:
+  - ingress.yaml  # not re-indented
+---
+apiVersion: ` + APIVersionCurrent + `  # already current
+name: ingress
+kind: ComponentWorkload
+`,
+			want: `# the collection
+apiVersion: ` + APIVersionCurrent + `
+name: platform
+kind: WorkloadCollection
+spec:
+  componentFiles:
+  - ingress.yaml  # not re-indented
+---
+apiVersion: ` + APIVersionCurrent + `  # already current
+name: ingress
+kind: ComponentWorkload
+`,
+			wantChanged: true,
+		},
+		{
+			name:  "flow style documents are encoded",
+			input: `{"name": "webapp", "kind": "StandaloneWorkload"}`,
+			want: `{"apiVersion": "` + APIVersionCurrent + `", "name": "webapp", "kind": "StandaloneWorkload"}
+`,
+			wantChanged: true,
+		},
+		{
+			name: "current documents are unchanged",
+			input: `apiVersion: ` + APIVersionCurrent + `
+name: webapp
+kind: StandaloneWorkload
+`,
+			want: `apiVersion: ` + APIVersionCurrent + `
+name: webapp
+kind: StandaloneWorkload
+`,
+			wantChanged: false,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, changed, err := MigrateContent([]byte(tt.input))
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantChanged, changed)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
@@ -0,0 +1,364 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package kinds
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nukleros/operator-builder/internal/workload/v1/markers"
+	"github.com/nukleros/operator-builder/internal/workload/v1/rbac"
+)
+
+var ErrInvalidWebhookMarker = errors.New("invalid webhook marker")
+
+// Webhook is the logic of the defaulting and validating webhooks of an API which is generated from
+// the webhook markers of the manifests of a workload.
+type Webhook struct {
+	Defaults    []*FieldDefault
+	Validations []*FieldValidation
+
+	// ClusterScoped is whether the API is cluster scoped.  The values of the unique fields of a
+	// namespaced API are only unique within the namespace of a custom resource.
+	ClusterScoped bool
+}
+
+// WebhookField is an API spec field which is defaulted or validated by a webhook.
+type WebhookField struct {
+	Path     string
+	Selector string
+	JSONPath []string
+	Type     markers.FieldType
+}
+
+// FieldDefault is the default of an API spec field which is set by the defaulting webhook.  The
+// default is either a set of entries which are merged into a map, or is computed from another
+// field when the field is not given.
+type FieldDefault struct {
+	WebhookField
+
+	Merge  map[string]string
+	From   string
+	Format string
+}
+
+// FieldValidation is the validation of an API spec field which is performed by the validating
+// webhook.  The field either references an existing object by name or has a value which is unique
+// among the custom resources of the API.
+type FieldValidation struct {
+	WebhookField
+
+	Reference *FieldReference
+	Unique    bool
+	Message   string
+}
+
+// FieldReference is the object which an API spec field references by name.
+type FieldReference struct {
+	APIVersion    string
+	Kind          string
+	ClusterScoped bool
+}
+
+// FieldPath returns the arguments of the field.NewPath which locates the field within a custom
+// resource, as it is given in the errors of a webhook.
+func (field *WebhookField) FieldPath() string {
+	quoted := make([]string, len(field.JSONPath))
+	for i, part := range field.JSONPath {
+		quoted[i] = fmt.Sprintf("%q", part)
+	}
+
+	return strings.Join(quoted, ", ")
+}
+
+// ZeroValue returns the value of the field when it is not given.
+func (field *WebhookField) ZeroValue() string {
+	if field.Type == markers.FieldInt {
+		return "0"
+	}
+
+	return `""`
+}
+
+// Group returns the API group of the referenced object.
+func (ref *FieldReference) Group() string {
+	if index := strings.LastIndex(ref.APIVersion, "/"); index >= 0 {
+		return ref.APIVersion[:index]
+	}
+
+	return ""
+}
+
+// IsEmpty returns whether the webhook has no logic to generate.
+func (webhook *Webhook) IsEmpty() bool {
+	return webhook == nil || (len(webhook.Defaults) == 0 && len(webhook.Validations) == 0)
+}
+
+// HasReferences returns whether any of the validations of the webhook look up another object.
+func (webhook *Webhook) HasReferences() bool {
+	if webhook == nil {
+		return false
+	}
+
+	for _, validation := range webhook.Validations {
+		if validation.Reference != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasLookups returns whether any of the validations of the webhook read objects from the cluster.
+func (webhook *Webhook) HasLookups() bool {
+	if webhook == nil {
+		return false
+	}
+
+	for _, validation := range webhook.Validations {
+		if validation.Reference != nil || validation.Unique {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasFormat returns whether any of the defaults of the webhook are formatted.
+func (webhook *Webhook) HasFormat() bool {
+	if webhook == nil {
+		return false
+	}
+
+	for _, fieldDefault := range webhook.Defaults {
+		if fieldDefault.Format != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// processWebhookMarkers resolves the webhook markers of the workload against its API spec fields,
+// given whether its API is cluster scoped.  It must be called once all of the API spec fields of
+// the workload are known.
+func (ws *WorkloadSpec) processWebhookMarkers(clusterScoped bool) error {
+	if len(ws.WebhookDefaultMarkers) == 0 && len(ws.WebhookValidateMarkers) == 0 {
+		return nil
+	}
+
+	leaves := map[string]*apiLeaf{}
+	for _, leaf := range ws.APISpecFields.leaves() {
+		leaves[leaf.path] = leaf
+	}
+
+	webhook := &Webhook{ClusterScoped: clusterScoped}
+
+	for _, marker := range ws.WebhookDefaultMarkers {
+		fieldDefault, err := ws.newFieldDefault(marker, leaves)
+		if err != nil {
+			return fmt.Errorf("%w; %s", err, marker)
+		}
+
+		webhook.Defaults = append(webhook.Defaults, fieldDefault)
+	}
+
+	for _, marker := range ws.WebhookValidateMarkers {
+		validation, err := newFieldValidation(marker, leaves)
+		if err != nil {
+			return fmt.Errorf("%w; %s", err, marker)
+		}
+
+		webhook.Validations = append(webhook.Validations, validation)
+	}
+
+	ws.Webhook = webhook
+
+	return nil
+}
+
+// newFieldDefault returns the default of an API spec field which is given by a webhook marker.
+func (ws *WorkloadSpec) newFieldDefault(
+	marker *markers.WebhookDefaultMarker,
+	leaves map[string]*apiLeaf,
+) (*FieldDefault, error) {
+	leaf, found := leaves[marker.GetField()]
+	if !found {
+		return nil, fmt.Errorf("%w: no api field %s", ErrInvalidWebhookMarker, marker.GetField())
+	}
+
+	fieldDefault := &FieldDefault{WebhookField: leaf.webhookField(), Format: marker.GetFormat()}
+
+	if marker.IsMerge() {
+		if leaf.field.Type != markers.FieldStringMap {
+			return nil, fmt.Errorf("%w: api field %s of type %s may not be merged",
+				ErrInvalidWebhookMarker, leaf.path, leaf.field.Type)
+		}
+
+		fieldDefault.Merge = ws.getStringMapDefault(leaf.path)
+		if len(fieldDefault.Merge) == 0 {
+			return nil, fmt.Errorf("%w: api field %s has no default entries to merge", ErrInvalidWebhookMarker, leaf.path)
+		}
+
+		return fieldDefault, nil
+	}
+
+	if leaf.field.Default != "" {
+		return nil, fmt.Errorf("%w: api field %s already has the default %s", ErrInvalidWebhookMarker, leaf.path, leaf.field.Default)
+	}
+
+	var fromType markers.FieldType
+
+	if parent, supported := supportedWebhookParents()[marker.GetFrom()]; supported {
+		fieldDefault.From, fromType = parent, markers.FieldString
+	} else {
+		from, found := leaves[marker.GetFrom()]
+		if !found {
+			return nil, fmt.Errorf("%w: no api field %s to default from", ErrInvalidWebhookMarker, marker.GetFrom())
+		}
+
+		fieldDefault.From, fromType = from.selector, from.field.Type
+	}
+
+	switch {
+	case leaf.field.Type != markers.FieldString && leaf.field.Type != markers.FieldInt:
+		return nil, fmt.Errorf("%w: api field %s of type %s may not be computed",
+			ErrInvalidWebhookMarker, leaf.path, leaf.field.Type)
+	case fieldDefault.Format != "" && leaf.field.Type != markers.FieldString:
+		return nil, fmt.Errorf("%w: api field %s of type %s may not be formatted",
+			ErrInvalidWebhookMarker, leaf.path, leaf.field.Type)
+	case fieldDefault.Format == "" && fromType != leaf.field.Type:
+		return nil, fmt.Errorf("%w: api field %s of type %s may not be defaulted from %s of type %s",
+			ErrInvalidWebhookMarker, leaf.path, leaf.field.Type, marker.GetFrom(), fromType)
+	}
+
+	// the field is set by the defaulting webhook before the custom resource is validated against
+	// its schema, so it is no longer required to be given
+	leaf.field.setComputedDefault(marker.GetFrom())
+
+	return fieldDefault, nil
+}
+
+// newFieldValidation returns the validation of an API spec field which is given by a webhook marker.
+func newFieldValidation(marker *markers.WebhookValidateMarker, leaves map[string]*apiLeaf) (*FieldValidation, error) {
+	leaf, found := leaves[marker.GetField()]
+	if !found {
+		return nil, fmt.Errorf("%w: no api field %s", ErrInvalidWebhookMarker, marker.GetField())
+	}
+
+	if marker.GetReference() == "" && !marker.IsUnique() {
+		return nil, fmt.Errorf("%w: one of reference or unique must be given for api field %s",
+			ErrInvalidWebhookMarker, leaf.path)
+	}
+
+	validation := &FieldValidation{
+		WebhookField: leaf.webhookField(),
+		Unique:       marker.IsUnique(),
+		Message:      marker.GetMessage(),
+	}
+
+	if marker.GetReference() != "" {
+		if leaf.field.Type != markers.FieldString {
+			return nil, fmt.Errorf("%w: api field %s of type %s may not reference an object by name",
+				ErrInvalidWebhookMarker, leaf.path, leaf.field.Type)
+		}
+
+		validation.Reference = &FieldReference{
+			APIVersion:    marker.GetAPIVersion(),
+			Kind:          marker.GetReference(),
+			ClusterScoped: marker.IsClusterScoped(),
+		}
+	}
+
+	if validation.Unique && leaf.field.Type != markers.FieldString && leaf.field.Type != markers.FieldInt {
+		return nil, fmt.Errorf("%w: api field %s of type %s may not be unique",
+			ErrInvalidWebhookMarker, leaf.path, leaf.field.Type)
+	}
+
+	return validation, nil
+}
+
+// getStringMapDefault returns the default entries of a stringMap API spec field, as they are
+// given by its field marker.
+func (ws *WorkloadSpec) getStringMapDefault(path string) map[string]string {
+	fieldMarkers := []markers.FieldMarkerProcessor{}
+
+	for _, marker := range ws.FieldMarkers {
+		fieldMarkers = append(fieldMarkers, marker)
+	}
+
+	for _, marker := range ws.CollectionFieldMarkers {
+		fieldMarkers = append(fieldMarkers, marker)
+	}
+
+	for _, marker := range fieldMarkers {
+		if marker.GetName() != path || marker.GetDefault() == nil {
+			continue
+		}
+
+		if entries, ok := convertDefaultSampleVal(marker.GetDefault(), markers.FieldStringMap).(map[string]string); ok {
+			return entries
+		}
+	}
+
+	return nil
+}
+
+// addWebhookRBACRules adds the rules which allow the validating webhook, which runs within the
+// controller manager, to read the objects which are referenced by the API spec fields.
+func (ws *WorkloadSpec) addWebhookRBACRules() {
+	if ws.Webhook == nil {
+		return
+	}
+
+	for _, validation := range ws.Webhook.Validations {
+		if validation.Reference == nil {
+			continue
+		}
+
+		source := fmt.Sprintf("%s:field=%s", markers.WebhookValidateMarkerPrefix, validation.Path)
+		rules := rbac.ForDependency(validation.Reference.Group(), validation.Reference.Kind)
+
+		if validation.Reference.ClusterScoped {
+			ws.addClusterRBACRules(source, rules)
+
+			continue
+		}
+
+		ws.addRBACRules(source, rules)
+	}
+}
+
+// supportedWebhookParents returns the metadata fields from which an API spec field may be
+// defaulted, along with their selectors within a custom resource.
+func supportedWebhookParents() map[string]string {
+	return map[string]string{
+		"metadata.name":      "Name",
+		"metadata.namespace": "Namespace",
+	}
+}
+
+// webhookField returns the API spec field, along with its path within a custom resource.
+func (leaf *apiLeaf) webhookField() WebhookField {
+	return WebhookField{
+		Path:     leaf.path,
+		Selector: leaf.selector,
+		JSONPath: append([]string{"spec"}, strings.Split(leaf.path, ".")...),
+		Type:     leaf.field.Type,
+	}
+}
+
+// setComputedDefault marks an API spec field, which has no default in the schema of the API, as
+// defaulted by the defaulting webhook.
+func (api *APIFields) setComputedDefault(from string) {
+	for i, marker := range api.Markers {
+		if marker == "+kubebuilder:validation:Required" {
+			api.Markers[i] = "+kubebuilder:validation:Optional"
+		}
+	}
+
+	api.Markers = append(api.Markers, fmt.Sprintf("(Default: computed from %s)", from))
+	api.Sample = strings.TrimSuffix(api.Sample, "  # required field")
+}
@@ -0,0 +1,248 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package kinds
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nukleros/operator-builder/internal/workload/v1/markers"
+	"github.com/nukleros/operator-builder/internal/workload/v1/rbac"
+)
+
+func newTestWebhookSpec(t *testing.T) *WorkloadSpec {
+	t.Helper()
+
+	labelsName, labelsDefault := "labels", "app=webapp;tier=web"
+
+	spec := &WorkloadSpec{
+		APISpecFields: &APIFields{},
+		RBACRules:     &rbac.Rules{},
+		FieldMarkers: []*markers.FieldMarker{
+			{Name: &labelsName, Type: markers.FieldStringMap, Default: labelsDefault},
+		},
+	}
+
+	require.NoError(t, spec.APISpecFields.AddField("web.secretName", markers.FieldString, nil, nil, false))
+	require.NoError(t, spec.APISpecFields.AddField("web.host", markers.FieldString, nil, nil, false))
+	require.NoError(t, spec.APISpecFields.AddField("web.replicas", markers.FieldInt, nil, 1, true))
+	require.NoError(t, spec.APISpecFields.AddField(
+		"labels", markers.FieldStringMap, nil, markers.SplitStringMapDefault(labelsDefault), true))
+	require.NoError(t, spec.APISpecFields.AddField("annotations", markers.FieldStringMap, nil, nil, false))
+
+	return spec
+}
+
+func TestWorkloadSpec_processWebhookMarkers(t *testing.T) {
+	t.Parallel()
+
+	str := func(value string) *string { return &value }
+	yes := true
+
+	tests := []struct {
+		name          string
+		defaults      []*markers.WebhookDefaultMarker
+		validates     []*markers.WebhookValidateMarker
+		clusterScoped bool
+		want          *Webhook
+		wantError     bool
+	}{
+		{
+			name: "no markers",
+		},
+		{
+			name: "validations",
+			validates: []*markers.WebhookValidateMarker{
+				{Field: str("web.secretName"), Reference: str("Secret")},
+				{Field: str("web.host"), Unique: &yes, Message: str("host is in use")},
+			},
+			want: &Webhook{
+				Validations: []*FieldValidation{
+					{
+						WebhookField: WebhookField{
+							Path:     "web.secretName",
+							Selector: "Spec.Web.SecretName",
+							JSONPath: []string{"spec", "web", "secretName"},
+							Type:     markers.FieldString,
+						},
+						Reference: &FieldReference{APIVersion: "v1", Kind: "Secret"},
+					},
+					{
+						WebhookField: WebhookField{
+							Path:     "web.host",
+							Selector: "Spec.Web.Host",
+							JSONPath: []string{"spec", "web", "host"},
+							Type:     markers.FieldString,
+						},
+						Unique:  true,
+						Message: "host is in use",
+					},
+				},
+			},
+		},
+		{
+			name:          "validations of a cluster scoped api",
+			validates:     []*markers.WebhookValidateMarker{{Field: str("web.host"), Unique: &yes}},
+			clusterScoped: true,
+			want: &Webhook{
+				Validations: []*FieldValidation{
+					{
+						WebhookField: WebhookField{
+							Path:     "web.host",
+							Selector: "Spec.Web.Host",
+							JSONPath: []string{"spec", "web", "host"},
+							Type:     markers.FieldString,
+						},
+						Unique: true,
+					},
+				},
+				ClusterScoped: true,
+			},
+		},
+		{
+			name: "defaults",
+			defaults: []*markers.WebhookDefaultMarker{
+				{Field: str("labels"), Merge: &yes},
+				{Field: str("web.host"), From: str("metadata.name"), Format: str("%s.acme.com")},
+				{Field: str("web.secretName"), From: str("web.host")},
+			},
+			want: &Webhook{
+				Defaults: []*FieldDefault{
+					{
+						WebhookField: WebhookField{
+							Path:     "labels",
+							Selector: "Spec.Labels",
+							JSONPath: []string{"spec", "labels"},
+							Type:     markers.FieldStringMap,
+						},
+						Merge: map[string]string{"app": "webapp", "tier": "web"},
+					},
+					{
+						WebhookField: WebhookField{
+							Path:     "web.host",
+							Selector: "Spec.Web.Host",
+							JSONPath: []string{"spec", "web", "host"},
+							Type:     markers.FieldString,
+						},
+						From:   "Name",
+						Format: "%s.acme.com",
+					},
+					{
+						WebhookField: WebhookField{
+							Path:     "web.secretName",
+							Selector: "Spec.Web.SecretName",
+							JSONPath: []string{"spec", "web", "secretName"},
+							Type:     markers.FieldString,
+						},
+						From: "Spec.Web.Host",
+					},
+				},
+			},
+		},
+		{
+			name:      "missing field",
+			validates: []*markers.WebhookValidateMarker{{Field: str("web.missing"), Unique: &yes}},
+			wantError: true,
+		},
+		{
+			name:      "validation without a reference or unique",
+			validates: []*markers.WebhookValidateMarker{{Field: str("web.host"), Message: str("host is invalid")}},
+			wantError: true,
+		},
+		{
+			name:      "reference of a non-string field",
+			validates: []*markers.WebhookValidateMarker{{Field: str("web.replicas"), Reference: str("Secret")}},
+			wantError: true,
+		},
+		{
+			name:      "merge of a non-map field",
+			defaults:  []*markers.WebhookDefaultMarker{{Field: str("web.host"), Merge: &yes}},
+			wantError: true,
+		},
+		{
+			name:      "merge without default entries",
+			defaults:  []*markers.WebhookDefaultMarker{{Field: str("annotations"), Merge: &yes}},
+			wantError: true,
+		},
+		{
+			name:      "computed default of a defaulted field",
+			defaults:  []*markers.WebhookDefaultMarker{{Field: str("web.replicas"), From: str("web.host")}},
+			wantError: true,
+		},
+		{
+			name:      "computed default of a different type",
+			defaults:  []*markers.WebhookDefaultMarker{{Field: str("web.host"), From: str("web.replicas")}},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			spec := newTestWebhookSpec(t)
+			spec.WebhookDefaultMarkers = tt.defaults
+			spec.WebhookValidateMarkers = tt.validates
+
+			err := spec.processWebhookMarkers(tt.clusterScoped)
+			if tt.wantError {
+				assert.ErrorIs(t, err, ErrInvalidWebhookMarker)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, spec.Webhook)
+		})
+	}
+}
+
+func TestWorkloadSpec_processWebhookMarkers_computedDefault(t *testing.T) {
+	t.Parallel()
+
+	field, from := "web.host", "metadata.name"
+
+	spec := newTestWebhookSpec(t)
+	spec.WebhookDefaultMarkers = []*markers.WebhookDefaultMarker{{Field: &field, From: &from}}
+
+	require.NoError(t, spec.processWebhookMarkers(false))
+
+	host := spec.APISpecFields.Children[0].Children[1]
+	assert.Equal(t, []string{"+kubebuilder:validation:Optional", "(Default: computed from metadata.name)"}, host.Markers)
+	assert.Equal(t, `host: ""`, host.Sample)
+}
+
+func TestWorkloadSpec_addWebhookRBACRules(t *testing.T) {
+	t.Parallel()
+
+	spec := &WorkloadSpec{
+		RBACRules: &rbac.Rules{},
+		Webhook: &Webhook{
+			Validations: []*FieldValidation{
+				{WebhookField: WebhookField{Path: "web.secretName"}, Reference: &FieldReference{APIVersion: "v1", Kind: "Secret"}},
+				{WebhookField: WebhookField{Path: "web.issuer"}, Reference: &FieldReference{APIVersion: "cert-manager.io/v1", Kind: "Issuer"}},
+				{WebhookField: WebhookField{Path: "web.host"}, Unique: true},
+				{
+					WebhookField: WebhookField{Path: "web.clusterIssuer"},
+					Reference:    &FieldReference{APIVersion: "cert-manager.io/v1", Kind: "ClusterIssuer", ClusterScoped: true},
+				},
+			},
+		},
+	}
+
+	spec.addWebhookRBACRules()
+
+	require.Len(t, spec.RBACGrants, 3)
+	assert.Equal(t, "+operator-builder:webhook:validate:field=web.secretName", spec.RBACGrants[0].Source)
+	assert.False(t, spec.RBACGrants[0].ClusterScoped)
+	assert.True(t, spec.RBACGrants[2].ClusterScoped)
+	assert.Equal(t, &rbac.Rules{
+		{Group: "core", Resource: "secrets", Verbs: []string{"get", "list", "watch"}},
+		{Group: "cert-manager.io", Resource: "issuers", Verbs: []string{"get", "list", "watch"}},
+		{Group: "cert-manager.io", Resource: "clusterissuers", Verbs: []string{"get", "list", "watch"}},
+	}, spec.RBACRules)
+}
@@ -51,7 +51,14 @@ type WorkloadBuilder interface {
 	GetAPIGroup() string
 	GetAPIVersion() string
 	GetAPIKind() string
+	GetAPIConversions() []*APIConversion
+	GetWebhook() *Webhook
 	GetDependencies() []*ComponentWorkload
+	GetExternalDependencies() []*ExternalDependency
+	GetControllerOptions() *ControllerOptions
+	GetPhases() []*Phase
+	GetCommonMetadata() *CommonMetadata
+	GetRBACOptions() *RBACOptions
 	GetCollection() *WorkloadCollection
 	GetComponents() []*ComponentWorkload
 	GetAPISpecFields() *APIFields
@@ -78,31 +85,48 @@ var (
 
 // WorkloadAPISpec contains fields shared by all workload specs.
 type WorkloadAPISpec struct {
-	Domain        string `json:"domain" yaml:"domain"`
-	Group         string `json:"group" yaml:"group"`
-	Version       string `json:"version" yaml:"version"`
-	Kind          string `json:"kind" yaml:"kind"`
-	ClusterScoped bool   `json:"clusterScoped" yaml:"clusterScoped"`
+	Domain        string `json:"domain" yaml:"domain" jsonschema_description:"The domain of the API, required for standalone workloads and collections."`
+	Group         string `json:"group" yaml:"group" jsonschema:"required" jsonschema_description:"The group of the API."`
+	Version       string `json:"version" yaml:"version" jsonschema:"required" jsonschema_description:"The version of the API."`
+	Kind          string `json:"kind" yaml:"kind" jsonschema:"required" jsonschema_description:"The kind of the API."`
+	ClusterScoped bool   `json:"clusterScoped" yaml:"clusterScoped" jsonschema_description:"Whether the API is cluster scoped."`
+
+	Conversion []*APIConversion `json:"conversion,omitempty" yaml:"conversion,omitempty" validate:"omitempty" jsonschema_description:"The previous versions of the API which are converted to and from this version, which is the hub version, by the conversion webhook."`
 }
 
 // WorkloadShared contains fields shared by all workloads.
 type WorkloadShared struct {
-	Name        string       `json:"name"  yaml:"name" validate:"required"`
-	Kind        WorkloadKind `json:"kind"  yaml:"kind" validate:"required"`
+	APIVersion  string       `json:"apiVersion,omitempty" yaml:"apiVersion,omitempty" jsonschema_description:"The version of the workload config format.  Workload configs without a version are converted from the legacy format."`
+	Name        string       `json:"name"  yaml:"name" validate:"required" jsonschema:"required" jsonschema_description:"The unique name of the workload."`
+	Kind        WorkloadKind `json:"kind"  yaml:"kind" validate:"required" jsonschema:"required"`
 	PackageName string       `json:",omitempty" yaml:",omitempty" validate:"omitempty"`
 }
 
 // WorkloadSpec contains information required to generate source code.
 type WorkloadSpec struct {
-	Resources []string `json:"resources" yaml:"resources"`
+	Resources  []string           `json:"resources" yaml:"resources" jsonschema_description:"The manifest files, glob patterns or kustomization directories for the workload, relative to the workload config."`
+	Controller *ControllerOptions `json:"controller,omitempty" yaml:"controller,omitempty" validate:"omitempty" jsonschema_description:"The options which tune the controller of the workload."`
+	Phases     []*Phase           `json:"phases,omitempty" yaml:"phases,omitempty" validate:"omitempty" jsonschema_description:"The custom phases of the controller of the workload, which are executed along with the generated phases."`
+
+	CommonLabels           map[string]string `json:"commonLabels,omitempty" yaml:"commonLabels,omitempty" validate:"omitempty" jsonschema_description:"The labels which are applied to all child resources and to their label selectors."`
+	CommonAnnotations      map[string]string `json:"commonAnnotations,omitempty" yaml:"commonAnnotations,omitempty" validate:"omitempty" jsonschema_description:"The annotations which are applied to all child resources."`
+	NamePrefix             string            `json:"namePrefix,omitempty" yaml:"namePrefix,omitempty" validate:"omitempty" jsonschema_description:"The prefix which is prepended to the names of all child resources and to the references to them."`
+	CommonLabelsField      string            `json:"commonLabelsField,omitempty" yaml:"commonLabelsField,omitempty" validate:"omitempty" jsonschema_description:"The name of a stringMap API spec field which holds extra labels that are applied to all child resources."`
+	CommonAnnotationsField string            `json:"commonAnnotationsField,omitempty" yaml:"commonAnnotationsField,omitempty" validate:"omitempty" jsonschema_description:"The name of a stringMap API spec field which holds extra annotations that are applied to all child resources."`
+	RBAC                   *RBACOptions      `json:"rbac,omitempty" yaml:"rbac,omitempty" validate:"omitempty" jsonschema_description:"The options for the RBAC of the controller of the workload."`
 
 	Manifests              *manifests.Manifests             `json:",omitempty" yaml:",omitempty" validate:"omitempty"`
 	FieldMarkers           []*markers.FieldMarker           `json:",omitempty" yaml:",omitempty" validate:"omitempty"`
 	CollectionFieldMarkers []*markers.CollectionFieldMarker `json:",omitempty" yaml:",omitempty" validate:"omitempty"`
+	WebhookDefaultMarkers  []*markers.WebhookDefaultMarker  `json:",omitempty" yaml:",omitempty" validate:"omitempty"`
+	WebhookValidateMarkers []*markers.WebhookValidateMarker `json:",omitempty" yaml:",omitempty" validate:"omitempty"`
+	Webhook                *Webhook                         `json:",omitempty" yaml:",omitempty" validate:"omitempty"`
 	ForCollection          bool                             `json:",omitempty" yaml:",omitempty" validate:"omitempty"`
 	Collection             *WorkloadCollection              `json:",omitempty" yaml:",omitempty" validate:"omitempty"`
+	ParentCollection       *WorkloadCollection              `json:",omitempty" yaml:",omitempty" validate:"omitempty"`
 	APISpecFields          *APIFields                       `json:",omitempty" yaml:",omitempty" validate:"omitempty"`
 	RBACRules              *rbac.Rules                      `json:",omitempty" yaml:",omitempty" validate:"omitempty"`
+	RBACGrants             []*rbac.Grant                    `json:",omitempty" yaml:",omitempty" validate:"omitempty"`
 }
 
 // NewSampleAPISpec returns a new instance of a sample api specification.
@@ -159,8 +183,10 @@ func (ws *WorkloadSpec) init() {
 }
 
 func (ws *WorkloadSpec) appendCollectionRef() {
+	collection := ws.getCollectionRef()
+
 	// ensure api spec and collection is already set
-	if ws.APISpecFields == nil || ws.Collection == nil {
+	if ws.APISpecFields == nil || collection == nil {
 		return
 	}
 
@@ -171,7 +197,7 @@ func (ws *WorkloadSpec) appendCollectionRef() {
 
 	var sampleNamespace string
 
-	if ws.Collection.IsClusterScoped() {
+	if collection.IsClusterScoped() {
 		sampleNamespace = ""
 	} else {
 		sampleNamespace = "default"
@@ -198,7 +224,7 @@ func (ws *WorkloadSpec) appendCollectionRef() {
 				Name:   "Name",
 				Type:   markers.FieldString,
 				Tags:   fmt.Sprintf("`json:%q`", "name"),
-				Sample: fmt.Sprintf("#name: %q", strings.ToLower(ws.Collection.GetAPIKind())+"-sample"),
+				Sample: fmt.Sprintf("#name: %q", strings.ToLower(collection.GetAPIKind())+"-sample"),
 				Markers: []string{
 					"+kubebuilder:validation:Required",
 					"Required if specifying collection.  The name of the collection",
@@ -229,6 +255,10 @@ func processManifestError(err error, manifest *manifests.Manifest) error {
 func (ws *WorkloadSpec) processManifests(markerTypes ...markers.MarkerType) error {
 	ws.init()
 
+	if err := ws.getCommonMetadata().appendFields(ws.APISpecFields); err != nil {
+		return err
+	}
+
 	// track the unique names so that we can handle when we have an overlap
 	uniqueNames := map[string]bool{}
 
@@ -245,9 +275,14 @@ func (ws *WorkloadSpec) processManifests(markerTypes ...markers.MarkerType) erro
 			markerByVar[fm.GetSourceCodeVariable()] = fm
 		}
 
+		extracted, err := manifestFile.ExtractManifests()
+		if err != nil {
+			return processManifestError(err, manifestFile)
+		}
+
 		var childResources []manifests.ChildResource
 
-		for _, manifest := range manifestFile.ExtractManifests() {
+		for _, manifest := range extracted {
 			// decode manifest into unstructured data type
 			var manifestObject unstructured.Unstructured
 
@@ -263,7 +298,7 @@ func (ws *WorkloadSpec) processManifests(markerTypes ...markers.MarkerType) erro
 			}
 
 			// create the new child resource and validate its unique name
-			childResource, err := manifests.NewChildResource(manifestObject, markerByVar)
+			childResource, err := manifests.NewChildResource(manifestObject, markerByVar, ws.getRBACOptions().Minimal)
 			if err != nil {
 				return processManifestError(err, manifestFile)
 			}
@@ -280,6 +315,17 @@ func (ws *WorkloadSpec) processManifests(markerTypes ...markers.MarkerType) erro
 
 			uniqueNames[childResource.UniqueName] = true
 
+			// the controller may only manage cluster scoped child resources with a cluster role
+			if ws.getRBACOptions().IsNamespaced() && isClusterScopedKind(manifestObject.GetKind()) {
+				return processManifestError(
+					fmt.Errorf(
+						"%w; cluster scoped resource kind [%s] with name [%s] requires the %s rbac scope",
+						ErrInvalidRBACOptions, manifestObject.GetKind(), manifestObject.GetName(), RBACScopeCluster,
+					),
+					manifestFile,
+				)
+			}
+
 			// generate the object source code
 			resourceDefinition, err := code.Generate([]byte(manifest), "resourceObj")
 			if err != nil {
@@ -368,6 +414,14 @@ func (ws *WorkloadSpec) processMarkerResults(markerResults []*inspect.YAMLResult
 		case *markers.CollectionFieldMarker:
 			marker = t
 			ws.CollectionFieldMarkers = append(ws.CollectionFieldMarkers, t)
+		case *markers.WebhookDefaultMarker:
+			ws.WebhookDefaultMarkers = append(ws.WebhookDefaultMarkers, t)
+
+			continue
+		case *markers.WebhookValidateMarker:
+			ws.WebhookValidateMarkers = append(ws.WebhookValidateMarkers, t)
+
+			continue
 		default:
 			continue
 		}
@@ -479,11 +533,137 @@ func (ws *WorkloadSpec) setSourceFileNames() {
 	}
 }
 
+// validate returns an error if any of the controller options, custom phases, common metadata or
+// rbac options of the workload are invalid.
+func (ws *WorkloadSpec) validate(clusterScoped bool) error {
+	if err := ws.Controller.validate(); err != nil {
+		return err
+	}
+
+	if err := validatePhases(ws.Phases); err != nil {
+		return err
+	}
+
+	if err := ws.getCommonMetadata().validate(); err != nil {
+		return err
+	}
+
+	return ws.RBAC.validate(clusterScoped)
+}
+
+// getControllerOptions returns the controller options of the workload, which are empty if none are
+// set so that the defaults are used.
+func (ws *WorkloadSpec) getControllerOptions() *ControllerOptions {
+	if ws.Controller == nil {
+		return &ControllerOptions{}
+	}
+
+	return ws.Controller
+}
+
+// getRBACOptions returns the rbac options of the workload, which are empty if none are set so
+// that the defaults are used.
+func (ws *WorkloadSpec) getRBACOptions() *RBACOptions {
+	if ws.RBAC == nil {
+		return &RBACOptions{}
+	}
+
+	return ws.RBAC
+}
+
+// getCommonMetadata returns the metadata which is applied to all of the child resources of the
+// workload.
+func (ws *WorkloadSpec) getCommonMetadata() *CommonMetadata {
+	return &CommonMetadata{
+		Labels:           ws.CommonLabels,
+		Annotations:      ws.CommonAnnotations,
+		NamePrefix:       ws.NamePrefix,
+		LabelsField:      ws.CommonLabelsField,
+		AnnotationsField: ws.CommonAnnotationsField,
+	}
+}
+
+// addRBACRules adds a set of rules to the RBAC rules of the workload, recording the source for
+// which they are granted.
+func (ws *WorkloadSpec) addRBACRules(source string, rules *rbac.Rules) {
+	ws.RBACRules.Add(rules)
+	ws.RBACGrants = append(ws.RBACGrants, &rbac.Grant{Source: source, Rules: rules})
+}
+
+// addClusterRBACRules adds a set of rules for cluster scoped objects to the RBAC rules of the
+// workload.  The rules are granted by a cluster role even when the RBAC of the workload is
+// namespace scoped.
+func (ws *WorkloadSpec) addClusterRBACRules(source string, rules *rbac.Rules) {
+	ws.RBACRules.Add(rules)
+	ws.RBACGrants = append(ws.RBACGrants, &rbac.Grant{Source: source, Rules: rules, ClusterScoped: true})
+}
+
+// setRBACOptions adds the extra rules of the workload to its RBAC rules and records the rules of
+// its child resources.  When the RBAC of the workload is namespace scoped, the resource rules of
+// the workload and of its child resources are scoped to the namespace of the controller manager,
+// other than the rules for cluster scoped objects which the workload reads.
+func (ws *WorkloadSpec) setRBACOptions() {
+	options := ws.getRBACOptions()
+
+	for i, rule := range options.ExtraRules {
+		ws.addRBACRules(fmt.Sprintf("spec.rbac.extraRules[%d]", i), rule.toRules())
+	}
+
+	ws.addWebhookRBACRules()
+
+	if ws.Manifests != nil {
+		for _, manifest := range *ws.Manifests {
+			for i := range manifest.ChildResources {
+				child := &manifest.ChildResources[i]
+				if child.RBAC == nil {
+					continue
+				}
+
+				ws.RBACGrants = append(ws.RBACGrants, &rbac.Grant{
+					Manifest: manifest.Filename,
+					Source:   fmt.Sprintf("%s/%s", child.Kind, child.Name),
+					Rules:    child.RBAC,
+				})
+			}
+		}
+	}
+
+	if !options.IsNamespaced() {
+		return
+	}
+
+	clusterScoped := &rbac.Rules{}
+
+	for _, grant := range ws.RBACGrants {
+		if grant.ClusterScoped {
+			clusterScoped.Add(grant.Rules)
+		}
+	}
+
+	ws.RBACRules.SetNamespace(rbacNamespace, clusterScoped)
+
+	for _, grant := range ws.RBACGrants {
+		if !grant.ClusterScoped {
+			grant.Rules.SetNamespace(rbacNamespace, nil)
+		}
+	}
+}
+
 // needsCollectionRef determines if the workload spec needs a collection ref as
 // part of its spec for determining which collection to use.  In this case, we
 // want to check and see if a collection is set, but also ensure that this is not
-// a workload spec that belongs to a collection, as nested collections are
-// unsupported.
+// a workload spec that belongs to a top-level collection, which has no parent.
 func (ws *WorkloadSpec) needsCollectionRef() bool {
-	return ws.Collection != nil && !ws.ForCollection
+	return ws.getCollectionRef() != nil
+}
+
+// getCollectionRef returns the collection which the workload spec references.  A
+// collection references its parent collection, if it is nested, while a component
+// references the collection which it belongs to.
+func (ws *WorkloadSpec) getCollectionRef() *WorkloadCollection {
+	if ws.ForCollection {
+		return ws.ParentCollection
+	}
+
+	return ws.Collection
 }
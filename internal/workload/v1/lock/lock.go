@@ -0,0 +1,208 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package lock
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
+
+	"github.com/nukleros/operator-builder/internal/workload/v1/config"
+)
+
+var ErrMissingLock = errors.New("no lock file found")
+
+// Path is the path of the lock file, which contains a hash of each of the inputs from which the
+// code of a project was last generated.
+var Path = filepath.Join(".operator-builder", "inputs.lock")
+
+const hashPrefix = "sha256:"
+
+// Lock contains the hashes of the workload configs and manifests, along with the variables which
+// are substituted within them, from which the code of a project was generated.
+type Lock struct {
+	WorkloadConfig string   `yaml:"workloadConfig"`
+	Variables      string   `yaml:"variables,omitempty"`
+	Inputs         []*Input `yaml:"inputs"`
+}
+
+// Input is a single file from which the code of a project was generated.
+type Input struct {
+	Path string `yaml:"path"`
+	Hash string `yaml:"hash"`
+}
+
+// New returns the lock for a workload config which has been processed by the `create api`
+// subcommand, so that the manifests of each of its workloads have been loaded.
+func New(processor *config.Processor) (*Lock, error) {
+	paths := map[string]bool{}
+
+	for _, topLevelProcessor := range processor.GetTopLevelProcessors() {
+		for _, workloadProcessor := range topLevelProcessor.GetProcessors() {
+			paths[workloadProcessor.Path] = true
+
+			for _, manifest := range *workloadProcessor.Workload.GetManifests() {
+				if !manifest.Kustomization {
+					paths[manifest.Filename] = true
+
+					continue
+				}
+
+				// the manifests of a kustomization are built from each of the files within it
+				if err := filepath.WalkDir(manifest.Filename, func(path string, entry fs.DirEntry, err error) error {
+					if err != nil {
+						return err
+					}
+
+					if !entry.IsDir() {
+						paths[path] = true
+					}
+
+					return nil
+				}); err != nil {
+					return nil, fmt.Errorf("%w; unable to read kustomization %s", err, manifest.Filename)
+				}
+			}
+		}
+	}
+
+	lock := &Lock{
+		WorkloadConfig: filepath.ToSlash(processor.Path),
+		Variables:      hashVariables(processor.Variables),
+	}
+
+	for path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("%w; unable to read input %s", err, path)
+		}
+
+		lock.Inputs = append(lock.Inputs, &Input{Path: filepath.ToSlash(path), Hash: hash(content)})
+	}
+
+	sort.Slice(lock.Inputs, func(i, j int) bool {
+		return lock.Inputs[i].Path < lock.Inputs[j].Path
+	})
+
+	return lock, nil
+}
+
+// Read reads the lock file of a project.
+func Read(fs afero.Fs) (*Lock, error) {
+	content, err := afero.ReadFile(fs, Path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%w at %s", ErrMissingLock, Path)
+		}
+
+		return nil, fmt.Errorf("%w; unable to read lock file %s", err, Path)
+	}
+
+	lock := &Lock{}
+	if err := yaml.Unmarshal(content, lock); err != nil {
+		return nil, fmt.Errorf("%w; unable to unmarshal lock file %s", err, Path)
+	}
+
+	return lock, nil
+}
+
+// Write writes the lock file of a project.
+func (lock *Lock) Write(fs afero.Fs) error {
+	var buffer bytes.Buffer
+
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(lock); err != nil {
+		return fmt.Errorf("%w; unable to marshal lock file", err)
+	}
+
+	if err := fs.MkdirAll(filepath.Dir(Path), 0o755); err != nil {
+		return fmt.Errorf("%w; unable to create directory for lock file %s", err, Path)
+	}
+
+	if err := afero.WriteFile(fs, Path, buffer.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("%w; unable to write lock file %s", err, Path)
+	}
+
+	return nil
+}
+
+// Changes returns a description of each of the inputs which have changed since the lock was
+// recorded, when compared with the current lock.
+func (lock *Lock) Changes(current *Lock) []string {
+	changes := []string{}
+
+	if lock.WorkloadConfig != current.WorkloadConfig {
+		changes = append(changes, fmt.Sprintf("workload config changed from %s to %s", lock.WorkloadConfig, current.WorkloadConfig))
+	}
+
+	if lock.Variables != current.Variables {
+		changes = append(changes, "config variables changed")
+	}
+
+	recorded := map[string]string{}
+	for _, input := range lock.Inputs {
+		recorded[input.Path] = input.Hash
+	}
+
+	for _, input := range current.Inputs {
+		hash, found := recorded[input.Path]
+
+		switch {
+		case !found:
+			changes = append(changes, fmt.Sprintf("%s was added", input.Path))
+		case hash != input.Hash:
+			changes = append(changes, fmt.Sprintf("%s was modified", input.Path))
+		}
+
+		delete(recorded, input.Path)
+	}
+
+	removed := make([]string, 0, len(recorded))
+	for path := range recorded {
+		removed = append(removed, path)
+	}
+
+	sort.Strings(removed)
+
+	for _, path := range removed {
+		changes = append(changes, fmt.Sprintf("%s was removed", path))
+	}
+
+	return changes
+}
+
+func hash(content []byte) string {
+	sum := sha256.Sum256(content)
+
+	return hashPrefix + hex.EncodeToString(sum[:])
+}
+
+// hashVariables returns the hash of the variables which are substituted within a workload config,
+// or an empty string if there are none.
+func hashVariables(variables config.Variables) string {
+	if len(variables) == 0 {
+		return ""
+	}
+
+	lines := make([]string, 0, len(variables))
+	for key, value := range variables {
+		lines = append(lines, fmt.Sprintf("%s=%s", key, value))
+	}
+
+	sort.Strings(lines)
+
+	return hash([]byte(strings.Join(lines, "\n")))
+}
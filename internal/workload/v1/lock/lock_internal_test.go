@@ -0,0 +1,102 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package lock
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nukleros/operator-builder/internal/workload/v1/config"
+)
+
+func NewTestLock() *Lock {
+	return &Lock{
+		WorkloadConfig: "workload.yaml",
+		Inputs: []*Input{
+			{Path: "app.yaml", Hash: hash([]byte("app"))},
+			{Path: "workload.yaml", Hash: hash([]byte("workload"))},
+		},
+	}
+}
+
+func TestLock_Changes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		current func(*Lock)
+		want    []string
+	}{
+		{
+			name:    "unchanged inputs",
+			current: func(*Lock) {},
+			want:    []string{},
+		},
+		{
+			name: "modified input",
+			current: func(lock *Lock) {
+				lock.Inputs[0].Hash = hash([]byte("changed"))
+			},
+			want: []string{"app.yaml was modified"},
+		},
+		{
+			name: "added and removed inputs",
+			current: func(lock *Lock) {
+				lock.Inputs[0].Path = "service.yaml"
+			},
+			want: []string{"service.yaml was added", "app.yaml was removed"},
+		},
+		{
+			name: "changed variables",
+			current: func(lock *Lock) {
+				lock.Variables = hashVariables(config.Variables{"replicas": "2"})
+			},
+			want: []string{"config variables changed"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			current := NewTestLock()
+			tt.current(current)
+
+			assert.Equal(t, tt.want, NewTestLock().Changes(current))
+		})
+	}
+}
+
+func TestLock_Write(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	_, err := Read(fs)
+	require.ErrorIs(t, err, ErrMissingLock)
+
+	require.NoError(t, NewTestLock().Write(fs))
+
+	lock, err := Read(fs)
+	require.NoError(t, err)
+	assert.Equal(t, NewTestLock(), lock)
+}
+
+func Test_hashVariables(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, hashVariables(nil))
+	assert.Equal(t,
+		hashVariables(config.Variables{"a": "1", "b": "2"}),
+		hashVariables(config.Variables{"b": "2", "a": "1"}),
+	)
+	assert.NotEqual(t,
+		hashVariables(config.Variables{"a": "1"}),
+		hashVariables(config.Variables{"a": "2"}),
+	)
+}
@@ -43,9 +43,19 @@ type ChildResource struct {
 // NewChildResource returns a representation of a ChildResource object given an unstructured
 // Kubernetes object.  markerByVar maps Go source-code variable expressions to their
 // FieldMarker definitions so that RBAC generation can resolve types and defaults for
-// dynamically-valued Role/ClusterRole fields.
-func NewChildResource(object unstructured.Unstructured, markerByVar map[string]*markers.FieldMarker) (*ChildResource, error) {
-	rbacRules, err := rbac.ForResource(&object, markerByVar)
+// dynamically-valued Role/ClusterRole fields.  When minimal is set, the RBAC for the resource
+// itself only includes the verbs which the controller uses.
+func NewChildResource(
+	object unstructured.Unstructured,
+	markerByVar map[string]*markers.FieldMarker,
+	minimal bool,
+) (*ChildResource, error) {
+	forResource := rbac.ForResource
+	if minimal {
+		forResource = rbac.ForMinimalResource
+	}
+
+	rbacRules, err := forResource(&object, markerByVar)
 	if err != nil {
 		return nil, fmt.Errorf(
 			"%w with kind [%s] and name [%s]",
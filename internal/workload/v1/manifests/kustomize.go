@@ -0,0 +1,57 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package manifests
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"sigs.k8s.io/kustomize/api/konfig"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+)
+
+var ErrBuildKustomization = errors.New("error building kustomization")
+
+// isKustomization determines if a path is a directory which contains a kustomization
+// file, as recognized by the kustomize api.
+func isKustomization(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || !info.IsDir() {
+		return false
+	}
+
+	for _, fileName := range konfig.RecognizedKustomizationFileNames() {
+		if _, err := os.Stat(filepath.Join(path, fileName)); err == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// buildKustomization builds a kustomization directory in-process and returns the rendered
+// documents as a multi-document YAML stream.
+//
+// NOTE: the rendered resources are serialized directly from their yaml nodes rather than with
+// the kustomize AsYaml method.  AsYaml round trips each resource through JSON, which drops
+// the comments from the base files and therefore any markers that are defined within them.
+func buildKustomization(path string) ([]byte, error) {
+	kustomizer := krusty.MakeKustomizer(krusty.MakeDefaultOptions())
+
+	resourceMap, err := kustomizer.Run(filesys.MakeFsOnDisk(), path)
+	if err != nil {
+		return nil, fmt.Errorf("%w; %s at path %s", err, ErrBuildKustomization.Error(), path)
+	}
+
+	content, err := kio.StringAll(resourceMap.ToRNodeSlice())
+	if err != nil {
+		return nil, fmt.Errorf("%w; %s at path %s", err, ErrBuildKustomization.Error(), path)
+	}
+
+	return []byte(content), nil
+}
@@ -0,0 +1,151 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package manifests
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const (
+	testKustomizeBaseDeployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: webapp
+spec:
+  replicas: 2 # +operator-builder:field:name=webapp.replicas,default=2,type=int
+  template:
+    spec:
+      containers:
+        - name: webapp
+          # +operator-builder:field:name=webapp.image,type=string
+          image: nginx:1.0
+`
+	testKustomizeBase = `resources:
+  - deployment.yaml
+`
+	testKustomizeOverlay = `resources:
+  - ../../base
+namePrefix: prod-
+`
+)
+
+func writeTestKustomization(t *testing.T) (base, overlay string) {
+	t.Helper()
+
+	root := t.TempDir()
+	base = filepath.Join(root, "base")
+	overlay = filepath.Join(root, "overlays", "prod")
+
+	for path, content := range map[string]string{
+		filepath.Join(base, "deployment.yaml"):       testKustomizeBaseDeployment,
+		filepath.Join(base, "kustomization.yaml"):    testKustomizeBase,
+		filepath.Join(overlay, "kustomization.yaml"): testKustomizeOverlay,
+	} {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return base, overlay
+}
+
+func Test_isKustomization(t *testing.T) {
+	t.Parallel()
+
+	base, overlay := writeTestKustomization(t)
+
+	tests := []struct {
+		name string
+		path string
+		want bool
+	}{
+		{
+			name: "ensure a base directory is a kustomization",
+			path: base,
+			want: true,
+		},
+		{
+			name: "ensure an overlay directory is a kustomization",
+			path: overlay,
+			want: true,
+		},
+		{
+			name: "ensure a directory without a kustomization file is not a kustomization",
+			path: filepath.Dir(overlay),
+			want: false,
+		},
+		{
+			name: "ensure a regular file is not a kustomization",
+			path: filepath.Join(base, "deployment.yaml"),
+			want: false,
+		},
+		{
+			name: "ensure a missing path is not a kustomization",
+			path: filepath.Join(base, "missing"),
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := isKustomization(tt.path); got != tt.want {
+				t.Errorf("isKustomization() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestManifest_LoadContent_Kustomization(t *testing.T) {
+	t.Parallel()
+
+	_, overlay := writeTestKustomization(t)
+
+	expanded, err := ExpandManifests(filepath.Dir(filepath.Dir(overlay)), []string{"overlays/prod"})
+	if err != nil {
+		t.Fatalf("ExpandManifests() error = %v", err)
+	}
+
+	if len(*expanded) != 1 {
+		t.Fatalf("ExpandManifests() returned %d manifests, want 1", len(*expanded))
+	}
+
+	manifest := (*expanded)[0]
+	if !manifest.Kustomization {
+		t.Fatalf("ExpandManifests() did not detect kustomization at %s", manifest.Filename)
+	}
+
+	if err := manifest.LoadContent(false); err != nil {
+		t.Fatalf("LoadContent() error = %v", err)
+	}
+
+	content := string(manifest.Content)
+
+	for _, want := range []string{
+		"name: prod-webapp",
+		"# +operator-builder:field:name=webapp.replicas,default=2,type=int",
+		"# +operator-builder:field:name=webapp.image,type=string",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("LoadContent() content missing %q; got:\n%s", want, content)
+		}
+	}
+
+	extracted, err := manifest.ExtractManifests()
+	if err != nil {
+		t.Fatalf("ExtractManifests() error = %v", err)
+	}
+
+	if len(extracted) != 1 {
+		t.Errorf("ExtractManifests() returned %d manifests, want 1", len(extracted))
+	}
+}
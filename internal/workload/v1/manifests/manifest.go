@@ -4,12 +4,18 @@
 package manifests
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"gopkg.in/yaml.v3"
+	"sigs.k8s.io/kustomize/api/konfig"
+
 	"github.com/nukleros/operator-builder/internal/utils"
 	"github.com/nukleros/operator-builder/internal/workload/v1/markers"
 )
@@ -23,6 +29,7 @@ type Manifest struct {
 	SourceFilename           string          `json:",omitempty" yaml:",omitempty" validate:"omitempty"`
 	ChildResources           []ChildResource `json:",omitempty" yaml:",omitempty" validate:"omitempty"`
 	PreferredSourceFileNames []string        `json:",omitempty" yaml:",omitempty" validate:"omitempty"`
+	Kustomization            bool            `json:",omitempty" yaml:",omitempty" validate:"omitempty"`
 }
 
 // Manifests represents a collection of manifests.
@@ -45,7 +52,12 @@ func ExpandManifests(workloadPath string, manifestPaths []string) (*Manifests, e
 				return &Manifests{}, fmt.Errorf("unable to determine relative file path, %w", err)
 			}
 
-			manifest := &Manifest{Filename: files[f], PreferredSourceFileNames: getFileNames(rf)}
+			manifest := &Manifest{
+				Filename:                 files[f],
+				PreferredSourceFileNames: getFileNames(rf),
+				Kustomization:            isKustomization(files[f]),
+			}
+
 			manifests = append(manifests, manifest)
 		}
 	}
@@ -53,37 +65,95 @@ func ExpandManifests(workloadPath string, manifestPaths []string) (*Manifests, e
 	return &manifests, nil
 }
 
-// ExtractManifests extracts the manifests as YAML strings from a manifest with
-// existing manifest content.
-func (manifest *Manifest) ExtractManifests() []string {
-	var manifests []string
+// FindManifests returns the paths of the manifest files within a directory.  Subdirectories are
+// only searched when recursive is requested.  Directories which contain a kustomization are
+// returned as a single manifest rather than as their individual files.  Hidden files and
+// directories are ignored.
+func FindManifests(dir string, recursive bool) ([]string, error) {
+	if isKustomization(dir) {
+		return []string{dir}, nil
+	}
+
+	var found []string
+
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
 
-	lines := strings.Split(string(manifest.Content), "\n")
+		if path == dir {
+			return nil
+		}
 
-	var content string
+		if strings.HasPrefix(entry.Name(), ".") {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
 
-	for i := range lines {
-		if strings.TrimRight(lines[i], " ") == "---" {
-			if content != "" {
-				manifests = append(manifests, content)
-				content = ""
+			return nil
+		}
+
+		if entry.IsDir() {
+			if !recursive {
+				return filepath.SkipDir
 			}
-		} else {
-			content = content + "\n" + lines[i]
+
+			if isKustomization(path) {
+				found = append(found, path)
+
+				return filepath.SkipDir
+			}
+
+			return nil
 		}
+
+		if isManifestFile(entry.Name()) {
+			found = append(found, path)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w; unable to find manifests in directory %s", err, dir)
 	}
 
-	// ensure content is not empty before appending it to the manifests slice
-	if strings.TrimSpace(content) != "" {
-		manifests = append(manifests, content)
+	return found, nil
+}
+
+// ExtractManifests extracts the manifests as YAML strings from a manifest with
+// existing manifest content.  Documents of a list kind (e.g. v1/List) are expanded
+// into their individual items and JSON documents are converted to YAML.
+func (manifest *Manifest) ExtractManifests() ([]string, error) {
+	var manifests []string
+
+	decoder := yaml.NewDecoder(bytes.NewReader(manifest.Content))
+
+	for {
+		var document yaml.Node
+
+		if err := decoder.Decode(&document); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("%w; %s for manifest file %s", err, ErrProcessManifest.Error(), manifest.Filename)
+		}
+
+		for _, node := range expandDocument(&document) {
+			content, err := yaml.Marshal(node)
+			if err != nil {
+				return nil, fmt.Errorf("%w; %s for manifest file %s", err, ErrProcessManifest.Error(), manifest.Filename)
+			}
+
+			manifests = append(manifests, string(content))
+		}
 	}
 
-	return manifests
+	return manifests, nil
 }
 
-// LoadContent sets the Content field of the manifest in raw format as []byte.
+// LoadContent sets the Content field of the manifest in raw format as []byte.  If the manifest
+// refers to a kustomization directory, the content is the rendered output of the kustomization.
 func (manifest *Manifest) LoadContent(isCollection bool) error {
-	manifestContent, err := os.ReadFile(manifest.Filename)
+	manifestContent, err := manifest.readContent()
 	if err != nil {
 		return fmt.Errorf("%w; %s for manifest file %s", err, ErrProcessManifest.Error(), manifest.Filename)
 	}
@@ -102,6 +172,16 @@ func (manifest *Manifest) LoadContent(isCollection bool) error {
 	return nil
 }
 
+// readContent reads the raw content of the manifest, either from the manifest file itself or
+// by building the kustomization directory that the manifest refers to.
+func (manifest *Manifest) readContent() ([]byte, error) {
+	if manifest.Kustomization {
+		return buildKustomization(manifest.Filename)
+	}
+
+	return os.ReadFile(manifest.Filename)
+}
+
 // FromFiles returns new manifest objects given a set of file paths.
 func FromFiles(manifestFiles []string) *Manifests {
 	manifests := make(Manifests, len(manifestFiles))
@@ -154,6 +234,98 @@ func (manifests Manifests) FuncNames() (createFuncNames, initFuncNames []string)
 	return createFuncNames, initFuncNames
 }
 
+// expandDocument returns the individual manifests which are contained within a YAML document.  Empty
+// documents return no manifests, documents of a list kind return each of their items and any other
+// document is returned as-is.  JSON documents are converted to block style YAML.
+func expandDocument(document *yaml.Node) []*yaml.Node {
+	if document.Kind != yaml.DocumentNode || len(document.Content) == 0 {
+		return nil
+	}
+
+	root := document.Content[0]
+
+	// skip empty documents, such as those which only contain comments
+	if root.Kind == yaml.ScalarNode && root.Tag == "!!null" {
+		return nil
+	}
+
+	if root.Style&yaml.FlowStyle != 0 {
+		setBlockStyle(root)
+	}
+
+	items := listItems(root)
+	if items == nil {
+		return []*yaml.Node{document}
+	}
+
+	var expanded []*yaml.Node
+
+	for _, item := range items.Content {
+		expanded = append(expanded, expandDocument(&yaml.Node{
+			Kind:    yaml.DocumentNode,
+			Content: []*yaml.Node{item},
+		})...)
+	}
+
+	return expanded
+}
+
+// listItems returns the items of a list kind (e.g. v1/List or any other *List kind) or nil if the
+// node does not represent a list kind.
+func listItems(node *yaml.Node) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	var kind, items *yaml.Node
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		switch node.Content[i].Value {
+		case "kind":
+			kind = node.Content[i+1]
+		case "items":
+			items = node.Content[i+1]
+		}
+	}
+
+	if kind == nil || !strings.HasSuffix(kind.Value, "List") {
+		return nil
+	}
+
+	if items == nil || items.Kind != yaml.SequenceNode {
+		return nil
+	}
+
+	return items
+}
+
+// setBlockStyle recursively removes the flow style from a node so that it is encoded as block
+// style YAML.
+func setBlockStyle(node *yaml.Node) {
+	node.Style &^= yaml.FlowStyle
+
+	for _, child := range node.Content {
+		setBlockStyle(child)
+	}
+}
+
+// isManifestFile determines if a file name refers to a manifest file.  Kustomization files are not
+// considered manifest files, as they are only meaningful when the kustomization is built.
+func isManifestFile(fileName string) bool {
+	for _, kustomizationFileName := range konfig.RecognizedKustomizationFileNames() {
+		if fileName == kustomizationFileName {
+			return false
+		}
+	}
+
+	switch filepath.Ext(fileName) {
+	case ".yaml", ".yml", ".json":
+		return true
+	}
+
+	return false
+}
+
 // getFileNames returns all available file names.
 func getFileNames(relativeFileName string) []string {
 	// remove ./ and ../ from relative file name
@@ -4,6 +4,8 @@
 package manifests
 
 import (
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 )
@@ -97,3 +99,173 @@ func Test_getFileNames(t *testing.T) {
 		})
 	}
 }
+
+func TestManifest_ExtractManifests(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "ensure multiple yaml documents are extracted",
+			content: `---
+apiVersion: v1
+kind: Namespace
+metadata:
+    name: first
+---
+# comment only document
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+    name: second # +operator-builder:field:name=namespace,type=string
+`,
+			want: []string{
+				"apiVersion: v1\nkind: Namespace\nmetadata:\n    name: first\n",
+				"apiVersion: v1\nkind: Namespace\nmetadata:\n    name: second # +operator-builder:field:name=namespace,type=string\n",
+			},
+		},
+		{
+			name: "ensure list items are expanded with their comments",
+			content: `apiVersion: v1
+kind: List
+items:
+    # +operator-builder:resource:field=enabled,value=true,include
+    - apiVersion: v1
+      kind: ConfigMap
+      metadata:
+        name: first
+    - apiVersion: v1
+      kind: ConfigMap
+      metadata:
+        name: second
+`,
+			want: []string{
+				"# +operator-builder:resource:field=enabled,value=true,include\napiVersion: v1\nkind: ConfigMap\nmetadata:\n    name: first\n",
+				"apiVersion: v1\nkind: ConfigMap\nmetadata:\n    name: second\n",
+			},
+		},
+		{
+			name: "ensure specific list kinds are expanded",
+			content: `apiVersion: v1
+kind: ConfigMapList
+items:
+    - apiVersion: v1
+      kind: ConfigMap
+      metadata:
+        name: first
+`,
+			want: []string{
+				"apiVersion: v1\nkind: ConfigMap\nmetadata:\n    name: first\n",
+			},
+		},
+		{
+			name: "ensure json documents are converted to yaml",
+			content: `{
+  "apiVersion": "v1",
+  "kind": "List",
+  "items": [
+    {"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "first"}, "data": {"key": "value"}}
+  ]
+}`,
+			want: []string{
+				"\"apiVersion\": \"v1\"\n\"kind\": \"ConfigMap\"\n\"metadata\":\n    \"name\": \"first\"\n\"data\":\n    \"key\": \"value\"\n",
+			},
+		},
+		{
+			name:    "ensure invalid yaml returns an error",
+			content: "kind: [\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			manifest := &Manifest{Content: []byte(tt.content)}
+			got, err := manifest.ExtractManifests()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ExtractManifests() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExtractManifests() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindManifests(t *testing.T) {
+	t.Parallel()
+
+	base, overlay := writeTestKustomization(t)
+	root := filepath.Dir(base)
+
+	for path, content := range map[string]string{
+		filepath.Join(root, "namespace.yaml"):         "kind: Namespace\n",
+		filepath.Join(root, "README.md"):              "# manifests\n",
+		filepath.Join(root, "app", "deploy.json"):     "{}\n",
+		filepath.Join(root, ".hidden", "secret.yaml"): "kind: Secret\n",
+		filepath.Join(root, "app", "nested", "s.yml"): "kind: Service\n",
+	} {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		dir       string
+		recursive bool
+		want      []string
+	}{
+		{
+			name:      "ensure subdirectories and kustomizations are found recursively",
+			dir:       root,
+			recursive: true,
+			want: []string{
+				filepath.Join(root, "app", "deploy.json"),
+				filepath.Join(root, "app", "nested", "s.yml"),
+				base,
+				filepath.Join(root, "namespace.yaml"),
+				overlay,
+			},
+		},
+		{
+			name: "ensure only top level files are found without recursion",
+			dir:  root,
+			want: []string{filepath.Join(root, "namespace.yaml")},
+		},
+		{
+			name: "ensure a kustomization directory is a single manifest",
+			dir:  base,
+			want: []string{base},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := FindManifests(tt.dir, tt.recursive)
+			if err != nil {
+				t.Fatalf("FindManifests() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindManifests() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
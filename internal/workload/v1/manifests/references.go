@@ -0,0 +1,260 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package manifests
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ObjectReference represents a reference to a Kubernetes object.  A reference without a name
+// refers to a type of object, such as the kind which is defined by a custom resource definition.
+type ObjectReference struct {
+	Group     string
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+// Matches determines if an object reference refers to the same object as another reference.  A
+// reference without a namespace matches a reference in any namespace.
+func (ref ObjectReference) Matches(other ObjectReference) bool {
+	if ref.Group != other.Group || ref.Kind != other.Kind || ref.Name != other.Name {
+		return false
+	}
+
+	return ref.Namespace == "" || other.Namespace == "" || ref.Namespace == other.Namespace
+}
+
+func (ref ObjectReference) String() string {
+	kind := ref.Kind
+	if ref.Group != "" {
+		kind = fmt.Sprintf("%s.%s", ref.Kind, ref.Group)
+	}
+
+	if ref.Name == "" {
+		return kind
+	}
+
+	if ref.Namespace == "" {
+		return fmt.Sprintf("%s/%s", kind, ref.Name)
+	}
+
+	return fmt.Sprintf("%s/%s/%s", kind, ref.Namespace, ref.Name)
+}
+
+// References represents the objects that a set of manifests provide and the objects which the
+// manifests use.
+type References struct {
+	Provides []ObjectReference
+	Uses     []ObjectReference
+}
+
+// UsesAny determines if any of the objects used by a set of references are provided by another
+// set of references.
+func (references *References) UsesAny(other *References) bool {
+	for _, used := range references.Uses {
+		for _, provided := range other.Provides {
+			if used.Matches(provided) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// References returns the objects that a manifest provides and uses.  Objects are used by
+// reference, such as service accounts, secrets and config maps referenced by a pod spec or a
+// custom resource whose kind is defined by a custom resource definition.
+func (manifest *Manifest) References() (*References, error) {
+	references := &References{}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(manifest.Content))
+
+	for {
+		var document yaml.Node
+
+		if err := decoder.Decode(&document); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("%w; %s for manifest file %s", err, ErrProcessManifest.Error(), manifest.Filename)
+		}
+
+		for _, object := range expandDocument(&document) {
+			references.add(object.Content[0])
+		}
+	}
+
+	return references, nil
+}
+
+// add adds the references for a single object.
+func (references *References) add(object *yaml.Node) {
+	group, _ := splitAPIVersion(scalarValue(getField(object, "apiVersion")))
+	kind := scalarValue(getField(object, "kind"))
+	metadata := getField(object, "metadata")
+	namespace := scalarValue(getField(metadata, "namespace"))
+	spec := getField(object, "spec")
+
+	if kind == "" {
+		return
+	}
+
+	references.Provides = append(references.Provides, ObjectReference{
+		Group:     group,
+		Kind:      kind,
+		Namespace: namespace,
+		Name:      scalarValue(getField(metadata, "name")),
+	})
+
+	// every object uses its own type and its namespace
+	references.Uses = append(references.Uses, ObjectReference{Group: group, Kind: kind})
+
+	if namespace != "" {
+		references.Uses = append(references.Uses, ObjectReference{Kind: "Namespace", Name: namespace})
+	}
+
+	switch kind {
+	case "CustomResourceDefinition":
+		references.Provides = append(references.Provides, ObjectReference{
+			Group: scalarValue(getField(spec, "group")),
+			Kind:  scalarValue(getField(getField(spec, "names"), "kind")),
+		})
+	case "RoleBinding", "ClusterRoleBinding":
+		references.addBindingReferences(object, namespace)
+	default:
+		references.addPodSpecReferences(podSpec(kind, spec), namespace)
+	}
+}
+
+// addBindingReferences adds the references of a role binding or cluster role binding.
+func (references *References) addBindingReferences(binding *yaml.Node, namespace string) {
+	if roleRef := getField(binding, "roleRef"); roleRef != nil {
+		roleNamespace := namespace
+		if scalarValue(getField(roleRef, "kind")) == "ClusterRole" {
+			roleNamespace = ""
+		}
+
+		references.use("rbac.authorization.k8s.io", getField(roleRef, "kind"), roleNamespace, getField(roleRef, "name"))
+	}
+
+	for _, subject := range sequenceItems(getField(binding, "subjects")) {
+		if scalarValue(getField(subject, "kind")) != "ServiceAccount" {
+			continue
+		}
+
+		subjectNamespace := scalarValue(getField(subject, "namespace"))
+		if subjectNamespace == "" {
+			subjectNamespace = namespace
+		}
+
+		references.use("", getField(subject, "kind"), subjectNamespace, getField(subject, "name"))
+	}
+}
+
+// addPodSpecReferences adds the references of a pod spec, such as its service account, the secrets
+// and config maps used by its volumes and containers and its persistent volume claims.
+func (references *References) addPodSpecReferences(spec *yaml.Node, namespace string) {
+	if spec == nil {
+		return
+	}
+
+	serviceAccount := getField(spec, "serviceAccountName")
+	if serviceAccount == nil {
+		serviceAccount = getField(spec, "serviceAccount")
+	}
+
+	references.useKind("ServiceAccount", namespace, serviceAccount)
+
+	for _, pullSecret := range sequenceItems(getField(spec, "imagePullSecrets")) {
+		references.useKind("Secret", namespace, getField(pullSecret, "name"))
+	}
+
+	for _, volume := range sequenceItems(getField(spec, "volumes")) {
+		references.useKind("Secret", namespace, getField(getField(volume, "secret"), "secretName"))
+		references.useKind("ConfigMap", namespace, getField(getField(volume, "configMap"), "name"))
+		references.useKind("PersistentVolumeClaim", namespace, getField(getField(volume, "persistentVolumeClaim"), "claimName"))
+
+		for _, source := range sequenceItems(getField(getField(volume, "projected"), "sources")) {
+			references.useKind("Secret", namespace, getField(getField(source, "secret"), "name"))
+			references.useKind("ConfigMap", namespace, getField(getField(source, "configMap"), "name"))
+		}
+	}
+
+	for _, field := range []string{"initContainers", "containers"} {
+		for _, container := range sequenceItems(getField(spec, field)) {
+			for _, env := range sequenceItems(getField(container, "env")) {
+				valueFrom := getField(env, "valueFrom")
+
+				references.useKind("Secret", namespace, getField(getField(valueFrom, "secretKeyRef"), "name"))
+				references.useKind("ConfigMap", namespace, getField(getField(valueFrom, "configMapKeyRef"), "name"))
+			}
+
+			for _, envFrom := range sequenceItems(getField(container, "envFrom")) {
+				references.useKind("Secret", namespace, getField(getField(envFrom, "secretRef"), "name"))
+				references.useKind("ConfigMap", namespace, getField(getField(envFrom, "configMapRef"), "name"))
+			}
+		}
+	}
+}
+
+// useKind adds a reference to a core object of a particular kind given the node of its name.
+func (references *References) useKind(kind, namespace string, name *yaml.Node) {
+	references.use("", &yaml.Node{Kind: yaml.ScalarNode, Value: kind}, namespace, name)
+}
+
+// use adds a reference to an object given the nodes of its kind and name.  References without
+// a kind or name are ignored.
+func (references *References) use(group string, kind *yaml.Node, namespace string, name *yaml.Node) {
+	if scalarValue(kind) == "" || scalarValue(name) == "" {
+		return
+	}
+
+	references.Uses = append(references.Uses, ObjectReference{
+		Group:     group,
+		Kind:      kind.Value,
+		Namespace: namespace,
+		Name:      name.Value,
+	})
+}
+
+// podSpec returns the pod spec of an object given its kind and spec, or nil if the kind does not
+// contain a pod spec.
+func podSpec(kind string, spec *yaml.Node) *yaml.Node {
+	switch kind {
+	case "Pod":
+		return spec
+	case "Deployment", "ReplicaSet", "StatefulSet", "DaemonSet", "Job":
+		return getField(getField(spec, "template"), "spec")
+	case "CronJob":
+		return getField(getField(getField(getField(spec, "jobTemplate"), "spec"), "template"), "spec")
+	}
+
+	return nil
+}
+
+// sequenceItems returns the items of a sequence node or nil if the node is not a sequence.
+func sequenceItems(node *yaml.Node) []*yaml.Node {
+	if node == nil || node.Kind != yaml.SequenceNode {
+		return nil
+	}
+
+	return node.Content
+}
+
+// splitAPIVersion splits an api version into its group and version.  The group of the core api
+// is empty.
+func splitAPIVersion(apiVersion string) (group, version string) {
+	if i := strings.LastIndex(apiVersion, "/"); i >= 0 {
+		return apiVersion[:i], apiVersion[i+1:]
+	}
+
+	return "", apiVersion
+}
@@ -0,0 +1,169 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package manifests
+
+import (
+	"testing"
+)
+
+const (
+	testReferencesProvider = `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: certificates.cert-manager.io
+spec:
+  group: cert-manager.io
+  names:
+    kind: Certificate
+---
+apiVersion: v1
+kind: List
+items:
+  - apiVersion: v1
+    kind: ServiceAccount
+    metadata:
+      name: shared
+      namespace: acme
+  - apiVersion: v1
+    kind: Secret
+    metadata:
+      name: credentials
+      namespace: acme
+`
+	testReferencesConsumer = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  namespace: acme
+spec:
+  template:
+    spec:
+      serviceAccountName: shared
+      containers:
+        - name: app
+          image: nginx
+          env:
+            - name: PASSWORD
+              valueFrom:
+                secretKeyRef:
+                  name: credentials
+                  key: password
+`
+	testReferencesCustomResource = `apiVersion: cert-manager.io/v1
+kind: Certificate
+metadata:
+  name: app
+`
+	testReferencesBinding = `apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRoleBinding
+metadata:
+  name: app
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: ClusterRole
+  name: app
+subjects:
+  - kind: ServiceAccount
+    name: shared
+    namespace: acme
+`
+)
+
+func TestObjectReference_Matches(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		ref   ObjectReference
+		other ObjectReference
+		want  bool
+	}{
+		{
+			name:  "ensure identical references match",
+			ref:   ObjectReference{Kind: "Secret", Namespace: "acme", Name: "credentials"},
+			other: ObjectReference{Kind: "Secret", Namespace: "acme", Name: "credentials"},
+			want:  true,
+		},
+		{
+			name:  "ensure a reference without a namespace matches any namespace",
+			ref:   ObjectReference{Kind: "Secret", Name: "credentials"},
+			other: ObjectReference{Kind: "Secret", Namespace: "acme", Name: "credentials"},
+			want:  true,
+		},
+		{
+			name:  "ensure references in different namespaces do not match",
+			ref:   ObjectReference{Kind: "Secret", Namespace: "other", Name: "credentials"},
+			other: ObjectReference{Kind: "Secret", Namespace: "acme", Name: "credentials"},
+			want:  false,
+		},
+		{
+			name:  "ensure a type reference does not match an object",
+			ref:   ObjectReference{Group: "cert-manager.io", Kind: "Certificate"},
+			other: ObjectReference{Group: "cert-manager.io", Kind: "Certificate", Name: "app"},
+			want:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.ref.Matches(tt.other); got != tt.want {
+				t.Errorf("ObjectReference.Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestManifest_References(t *testing.T) {
+	t.Parallel()
+
+	provider, err := (&Manifest{Content: []byte(testReferencesProvider)}).References()
+	if err != nil {
+		t.Fatalf("References() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		want    bool
+	}{
+		{
+			name:    "ensure pod spec references use provided objects",
+			content: testReferencesConsumer,
+			want:    true,
+		},
+		{
+			name:    "ensure custom resources use provided custom resource definitions",
+			content: testReferencesCustomResource,
+			want:    true,
+		},
+		{
+			name:    "ensure binding subjects use provided service accounts",
+			content: testReferencesBinding,
+			want:    true,
+		},
+		{
+			name:    "ensure unrelated objects do not use provided objects",
+			content: "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: app\n  namespace: other\n",
+			want:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			references, err := (&Manifest{Content: []byte(tt.content)}).References()
+			if err != nil {
+				t.Fatalf("References() error = %v", err)
+			}
+
+			if got := references.UsesAny(provider); got != tt.want {
+				t.Errorf("References.UsesAny() = %v, want %v; uses %v", got, tt.want, references.Uses)
+			}
+		})
+	}
+}
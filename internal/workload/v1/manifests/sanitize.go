@@ -0,0 +1,240 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package manifests
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// serverField represents a field that is populated by the Kubernetes API server and should not
+// be present in a source manifest.  If a value is set, the field is only considered to be
+// populated by the API server when it matches the value.  If a value is excepted, the field is
+// considered to be set by the user when it matches the excepted value.
+type serverField struct {
+	path   []string
+	value  string
+	except string
+
+	// valueFrom returns the value of a field which is populated by the API server from another
+	// field of the object, or an empty string if the field may not have been populated by the
+	// API server.
+	valueFrom func(object *yaml.Node) string
+}
+
+// serverMetadataFields returns the metadata fields which are populated by the Kubernetes API
+// server for all objects.
+func serverMetadataFields() []string {
+	return []string{
+		"creationTimestamp",
+		"deletionGracePeriodSeconds",
+		"deletionTimestamp",
+		"generation",
+		"managedFields",
+		"ownerReferences",
+		"resourceVersion",
+		"selfLink",
+		"uid",
+	}
+}
+
+// serverAnnotations returns the annotations which are populated by the Kubernetes API server,
+// its controllers, or by kubectl for all objects.
+func serverAnnotations() []string {
+	return []string{
+		"kubectl.kubernetes.io/last-applied-configuration",
+		"deployment.kubernetes.io/revision",
+		"pv.kubernetes.io/bind-completed",
+		"pv.kubernetes.io/bound-by-controller",
+		"volume.beta.kubernetes.io/storage-provisioner",
+		"volume.kubernetes.io/storage-provisioner",
+	}
+}
+
+// serverDefaults returns the fields, by kind, which are defaulted by the Kubernetes API server.
+func serverDefaults() map[string][]serverField {
+	return map[string][]serverField{
+		"Service": {
+			// the cluster ip of a headless service is None, which is set by the user
+			{path: []string{"spec", "clusterIP"}, except: "None"},
+			{path: []string{"spec", "clusterIPs"}, except: "None"},
+			{path: []string{"spec", "internalTrafficPolicy"}, value: "Cluster"},
+			{path: []string{"spec", "ipFamilies"}},
+			{path: []string{"spec", "ipFamilyPolicy"}, value: "SingleStack"},
+			{path: []string{"spec", "sessionAffinity"}, value: "None"},
+		},
+		"Deployment": append([]serverField{
+			{path: []string{"spec", "progressDeadlineSeconds"}, value: "600"},
+			{path: []string{"spec", "revisionHistoryLimit"}, value: "10"},
+		}, podTemplateDefaults("spec", "template")...),
+		"DaemonSet": append([]serverField{
+			{path: []string{"spec", "revisionHistoryLimit"}, value: "10"},
+		}, podTemplateDefaults("spec", "template")...),
+		"StatefulSet": append([]serverField{
+			{path: []string{"spec", "podManagementPolicy"}, value: "OrderedReady"},
+			{path: []string{"spec", "revisionHistoryLimit"}, value: "10"},
+		}, podTemplateDefaults("spec", "template")...),
+		"Job":     podTemplateDefaults("spec", "template"),
+		"CronJob": podTemplateDefaults("spec", "jobTemplate", "spec", "template"),
+		"PersistentVolumeClaim": {
+			{path: []string{"spec", "volumeMode"}, value: "Filesystem"},
+			// a volume name which does not match a dynamically provisioned volume binds the claim
+			// to a volume which is set by the user
+			{path: []string{"spec", "volumeName"}, valueFrom: provisionedVolumeName},
+		},
+	}
+}
+
+// provisionedVolumeName returns the name of the volume which is dynamically provisioned for a
+// persistent volume claim, which is derived from the uid of the claim.
+func provisionedVolumeName(object *yaml.Node) string {
+	uid := getField(getField(object, "metadata"), "uid")
+	if uid == nil || uid.Value == "" {
+		return ""
+	}
+
+	return "pvc-" + uid.Value
+}
+
+// podTemplateDefaults returns the fields of a pod template, found at a particular path, which are
+// defaulted by the Kubernetes API server.
+func podTemplateDefaults(prefix ...string) []serverField {
+	path := func(keys ...string) []string {
+		return append(append([]string{}, prefix...), keys...)
+	}
+
+	return []serverField{
+		{path: path("metadata", "creationTimestamp")},
+		{path: path("spec", "dnsPolicy"), value: "ClusterFirst"},
+		{path: path("spec", "restartPolicy"), value: "Always"},
+		{path: path("spec", "schedulerName"), value: "default-scheduler"},
+		{path: path("spec", "securityContext"), value: "{}"},
+		{path: path("spec", "terminationGracePeriodSeconds"), value: "30"},
+	}
+}
+
+// Sanitize removes the fields from an object, exported from a running cluster, that are
+// populated by the Kubernetes API server, such as the status, server managed metadata and
+// server populated defaults.  The result is a clean object which is suitable for use as
+// a workload resource.
+func Sanitize(node *yaml.Node) {
+	object := node
+	if object.Kind == yaml.DocumentNode && len(object.Content) > 0 {
+		object = object.Content[0]
+	}
+
+	if object.Kind != yaml.MappingNode {
+		return
+	}
+
+	// the server defaults are removed first, as some of them are derived from the server
+	// populated metadata
+	if kind := getField(object, "kind"); kind != nil {
+		for _, field := range serverDefaults()[kind.Value] {
+			deletePath(object, field)
+		}
+	}
+
+	deleteField(object, "status")
+
+	if metadata := getField(object, "metadata"); metadata != nil {
+		for _, field := range serverMetadataFields() {
+			deleteField(metadata, field)
+		}
+
+		if annotations := getField(metadata, "annotations"); annotations != nil {
+			for _, annotation := range serverAnnotations() {
+				deleteField(annotations, annotation)
+			}
+
+			if len(annotations.Content) == 0 {
+				deleteField(metadata, "annotations")
+			}
+		}
+	}
+}
+
+// getField returns the value of a field within a mapping node or nil if it does not exist.
+func getField(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+// deleteField removes a field from a mapping node.
+func deleteField(node *yaml.Node, key string) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content = append(node.Content[:i], node.Content[i+2:]...)
+
+			return
+		}
+	}
+}
+
+// deletePath removes a server populated field from a mapping node given its path.  If the server
+// field has a value, it is only removed if the value matches, and it is not removed if it matches
+// the excepted value.
+func deletePath(node *yaml.Node, field serverField) {
+	parent := node
+
+	for _, key := range field.path[:len(field.path)-1] {
+		if parent = getField(parent, key); parent == nil {
+			return
+		}
+	}
+
+	last := field.path[len(field.path)-1]
+
+	value := getField(parent, last)
+	if value == nil {
+		return
+	}
+
+	expected := field.value
+
+	if field.valueFrom != nil {
+		if expected = field.valueFrom(node); expected == "" {
+			return
+		}
+	}
+
+	if expected != "" && !hasValue(value, expected) {
+		return
+	}
+
+	if field.except != "" && hasValue(value, field.except) {
+		return
+	}
+
+	deleteField(parent, last)
+}
+
+// hasValue determines if a node has a specific value.  An empty mapping is represented with
+// a value of "{}", and a sequence has the value if any of its items do.
+func hasValue(node *yaml.Node, value string) bool {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return value == "{}" && len(node.Content) == 0
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			if hasValue(item, value) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return strings.TrimSpace(node.Value) == value
+}
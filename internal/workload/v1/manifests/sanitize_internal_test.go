@@ -0,0 +1,275 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package manifests
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestSanitize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		manifest string
+		want     string
+	}{
+		{
+			name: "ensure server populated metadata and status are removed",
+			manifest: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  annotations:
+    kubectl.kubernetes.io/last-applied-configuration: '{}'
+  creationTimestamp: "2024-01-01T00:00:00Z"
+  name: webapp
+  namespace: default
+  resourceVersion: "1234"
+  uid: 2f0e4fce-7a43-4c7e-8a5a-2a0d6f6f4b1c
+data:
+  key: value
+`,
+			want: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: webapp
+  namespace: default
+data:
+  key: value
+`,
+		},
+		{
+			name: "ensure user defined annotations and comments are kept",
+			manifest: `apiVersion: v1
+kind: Service
+metadata:
+  annotations:
+    acme.com/owner: webapp
+    kubectl.kubernetes.io/last-applied-configuration: '{}'
+  name: webapp
+spec:
+  clusterIP: 10.96.0.10
+  clusterIPs:
+    - 10.96.0.10
+  ipFamilyPolicy: SingleStack
+  sessionAffinity: ClientIP
+  ports:
+    # +operator-builder:field:name=webapp.port,default=8080,type=int
+    - port: 8080
+status:
+  loadBalancer: {}
+`,
+			want: `apiVersion: v1
+kind: Service
+metadata:
+  annotations:
+    acme.com/owner: webapp
+  name: webapp
+spec:
+  sessionAffinity: ClientIP
+  ports:
+    # +operator-builder:field:name=webapp.port,default=8080,type=int
+    - port: 8080
+`,
+		},
+		{
+			name: "ensure the cluster ip of a headless service is kept",
+			manifest: `apiVersion: v1
+kind: Service
+metadata:
+  name: webapp
+spec:
+  clusterIP: None
+  clusterIPs:
+    - None
+  ipFamilyPolicy: SingleStack
+  selector:
+    app: webapp
+`,
+			want: `apiVersion: v1
+kind: Service
+metadata:
+  name: webapp
+spec:
+  clusterIP: None
+  clusterIPs:
+    - None
+  selector:
+    app: webapp
+`,
+		},
+		{
+			name: "ensure the volume name of a dynamically provisioned claim is removed",
+			manifest: `apiVersion: v1
+kind: PersistentVolumeClaim
+metadata:
+  annotations:
+    pv.kubernetes.io/bind-completed: "yes"
+  name: webapp
+  uid: 2f0e4fce-7a43-4c7e-8a5a-2a0d6f6f4b1c
+spec:
+  accessModes:
+    - ReadWriteOnce
+  volumeMode: Filesystem
+  volumeName: pvc-2f0e4fce-7a43-4c7e-8a5a-2a0d6f6f4b1c
+`,
+			want: `apiVersion: v1
+kind: PersistentVolumeClaim
+metadata:
+  name: webapp
+spec:
+  accessModes:
+    - ReadWriteOnce
+`,
+		},
+		{
+			name: "ensure the volume name of a statically bound claim is kept",
+			manifest: `apiVersion: v1
+kind: PersistentVolumeClaim
+metadata:
+  name: webapp
+  uid: 2f0e4fce-7a43-4c7e-8a5a-2a0d6f6f4b1c
+spec:
+  accessModes:
+    - ReadWriteOnce
+  volumeName: webapp-data
+`,
+			want: `apiVersion: v1
+kind: PersistentVolumeClaim
+metadata:
+  name: webapp
+spec:
+  accessModes:
+    - ReadWriteOnce
+  volumeName: webapp-data
+`,
+		},
+		{
+			name: "ensure the volume name of a claim without a uid is kept",
+			manifest: `apiVersion: v1
+kind: PersistentVolumeClaim
+metadata:
+  name: webapp
+spec:
+  volumeName: pvc-2f0e4fce-7a43-4c7e-8a5a-2a0d6f6f4b1c
+`,
+			want: `apiVersion: v1
+kind: PersistentVolumeClaim
+metadata:
+  name: webapp
+spec:
+  volumeName: pvc-2f0e4fce-7a43-4c7e-8a5a-2a0d6f6f4b1c
+`,
+		},
+		{
+			name: "ensure server defaulted pod template fields are removed",
+			manifest: `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  annotations:
+    deployment.kubernetes.io/revision: "1"
+  generation: 1
+  name: webapp
+spec:
+  progressDeadlineSeconds: 600
+  replicas: 2
+  revisionHistoryLimit: 5
+  template:
+    metadata:
+      creationTimestamp: null
+      labels:
+        app: webapp
+    spec:
+      containers:
+        - name: webapp
+          image: nginx:1.0
+      dnsPolicy: ClusterFirst
+      restartPolicy: Always
+      schedulerName: default-scheduler
+      securityContext: {}
+      terminationGracePeriodSeconds: 60
+`,
+			want: `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: webapp
+spec:
+  replicas: 2
+  revisionHistoryLimit: 5
+  template:
+    metadata:
+      labels:
+        app: webapp
+    spec:
+      containers:
+        - name: webapp
+          image: nginx:1.0
+      terminationGracePeriodSeconds: 60
+`,
+		},
+		{
+			name: "ensure cron job templates are sanitized",
+			manifest: `apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: webapp
+spec:
+  schedule: '* * * * *'
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          restartPolicy: OnFailure
+          schedulerName: default-scheduler
+`,
+			want: `apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: webapp
+spec:
+  schedule: '* * * * *'
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          restartPolicy: OnFailure
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var node yaml.Node
+			if err := yaml.Unmarshal([]byte(tt.manifest), &node); err != nil {
+				t.Fatalf("yaml.Unmarshal() error = %v", err)
+			}
+
+			Sanitize(&node)
+
+			got, err := yaml.Marshal(&node)
+			if err != nil {
+				t.Fatalf("yaml.Marshal() error = %v", err)
+			}
+
+			var want yaml.Node
+			if err := yaml.Unmarshal([]byte(tt.want), &want); err != nil {
+				t.Fatalf("yaml.Unmarshal() error = %v", err)
+			}
+
+			wantBytes, err := yaml.Marshal(&want)
+			if err != nil {
+				t.Fatalf("yaml.Marshal() error = %v", err)
+			}
+
+			if string(got) != string(wantBytes) {
+				t.Errorf("Sanitize() got:\n%s\nwant:\n%s", got, wantBytes)
+			}
+		})
+	}
+}
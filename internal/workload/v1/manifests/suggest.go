@@ -0,0 +1,476 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package manifests
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/nukleros/operator-builder/internal/workload/v1/markers"
+)
+
+const (
+	markerPrefix = "+operator-builder:"
+
+	suggestionContextLines = 3
+)
+
+// Suggestion represents a field marker which is suggested for a well-known tunable value within
+// a manifest file.
+type Suggestion struct {
+	Line   int
+	Path   string
+	Name   string
+	Type   markers.FieldType
+	Value  string
+	Marker string
+}
+
+// MarkerSuggester suggests field markers for well-known tunable values within manifests.  A
+// single suggester should be used for all of the manifests of a workload so that marker names
+// are consistent across files and values that appear in several manifests share a single marker.
+type MarkerSuggester struct {
+	namesByKey  map[string]string
+	keysByName  map[string]string
+	suggestions []*Suggestion
+}
+
+// suggestionRule represents the rule by which a marker name is determined for a value.  Values
+// which share a key share a marker.  The names are tried in order until a name is found which
+// is not already used by another key.
+type suggestionRule struct {
+	key       string
+	names     []string
+	fieldType markers.FieldType
+}
+
+// NewMarkerSuggester returns a new suggester with no marker names in use.
+func NewMarkerSuggester() *MarkerSuggester {
+	return &MarkerSuggester{
+		namesByKey: map[string]string{},
+		keysByName: map[string]string{},
+	}
+}
+
+// Suggest returns the marker suggestions for the manifest content of a single file.  Values which
+// already have a marker, or a comment which the marker would conflict with, are skipped.
+func (suggester *MarkerSuggester) Suggest(content []byte) ([]*Suggestion, error) {
+	suggester.suggestions = nil
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+
+	for {
+		var document yaml.Node
+
+		if err := decoder.Decode(&document); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("%w; unable to decode manifest content", err)
+		}
+
+		// markers cannot be added to flow style documents such as JSON documents
+		if len(document.Content) == 0 || document.Content[0].Style&yaml.FlowStyle != 0 {
+			continue
+		}
+
+		for _, object := range expandDocument(&document) {
+			suggester.suggestObject(object.Content[0])
+		}
+	}
+
+	sort.SliceStable(suggester.suggestions, func(i, j int) bool {
+		return suggester.suggestions[i].Line < suggester.suggestions[j].Line
+	})
+
+	return suggester.suggestions, nil
+}
+
+// suggestObject suggests markers for the well-known tunable values within a single object.
+func (suggester *MarkerSuggester) suggestObject(object *yaml.Node) {
+	kind := scalarValue(getField(object, "kind"))
+	metadata := getField(object, "metadata")
+	name := scalarValue(getField(metadata, "name"))
+	spec := getField(object, "spec")
+
+	if namespace := getField(metadata, "namespace"); namespace != nil {
+		suggester.suggestNamespace(metadata, "metadata.namespace", namespace.Value)
+	}
+
+	switch kind {
+	case "Namespace":
+		suggester.suggestNamespace(metadata, "metadata.name", name)
+	case "Pod":
+		suggester.suggestPodSpec(spec, "spec", name)
+	case "Deployment", "ReplicaSet", "StatefulSet":
+		suggester.suggest(spec, "spec.replicas", "replicas", &suggestionRule{
+			names:     []string{lowerCamel(name, "replicas")},
+			fieldType: markers.FieldInt,
+		})
+
+		suggester.suggestPodSpec(getField(getField(spec, "template"), "spec"), "spec.template.spec", name)
+
+		if kind == "StatefulSet" {
+			suggester.suggestVolumeClaimTemplates(spec, name)
+		}
+	case "DaemonSet", "Job":
+		suggester.suggestPodSpec(getField(getField(spec, "template"), "spec"), "spec.template.spec", name)
+	case "CronJob":
+		podSpec := getField(getField(getField(getField(spec, "jobTemplate"), "spec"), "template"), "spec")
+		suggester.suggestPodSpec(podSpec, "spec.jobTemplate.spec.template.spec", name)
+	case "Service":
+		suggester.suggest(spec, "spec.type", "type", &suggestionRule{
+			names:     []string{lowerCamel(name, "serviceType")},
+			fieldType: markers.FieldString,
+		})
+	case "Ingress":
+		suggester.suggestIngressHosts(spec, name)
+	case "PersistentVolumeClaim":
+		suggester.suggestStorage(spec, "spec", name)
+	}
+}
+
+// suggestNamespace suggests a marker for a namespace.  All objects in the same namespace share
+// a single marker.
+func (suggester *MarkerSuggester) suggestNamespace(parent *yaml.Node, path, namespace string) {
+	suggester.suggest(parent, path, path[strings.LastIndex(path, ".")+1:], &suggestionRule{
+		key:       "namespace/" + namespace,
+		names:     []string{"namespace", lowerCamel(namespace, "namespace")},
+		fieldType: markers.FieldString,
+	})
+}
+
+// suggestPodSpec suggests markers for the images and compute resources of the containers within
+// a pod spec.
+func (suggester *MarkerSuggester) suggestPodSpec(podSpec *yaml.Node, path, objectName string) {
+	for _, field := range []string{"initContainers", "containers"} {
+		containers := getField(podSpec, field)
+		if containers == nil || containers.Kind != yaml.SequenceNode {
+			continue
+		}
+
+		for i, container := range containers.Content {
+			containerName := scalarValue(getField(container, "name"))
+			containerPath := fmt.Sprintf("%s.%s[%d]", path, field, i)
+
+			if image := getField(container, "image"); image != nil {
+				suggester.suggest(container, containerPath+".image", "image", &suggestionRule{
+					key: "image/" + image.Value,
+					names: []string{
+						lowerCamel(containerName, "image"),
+						lowerCamel(objectName, containerName, "image"),
+					},
+					fieldType: markers.FieldString,
+				})
+			}
+
+			suggester.suggestResources(getField(container, "resources"), containerPath+".resources", objectName, containerName)
+		}
+	}
+}
+
+// suggestResources suggests markers for the compute resource requests and limits of a container.
+func (suggester *MarkerSuggester) suggestResources(resources *yaml.Node, path, objectName, containerName string) {
+	for _, requirement := range []string{"requests", "limits"} {
+		quantities := getField(resources, requirement)
+		if quantities == nil || quantities.Kind != yaml.MappingNode {
+			continue
+		}
+
+		for i := 0; i+1 < len(quantities.Content); i += 2 {
+			resource := quantities.Content[i].Value
+
+			suggester.suggest(quantities, fmt.Sprintf("%s.%s.%s", path, requirement, resource), resource, &suggestionRule{
+				names: []string{
+					lowerCamel(containerName, "resources", requirement, resource),
+					lowerCamel(objectName, containerName, "resources", requirement, resource),
+				},
+				fieldType: markers.FieldString,
+			})
+		}
+	}
+}
+
+// suggestVolumeClaimTemplates suggests markers for the storage sizes of the volume claim templates
+// of a stateful set.
+func (suggester *MarkerSuggester) suggestVolumeClaimTemplates(spec *yaml.Node, objectName string) {
+	templates := getField(spec, "volumeClaimTemplates")
+	if templates == nil || templates.Kind != yaml.SequenceNode {
+		return
+	}
+
+	for i, template := range templates.Content {
+		templateName := scalarValue(getField(getField(template, "metadata"), "name"))
+
+		suggester.suggestStorage(
+			getField(template, "spec"),
+			fmt.Sprintf("spec.volumeClaimTemplates[%d].spec", i),
+			objectName+"-"+templateName,
+		)
+	}
+}
+
+// suggestStorage suggests a marker for the storage size of a persistent volume claim spec.
+func (suggester *MarkerSuggester) suggestStorage(claimSpec *yaml.Node, path, claimName string) {
+	suggester.suggest(getField(getField(claimSpec, "resources"), "requests"), path+".resources.requests.storage", "storage", &suggestionRule{
+		names:     []string{lowerCamel(claimName, "storageSize")},
+		fieldType: markers.FieldString,
+	})
+}
+
+// suggestIngressHosts suggests markers for the hosts of the rules of an ingress.  Rules which share
+// a host share a single marker.
+func (suggester *MarkerSuggester) suggestIngressHosts(spec *yaml.Node, objectName string) {
+	rules := getField(spec, "rules")
+	if rules == nil || rules.Kind != yaml.SequenceNode {
+		return
+	}
+
+	for i, rule := range rules.Content {
+		host := getField(rule, "host")
+		if host == nil {
+			continue
+		}
+
+		suggester.suggest(rule, fmt.Sprintf("spec.rules[%d].host", i), "host", &suggestionRule{
+			key:       "host/" + host.Value,
+			names:     []string{lowerCamel(objectName, "host")},
+			fieldType: markers.FieldString,
+		})
+	}
+}
+
+// suggest suggests a marker for the value of a key within a parent mapping node.  When the rule
+// has no key, values share a marker only when they share both their name and their value.
+func (suggester *MarkerSuggester) suggest(parent *yaml.Node, path, key string, rule *suggestionRule) {
+	if parent == nil || parent.Kind != yaml.MappingNode || parent.Style&yaml.FlowStyle != 0 {
+		return
+	}
+
+	var keyNode, value *yaml.Node
+
+	for i := 0; i+1 < len(parent.Content); i += 2 {
+		if parent.Content[i].Value == key {
+			keyNode, value = parent.Content[i], parent.Content[i+1]
+
+			break
+		}
+	}
+
+	if !canSuggest(keyNode, value) {
+		return
+	}
+
+	if rule.key == "" {
+		rule.key = fmt.Sprintf("%s/%s", rule.names[0], value.Value)
+	}
+
+	rule.key = fmt.Sprintf("%s/%s", rule.fieldType, rule.key)
+
+	name := suggester.resolveName(rule)
+
+	defaultValue := value.Value
+	if rule.fieldType != markers.FieldInt {
+		defaultValue = fmt.Sprintf("%q", value.Value)
+	}
+
+	suggester.suggestions = append(suggester.suggestions, &Suggestion{
+		Line:  value.Line,
+		Path:  path,
+		Name:  name,
+		Type:  rule.fieldType,
+		Value: value.Value,
+		Marker: fmt.Sprintf("%s:name=%s,default=%s,type=%s",
+			markers.FieldMarkerPrefix, name, defaultValue, rule.fieldType,
+		),
+	})
+}
+
+// resolveName returns the marker name for a rule.  The name of an existing marker is reused when
+// the rule key has been seen before, otherwise the first unused name is claimed.
+func (suggester *MarkerSuggester) resolveName(rule *suggestionRule) string {
+	if name, found := suggester.namesByKey[rule.key]; found {
+		return name
+	}
+
+	claim := func(name string) bool {
+		if _, taken := suggester.keysByName[name]; taken {
+			return false
+		}
+
+		suggester.namesByKey[rule.key] = name
+		suggester.keysByName[name] = rule.key
+
+		return true
+	}
+
+	for _, name := range rule.names {
+		if claim(name) {
+			return name
+		}
+	}
+
+	base := rule.names[len(rule.names)-1]
+
+	for i := 2; ; i++ {
+		if name := fmt.Sprintf("%s%d", base, i); claim(name) {
+			return name
+		}
+	}
+}
+
+// canSuggest determines if a marker may be suggested for a value.  The value must be a single
+// line scalar on the same line as its key without any existing comments or markers.
+func canSuggest(key, value *yaml.Node) bool {
+	if key == nil || value == nil || value.Kind != yaml.ScalarNode || value.Value == "" {
+		return false
+	}
+
+	if value.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 || strings.Contains(value.Value, "\n") {
+		return false
+	}
+
+	if key.Line != value.Line {
+		return false
+	}
+
+	for _, comment := range []string{key.HeadComment, key.LineComment, value.HeadComment, value.LineComment} {
+		if comment != "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ApplySuggestions returns the manifest content with each of the suggested markers added as an
+// in-line comment on the line of the value it was suggested for.
+func ApplySuggestions(content []byte, suggestions []*Suggestion) []byte {
+	lines := strings.Split(string(content), "\n")
+
+	for _, suggestion := range suggestions {
+		index := suggestion.Line - 1
+		if index < 0 || index >= len(lines) || strings.Contains(lines[index], markerPrefix) {
+			continue
+		}
+
+		lines[index] = fmt.Sprintf("%s # %s", strings.TrimRight(lines[index], " \t\r"), suggestion.Marker)
+	}
+
+	return []byte(strings.Join(lines, "\n"))
+}
+
+// SuggestionPatch returns a unified diff between the original manifest content and the manifest
+// content with suggestions applied.  Applying suggestions only ever modifies lines in place, so
+// the line numbers of the original and updated content are always the same.
+func SuggestionPatch(filename string, original, updated []byte) string {
+	originalLines := strings.Split(string(original), "\n")
+	updatedLines := strings.Split(string(updated), "\n")
+
+	var changed []int
+
+	for i := range originalLines {
+		if i < len(updatedLines) && originalLines[i] != updatedLines[i] {
+			changed = append(changed, i)
+		}
+	}
+
+	if len(changed) == 0 {
+		return ""
+	}
+
+	var patch strings.Builder
+
+	fmt.Fprintf(&patch, "--- %s\n+++ %s\n", filename, filename)
+
+	for start := 0; start < len(changed); {
+		// group the changed lines which have overlapping context into a single hunk
+		end := start
+		for end+1 < len(changed) && changed[end+1]-changed[end] <= 2*suggestionContextLines {
+			end++
+		}
+
+		first := max(changed[start]-suggestionContextLines, 0)
+		last := min(changed[end]+suggestionContextLines, len(originalLines)-1)
+
+		// do not include the empty line after a trailing newline as context
+		if last > changed[end] && last == len(originalLines)-1 && originalLines[last] == "" {
+			last--
+		}
+
+		count := last - first + 1
+
+		fmt.Fprintf(&patch, "@@ -%d,%d +%d,%d @@\n", first+1, count, first+1, count)
+
+		next := start
+
+		for i := first; i <= last; i++ {
+			if next <= end && changed[next] == i {
+				fmt.Fprintf(&patch, "-%s\n+%s\n", originalLines[i], updatedLines[i])
+
+				next++
+
+				continue
+			}
+
+			fmt.Fprintf(&patch, " %s\n", originalLines[i])
+		}
+
+		start = end + 1
+	}
+
+	return patch.String()
+}
+
+// scalarValue returns the value of a scalar node or an empty string if it is not a scalar.
+func scalarValue(node *yaml.Node) string {
+	if node == nil || node.Kind != yaml.ScalarNode {
+		return ""
+	}
+
+	return node.Value
+}
+
+// lowerCamel joins a set of words into a single dot-separated marker name where each of the words
+// is converted to lowerCamelCase.  Empty words are ignored.
+func lowerCamel(words ...string) string {
+	var parts []string
+
+	for _, word := range words {
+		var part strings.Builder
+
+		upper := false
+
+		for _, char := range word {
+			if !unicode.IsLetter(char) && !unicode.IsDigit(char) {
+				upper = part.Len() > 0
+
+				continue
+			}
+
+			switch {
+			case part.Len() == 0:
+				part.WriteRune(unicode.ToLower(char))
+			case upper:
+				part.WriteRune(unicode.ToUpper(char))
+			default:
+				part.WriteRune(char)
+			}
+
+			upper = false
+		}
+
+		if part.Len() > 0 {
+			parts = append(parts, part.String())
+		}
+	}
+
+	return strings.Join(parts, ".")
+}
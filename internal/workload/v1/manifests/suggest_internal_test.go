@@ -0,0 +1,190 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package manifests
+
+import (
+	"reflect"
+	"testing"
+)
+
+const (
+	testSuggestDeployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web-app
+  namespace: acme
+spec:
+  replicas: 2
+  template:
+    spec:
+      containers:
+        - name: web
+          image: nginx:1.0
+          resources:
+            requests:
+              cpu: 100m
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web-app
+  namespace: acme
+spec:
+  type: ClusterIP # keep this comment
+`
+	testSuggestStatefulSet = `apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: worker
+  namespace: acme
+spec:
+  replicas: 1 # +operator-builder:field:name=worker.replicas,default=1,type=int
+  template:
+    spec:
+      containers:
+        - name: web
+          image: nginx:1.0
+        - name: web
+          image: nginx:2.0
+  volumeClaimTemplates:
+    - metadata:
+        name: data
+      spec:
+        resources:
+          requests:
+            storage: 1Gi
+`
+)
+
+func TestMarkerSuggester_Suggest(t *testing.T) {
+	t.Parallel()
+
+	suggester := NewMarkerSuggester()
+
+	type suggestion struct {
+		line   int
+		marker string
+	}
+
+	// the files are suggested in order with a single suggester, so they may not be run in parallel
+	for _, tt := range []struct {
+		name    string
+		content string
+		want    []suggestion
+	}{
+		{
+			name:    "ensure well-known values are suggested",
+			content: testSuggestDeployment,
+			want: []suggestion{
+				{5, `+operator-builder:field:name=namespace,default="acme",type=string`},
+				{7, `+operator-builder:field:name=webApp.replicas,default=2,type=int`},
+				{12, `+operator-builder:field:name=web.image,default="nginx:1.0",type=string`},
+				{15, `+operator-builder:field:name=web.resources.requests.cpu,default="100m",type=string`},
+				{21, `+operator-builder:field:name=namespace,default="acme",type=string`},
+			},
+		},
+		{
+			name:    "ensure values are deduplicated and names are consistent across files",
+			content: testSuggestStatefulSet,
+			want: []suggestion{
+				{5, `+operator-builder:field:name=namespace,default="acme",type=string`},
+				{12, `+operator-builder:field:name=web.image,default="nginx:1.0",type=string`},
+				{14, `+operator-builder:field:name=worker.web.image,default="nginx:2.0",type=string`},
+				{21, `+operator-builder:field:name=workerData.storageSize,default="1Gi",type=string`},
+			},
+		},
+		{
+			name:    "ensure json documents are skipped",
+			content: `{"apiVersion": "v1", "kind": "Namespace", "metadata": {"name": "acme"}}`,
+		},
+	} {
+		got, err := suggester.Suggest([]byte(tt.content))
+		if err != nil {
+			t.Fatalf("%s: Suggest() error = %v", tt.name, err)
+		}
+
+		var gotSuggestions []suggestion
+
+		for _, s := range got {
+			gotSuggestions = append(gotSuggestions, suggestion{s.Line, s.Marker})
+		}
+
+		if !reflect.DeepEqual(gotSuggestions, tt.want) {
+			t.Errorf("%s: Suggest() = %v, want %v", tt.name, gotSuggestions, tt.want)
+		}
+	}
+}
+
+func TestApplySuggestions(t *testing.T) {
+	t.Parallel()
+
+	content := "kind: Service\nspec:\n  type: ClusterIP\n"
+	suggestions := []*Suggestion{
+		{Line: 3, Marker: "+operator-builder:field:name=serviceType,default=\"ClusterIP\",type=string"},
+	}
+
+	want := "kind: Service\nspec:\n  type: ClusterIP # +operator-builder:field:name=serviceType,default=\"ClusterIP\",type=string\n"
+
+	updated := ApplySuggestions([]byte(content), suggestions)
+	if string(updated) != want {
+		t.Fatalf("ApplySuggestions() = %q, want %q", updated, want)
+	}
+
+	if again := ApplySuggestions(updated, suggestions); string(again) != want {
+		t.Errorf("ApplySuggestions() applied twice = %q, want %q", again, want)
+	}
+
+	wantPatch := `--- service.yaml
++++ service.yaml
+@@ -1,3 +1,3 @@
+ kind: Service
+ spec:
+-  type: ClusterIP
++  type: ClusterIP # +operator-builder:field:name=serviceType,default="ClusterIP",type=string
+`
+
+	if patch := SuggestionPatch("service.yaml", []byte(content), updated); patch != wantPatch {
+		t.Errorf("SuggestionPatch() = %q, want %q", patch, wantPatch)
+	}
+
+	if patch := SuggestionPatch("service.yaml", []byte(content), []byte(content)); patch != "" {
+		t.Errorf("SuggestionPatch() without changes = %q, want empty", patch)
+	}
+}
+
+func Test_lowerCamel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		words []string
+		want  string
+	}{
+		{
+			name:  "ensure kebab case words are converted",
+			words: []string{"web-app", "replicas"},
+			want:  "webApp.replicas",
+		},
+		{
+			name:  "ensure empty words are ignored",
+			words: []string{"", "web", "image"},
+			want:  "web.image",
+		},
+		{
+			name:  "ensure leading capitals are lowered",
+			words: []string{"WebApp", "ephemeral-storage"},
+			want:  "webApp.ephemeralStorage",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := lowerCamel(tt.words...); got != tt.want {
+				t.Errorf("lowerCamel() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
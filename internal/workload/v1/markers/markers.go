@@ -32,6 +32,7 @@ const (
 	FieldMarkerType MarkerType = iota
 	CollectionMarkerType
 	ResourceMarkerType
+	WebhookMarkerType
 	UnknownMarkerType
 )
 
@@ -208,6 +209,10 @@ func initializeMarkerInspector(markerTypes ...MarkerType) (*inspect.Inspector, e
 			err = defineCollectionFieldMarker(registry)
 		case ResourceMarkerType:
 			err = defineResourceMarker(registry)
+		case WebhookMarkerType:
+			if err = defineWebhookValidateMarker(registry); err == nil {
+				err = defineWebhookDefaultMarker(registry)
+			}
 		}
 	}
 
@@ -242,6 +247,22 @@ func transformYAML(results ...*inspect.YAMLResult) error {
 
 			t.sourceCodeVar = sourceCodeVar
 			marker = &t
+		case WebhookValidateMarker:
+			if err := t.Validate(); err != nil {
+				return err
+			}
+
+			result.Object = &t
+
+			continue
+		case WebhookDefaultMarker:
+			if err := t.Validate(); err != nil {
+				return err
+			}
+
+			result.Object = &t
+
+			continue
 		default:
 			continue
 		}
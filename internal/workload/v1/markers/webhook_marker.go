@@ -0,0 +1,222 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package markers
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/nukleros/markers/marker"
+)
+
+var (
+	ErrWebhookMarkerMissingField = errors.New("webhook marker missing 'field' value")
+	ErrWebhookMarkerMissingRule  = errors.New("webhook marker has nothing to do")
+)
+
+const (
+	WebhookValidateMarkerPrefix = "+operator-builder:webhook:validate"
+	WebhookDefaultMarkerPrefix  = "+operator-builder:webhook:default"
+
+	// the api version of a referenced object when none is given.
+	defaultReferenceAPIVersion = "v1"
+)
+
+// WebhookValidateMarker is an object which represents a marker that generates the logic of the
+// validating webhook for an API spec field.  It is meant for the validations that cannot be
+// expressed in the schema of the API, such as those which look up other objects in the cluster.
+// A WebhookValidateMarker is discovered when a manifest is parsed and matches the constants
+// defined by the webhookValidateMarker constant above.
+type WebhookValidateMarker struct {
+	// inputs from the marker itself
+	Field         *string
+	Reference     *string
+	APIVersion    *string `marker:"apiVersion"`
+	ClusterScoped *bool
+	Unique        *bool
+	Message       *string
+}
+
+// String simply returns the marker as it should be printed in string format.
+func (wvm WebhookValidateMarker) String() string {
+	return fmt.Sprintf("WebhookValidateMarker{Field: %s Reference: %s APIVersion: %s Unique: %v}",
+		wvm.GetField(),
+		wvm.GetReference(),
+		wvm.GetAPIVersion(),
+		wvm.IsUnique(),
+	)
+}
+
+// defineWebhookValidateMarker will define a WebhookValidateMarker and add it a registry of markers.
+func defineWebhookValidateMarker(registry *marker.Registry) error {
+	webhookValidateMarker, err := marker.Define(WebhookValidateMarkerPrefix, WebhookValidateMarker{})
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	registry.Add(webhookValidateMarker)
+
+	return nil
+}
+
+// Validate ensures that the marker names a field and at least one validation of the field.
+func (wvm *WebhookValidateMarker) Validate() error {
+	if wvm.GetField() == "" {
+		return fmt.Errorf("%w for marker %s", ErrWebhookMarkerMissingField, wvm)
+	}
+
+	if wvm.GetReference() == "" && !wvm.IsUnique() {
+		return fmt.Errorf("%w, expected 'reference' or 'unique' for marker %s", ErrWebhookMarkerMissingRule, wvm)
+	}
+
+	return nil
+}
+
+// GetField is a convenience function to return the api field which is validated.
+func (wvm *WebhookValidateMarker) GetField() string {
+	if wvm.Field == nil {
+		return ""
+	}
+
+	return *wvm.Field
+}
+
+// GetReference is a convenience function to return the kind of the object which is referenced
+// by name by the api field.
+func (wvm *WebhookValidateMarker) GetReference() string {
+	if wvm.Reference == nil {
+		return ""
+	}
+
+	return *wvm.Reference
+}
+
+// GetAPIVersion is a convenience function to return the api version of the referenced object.
+func (wvm *WebhookValidateMarker) GetAPIVersion() string {
+	if wvm.APIVersion == nil {
+		return defaultReferenceAPIVersion
+	}
+
+	return *wvm.APIVersion
+}
+
+// GetMessage is a convenience function to return the message which is returned when the
+// validation fails.
+func (wvm *WebhookValidateMarker) GetMessage() string {
+	if wvm.Message == nil {
+		return ""
+	}
+
+	return *wvm.Message
+}
+
+// IsClusterScoped returns whether the referenced object is cluster scoped rather than in the
+// namespace of the custom resource.
+func (wvm *WebhookValidateMarker) IsClusterScoped() bool {
+	if wvm.ClusterScoped == nil {
+		return false
+	}
+
+	return *wvm.ClusterScoped
+}
+
+// IsUnique returns whether the value of the api field must be unique among the custom resources
+// of the same kind.
+func (wvm *WebhookValidateMarker) IsUnique() bool {
+	if wvm.Unique == nil {
+		return false
+	}
+
+	return *wvm.Unique
+}
+
+// WebhookDefaultMarker is an object which represents a marker that generates the logic of the
+// defaulting webhook for an API spec field.  It is meant for the defaults that cannot be
+// expressed in the schema of the API, such as merging the default entries of a map into the
+// entries which are given, or computing a default from another field.  A WebhookDefaultMarker
+// is discovered when a manifest is parsed and matches the constants defined by the
+// webhookDefaultMarker constant above.
+type WebhookDefaultMarker struct {
+	// inputs from the marker itself
+	Field  *string
+	Merge  *bool
+	From   *string
+	Format *string
+}
+
+// String simply returns the marker as it should be printed in string format.
+func (wdm WebhookDefaultMarker) String() string {
+	return fmt.Sprintf("WebhookDefaultMarker{Field: %s Merge: %v From: %s Format: %q}",
+		wdm.GetField(),
+		wdm.IsMerge(),
+		wdm.GetFrom(),
+		wdm.GetFormat(),
+	)
+}
+
+// defineWebhookDefaultMarker will define a WebhookDefaultMarker and add it a registry of markers.
+func defineWebhookDefaultMarker(registry *marker.Registry) error {
+	webhookDefaultMarker, err := marker.Define(WebhookDefaultMarkerPrefix, WebhookDefaultMarker{})
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	registry.Add(webhookDefaultMarker)
+
+	return nil
+}
+
+// Validate ensures that the marker names a field and exactly one way to default the field.
+func (wdm *WebhookDefaultMarker) Validate() error {
+	if wdm.GetField() == "" {
+		return fmt.Errorf("%w for marker %s", ErrWebhookMarkerMissingField, wdm)
+	}
+
+	if wdm.IsMerge() == (wdm.GetFrom() != "") {
+		return fmt.Errorf("%w, expected one of 'merge' or 'from' for marker %s", ErrWebhookMarkerMissingRule, wdm)
+	}
+
+	if wdm.GetFormat() != "" && wdm.GetFrom() == "" {
+		return fmt.Errorf("%w, 'format' requires 'from' for marker %s", ErrWebhookMarkerMissingRule, wdm)
+	}
+
+	return nil
+}
+
+// GetField is a convenience function to return the api field which is defaulted.
+func (wdm *WebhookDefaultMarker) GetField() string {
+	if wdm.Field == nil {
+		return ""
+	}
+
+	return *wdm.Field
+}
+
+// GetFrom is a convenience function to return the field from which the default is computed.
+func (wdm *WebhookDefaultMarker) GetFrom() string {
+	if wdm.From == nil {
+		return ""
+	}
+
+	return *wdm.From
+}
+
+// GetFormat is a convenience function to return the format with which the default is computed.
+func (wdm *WebhookDefaultMarker) GetFormat() string {
+	if wdm.Format == nil {
+		return ""
+	}
+
+	return *wdm.Format
+}
+
+// IsMerge returns whether the default entries of a map are merged into the entries which are
+// given.
+func (wdm *WebhookDefaultMarker) IsMerge() bool {
+	if wdm.Merge == nil {
+		return false
+	}
+
+	return *wdm.Merge
+}
@@ -0,0 +1,174 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package markers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInspectForYAML_webhookMarkers(t *testing.T) {
+	t.Parallel()
+
+	manifest := []byte(`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: webapp
+spec:
+  template:
+    spec:
+      serviceAccountName: webapp # +operator-builder:field:name=serviceAccount,type=string
+      # +operator-builder:webhook:validate:field=serviceAccount,reference=ServiceAccount
+      # +operator-builder:webhook:validate:field=hostname,unique=true,message="hostname is in use"
+      # +operator-builder:webhook:validate:field=issuer,reference=ClusterIssuer,apiVersion=cert-manager.io/v1,clusterScoped=true
+      # +operator-builder:webhook:default:field=labels,merge=true
+      # +operator-builder:webhook:default:field=hostname,from=metadata.name,format="%s.acme.com"
+      containers: []
+`)
+
+	_, results, err := InspectForYAML(manifest, FieldMarkerType, WebhookMarkerType)
+	require.NoError(t, err)
+
+	validations := []*WebhookValidateMarker{}
+	defaults := []*WebhookDefaultMarker{}
+
+	for _, result := range results {
+		switch marker := result.Object.(type) {
+		case *WebhookValidateMarker:
+			validations = append(validations, marker)
+		case *WebhookDefaultMarker:
+			defaults = append(defaults, marker)
+		}
+	}
+
+	require.Len(t, validations, 3)
+	assert.Equal(t, "serviceAccount", validations[0].GetField())
+	assert.Equal(t, "ServiceAccount", validations[0].GetReference())
+	assert.Equal(t, "v1", validations[0].GetAPIVersion())
+	assert.False(t, validations[0].IsUnique())
+	assert.Equal(t, "hostname", validations[1].GetField())
+	assert.True(t, validations[1].IsUnique())
+	assert.Equal(t, "hostname is in use", validations[1].GetMessage())
+	assert.Equal(t, "ClusterIssuer", validations[2].GetReference())
+	assert.Equal(t, "cert-manager.io/v1", validations[2].GetAPIVersion())
+	assert.True(t, validations[2].IsClusterScoped())
+
+	require.Len(t, defaults, 2)
+	assert.Equal(t, "labels", defaults[0].GetField())
+	assert.True(t, defaults[0].IsMerge())
+	assert.Equal(t, "hostname", defaults[1].GetField())
+	assert.Equal(t, "metadata.name", defaults[1].GetFrom())
+	assert.Equal(t, "%s.acme.com", defaults[1].GetFormat())
+}
+
+func TestWebhookValidateMarker_Validate(t *testing.T) {
+	t.Parallel()
+
+	field := "serviceAccount"
+	reference := "ServiceAccount"
+	unique := true
+
+	tests := []struct {
+		name    string
+		marker  *WebhookValidateMarker
+		wantErr error
+	}{
+		{
+			name:   "reference",
+			marker: &WebhookValidateMarker{Field: &field, Reference: &reference},
+		},
+		{
+			name:   "unique",
+			marker: &WebhookValidateMarker{Field: &field, Unique: &unique},
+		},
+		{
+			name:    "missing field",
+			marker:  &WebhookValidateMarker{Reference: &reference},
+			wantErr: ErrWebhookMarkerMissingField,
+		},
+		{
+			name:    "missing validation",
+			marker:  &WebhookValidateMarker{Field: &field},
+			wantErr: ErrWebhookMarkerMissingRule,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.marker.Validate()
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestWebhookDefaultMarker_Validate(t *testing.T) {
+	t.Parallel()
+
+	field := "hostname"
+	from := "metadata.name"
+	format := "%s.acme.com"
+	merge := true
+
+	tests := []struct {
+		name    string
+		marker  *WebhookDefaultMarker
+		wantErr error
+	}{
+		{
+			name:   "merge",
+			marker: &WebhookDefaultMarker{Field: &field, Merge: &merge},
+		},
+		{
+			name:   "computed",
+			marker: &WebhookDefaultMarker{Field: &field, From: &from, Format: &format},
+		},
+		{
+			name:    "missing field",
+			marker:  &WebhookDefaultMarker{Merge: &merge},
+			wantErr: ErrWebhookMarkerMissingField,
+		},
+		{
+			name:    "missing default",
+			marker:  &WebhookDefaultMarker{Field: &field},
+			wantErr: ErrWebhookMarkerMissingRule,
+		},
+		{
+			name:    "merged and computed",
+			marker:  &WebhookDefaultMarker{Field: &field, Merge: &merge, From: &from},
+			wantErr: ErrWebhookMarkerMissingRule,
+		},
+		{
+			name:    "format without from",
+			marker:  &WebhookDefaultMarker{Field: &field, Merge: &merge, Format: &format},
+			wantErr: ErrWebhookMarkerMissingRule,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.marker.Validate()
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -43,6 +43,16 @@ func defaultResourceVerbs() []string {
 	}
 }
 
+// minimalResourceVerbs is a helper function to define the verbs which the scaffolded controller
+// uses to manage its resources.  Resources are read and watched through the cache, created when
+// they do not exist and patched when they differ from their desired state, whereas they are
+// deleted by garbage collection rather than by the controller.
+func minimalResourceVerbs() []string {
+	return []string{
+		"get", "list", "watch", "create", "patch",
+	}
+}
+
 // defaultStatusVerbs is a helper function to define the default verbs which get placed
 // onto resources that have a /status suffix.
 func defaultStatusVerbs() []string {
@@ -51,6 +61,14 @@ func defaultStatusVerbs() []string {
 	}
 }
 
+// dependencyVerbs is a helper function to define the verbs which are allowed for resources
+// that are not managed by the scaffolded controller but are depended upon by it.
+func dependencyVerbs() []string {
+	return []string{
+		"get", "list", "watch",
+	}
+}
+
 // knownIrregulars is a helper function to define known irregular kinds and their
 // expected formats.
 //   - keys   = found values
@@ -71,7 +89,21 @@ func knownIrregulars() map[string]string {
 func ForResource(manifest *unstructured.Unstructured, markerByVar map[string]*markers.FieldMarker) (*Rules, error) {
 	rules := &Rules{}
 
-	if err := rules.addForResource(manifest, markerByVar); err != nil {
+	if err := rules.addForResource(manifest, markerByVar, defaultResourceVerbs()); err != nil {
+		return rules, err
+	}
+
+	return rules, nil
+}
+
+// ForMinimalResource will return the same set of rules as ForResource, except that the rule for
+// the resource itself only includes the verbs which the scaffolded controller uses.  The rules
+// that roles and cluster roles are requesting are unchanged, as the controller may only grant
+// the permissions which it holds itself.
+func ForMinimalResource(manifest *unstructured.Unstructured, markerByVar map[string]*markers.FieldMarker) (*Rules, error) {
+	rules := &Rules{}
+
+	if err := rules.addForResource(manifest, markerByVar, minimalResourceVerbs()); err != nil {
 		return rules, err
 	}
 
@@ -92,6 +124,18 @@ func ForWorkloads(workloads ...rbacWorkloadProcessor) *Rules {
 	return rules
 }
 
+// ForDependency will return a set of rules which allow a controller to read a resource that it
+// does not manage, so that it may determine whether the resource satisfies a dependency.
+func ForDependency(group, kind string) *Rules {
+	return &Rules{
+		{
+			Group:    getGroup(group),
+			Resource: getResource(kind),
+			Verbs:    dependencyVerbs(),
+		},
+	}
+}
+
 // getGroup returns the group in the proper format as expected by rbac markers.
 func getGroup(group string) string {
 	if group == "" {
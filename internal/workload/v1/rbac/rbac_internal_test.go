@@ -6,6 +6,8 @@ package rbac
 import (
 	"reflect"
 	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 func Test_getGroup(t *testing.T) {
@@ -86,6 +88,103 @@ func Test_getFieldString(t *testing.T) {
 	}
 }
 
+func TestForDependency(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		group string
+		kind  string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want *Rules
+	}{
+		{
+			name: "ensure dependency in a named group returns a read only rule",
+			args: args{
+				group: "cert-manager.io",
+				kind:  "ClusterIssuer",
+			},
+			want: &Rules{
+				{
+					Group:    "cert-manager.io",
+					Resource: "clusterissuers",
+					Verbs:    []string{"get", "list", "watch"},
+				},
+			},
+		},
+		{
+			name: "ensure dependency in the core group returns a read only rule",
+			args: args{
+				group: "",
+				kind:  "Secret",
+			},
+			want: &Rules{
+				{
+					Group:    "core",
+					Resource: "secrets",
+					Verbs:    []string{"get", "list", "watch"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := ForDependency(tt.args.group, tt.args.kind); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ForDependency() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestForMinimalResource(t *testing.T) {
+	t.Parallel()
+
+	role := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "rbac.authorization.k8s.io/v1",
+			"kind":       "Role",
+			"metadata": map[string]interface{}{
+				"name": "role",
+			},
+			"rules": []interface{}{
+				map[string]interface{}{
+					"apiGroups": []interface{}{""},
+					"resources": []interface{}{"pods"},
+					"verbs":     []interface{}{"get", "delete"},
+				},
+			},
+		},
+	}
+
+	want := &Rules{
+		{
+			Group:    "rbac.authorization.k8s.io",
+			Resource: "roles",
+			Verbs:    []string{"get", "list", "watch", "create", "patch"},
+		},
+		{
+			Group:    "core",
+			Resource: "pods",
+			Verbs:    []string{"get", "delete"},
+		},
+	}
+
+	got, err := ForMinimalResource(role, nil)
+	if err != nil {
+		t.Fatalf("ForMinimalResource() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ForMinimalResource() = %v, want %v", got, want)
+	}
+}
+
 func Test_getResource(t *testing.T) {
 	t.Parallel()
 
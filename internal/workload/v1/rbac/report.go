@@ -0,0 +1,149 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package rbac
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported rbac report format")
+
+const (
+	FormatYAML  = "yaml"
+	FormatTable = "table"
+)
+
+// Formats returns the formats in which an rbac report may be written.
+func Formats() []string {
+	return []string{FormatYAML, FormatTable}
+}
+
+// Grant is a set of rules which are granted to a controller for a single source, such as the API
+// of its workload, one of its child resources or one of its extra rules.  The rules of a grant for
+// cluster scoped objects are always granted by a cluster role.
+type Grant struct {
+	Manifest      string
+	Source        string
+	Rules         *Rules
+	ClusterScoped bool
+}
+
+// Report contains the rules which are granted to the controllers of a set of workloads, along
+// with the source of each of the rules.
+type Report struct {
+	Rules []*ReportRule `yaml:"rules"`
+}
+
+// ReportRule is a single rule within a report.  The marker is the kubebuilder rbac marker which
+// is generated for the rule.
+type ReportRule struct {
+	Workload        string   `yaml:"workload"`
+	Source          string   `yaml:"source"`
+	Manifest        string   `yaml:"manifest,omitempty"`
+	APIGroup        string   `yaml:"apiGroup,omitempty"`
+	Resource        string   `yaml:"resource,omitempty"`
+	NonResourceURLs []string `yaml:"nonResourceURLs,omitempty,flow"`
+	Verbs           []string `yaml:"verbs,flow"`
+	Namespace       string   `yaml:"namespace,omitempty"`
+	Marker          string   `yaml:"marker"`
+	Wildcard        bool     `yaml:"wildcard,omitempty"`
+}
+
+// Add adds the rules of the grants of a workload to a report.
+func (report *Report) Add(workload string, grants ...*Grant) {
+	for _, grant := range grants {
+		for i := range *grant.Rules {
+			rule := (*grant.Rules)[i]
+
+			report.Rules = append(report.Rules, &ReportRule{
+				Workload:        workload,
+				Source:          grant.Source,
+				Manifest:        grant.Manifest,
+				APIGroup:        rule.Group,
+				Resource:        rule.Resource,
+				NonResourceURLs: rule.URLs,
+				Verbs:           rule.Verbs,
+				Namespace:       rule.Namespace,
+				Marker:          strings.TrimPrefix(rule.ToMarker(), "// "),
+				Wildcard:        rule.HasWildcard(),
+			})
+		}
+	}
+}
+
+// Wildcards returns the rules within a report which grant access by way of a wildcard.
+func (report *Report) Wildcards() []*ReportRule {
+	wildcards := []*ReportRule{}
+
+	for _, rule := range report.Rules {
+		if rule.Wildcard {
+			wildcards = append(wildcards, rule)
+		}
+	}
+
+	return wildcards
+}
+
+// Write writes the report to a writer in the requested format.
+func (report *Report) Write(w io.Writer, format string) error {
+	var content string
+
+	switch format {
+	case FormatYAML:
+		var buffer bytes.Buffer
+
+		encoder := yaml.NewEncoder(&buffer)
+		encoder.SetIndent(2)
+
+		if err := encoder.Encode(report); err != nil {
+			return fmt.Errorf("%w; unable to marshal rbac report to yaml", err)
+		}
+
+		content = buffer.String()
+	case FormatTable:
+		content = report.table()
+	default:
+		return fmt.Errorf("%w [%s]; supported formats are [%s]", ErrUnsupportedFormat, format, strings.Join(Formats(), ", "))
+	}
+
+	if _, err := io.WriteString(w, content); err != nil {
+		return fmt.Errorf("%w; unable to write rbac report", err)
+	}
+
+	return nil
+}
+
+// table returns the report as a table with a row for each rule.
+func (report *Report) table() string {
+	var buffer bytes.Buffer
+
+	table := tabwriter.NewWriter(&buffer, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(table, "WORKLOAD\tSOURCE\tMANIFEST\tMARKER\tWILDCARD")
+
+	for _, rule := range report.Rules {
+		manifest := rule.Manifest
+		if manifest == "" {
+			manifest = "-"
+		}
+
+		wildcard := "-"
+		if rule.Wildcard {
+			wildcard = "yes"
+		}
+
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\n", rule.Workload, rule.Source, manifest, rule.Marker, wildcard)
+	}
+
+	_ = table.Flush()
+
+	return buffer.String()
+}
@@ -0,0 +1,109 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package rbac
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func NewTestReport() *Report {
+	report := &Report{}
+
+	report.Add("webapp",
+		&Grant{
+			Source: "api",
+			Rules:  &Rules{{Group: "apps.acme.com", Resource: "webapps", Verbs: []string{"get", "list"}}},
+		},
+		&Grant{
+			Manifest: "app.yaml",
+			Source:   "ClusterRole/admin",
+			Rules:    &Rules{{Group: "*", Resource: "*", Verbs: []string{"*"}}},
+		},
+	)
+
+	return report
+}
+
+func TestReport_Add(t *testing.T) {
+	t.Parallel()
+
+	report := NewTestReport()
+
+	require.Len(t, report.Rules, 2)
+	assert.Equal(t, &ReportRule{
+		Workload: "webapp",
+		Source:   "api",
+		APIGroup: "apps.acme.com",
+		Resource: "webapps",
+		Verbs:    []string{"get", "list"},
+		Marker:   "+kubebuilder:rbac:groups=apps.acme.com,resources=webapps,verbs=get;list",
+	}, report.Rules[0])
+	assert.Equal(t, "app.yaml", report.Rules[1].Manifest)
+	assert.True(t, report.Rules[1].Wildcard)
+
+	wildcards := report.Wildcards()
+	require.Len(t, wildcards, 1)
+	assert.Equal(t, "ClusterRole/admin", wildcards[0].Source)
+}
+
+func TestReport_Write(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		format   string
+		contains []string
+		wantErr  bool
+	}{
+		{
+			name:   "ensure yaml report contains each rule",
+			format: FormatYAML,
+			contains: []string{
+				"rules:\n  - workload: webapp\n    source: api\n",
+				"    manifest: app.yaml\n",
+				"    wildcard: true\n",
+			},
+		},
+		{
+			name:   "ensure table report contains a row for each rule",
+			format: FormatTable,
+			contains: []string{
+				"WORKLOAD  SOURCE",
+				"webapp    api                -         +kubebuilder:rbac:groups=apps.acme.com,resources=webapps,verbs=get;list  -",
+				"webapp    ClusterRole/admin  app.yaml  +kubebuilder:rbac:groups=*,resources=*,verbs=*",
+			},
+		},
+		{
+			name:    "ensure unsupported format returns an error",
+			format:  "json",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buffer bytes.Buffer
+
+			err := NewTestReport().Write(&buffer, tt.format)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrUnsupportedFormat)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			for _, want := range tt.contains {
+				assert.Contains(t, buffer.String(), want)
+			}
+		})
+	}
+}
@@ -5,15 +5,17 @@ package rbac
 
 import (
 	"fmt"
+	"strings"
 )
 
 // Rule contains the info needed to create the kubebuilder:rbac markers in
 // the controller.
 type Rule struct {
-	Group    string
-	Resource string
-	URLs     []string
-	Verbs    []string
+	Group     string
+	Resource  string
+	Namespace string
+	URLs      []string
+	Verbs     []string
 }
 
 // ToMarker will return a specific marker in string format.
@@ -26,6 +28,16 @@ func (rule *Rule) ToMarker() string {
 		)
 	}
 
+	if rule.Namespace != "" {
+		return fmt.Sprintf("%s:groups=%s,namespace=%s,resources=%s,verbs=%s",
+			kubebuilderPrefix,
+			rule.Group,
+			rule.Namespace,
+			rule.Resource,
+			getFieldString(rule.Verbs),
+		)
+	}
+
 	return fmt.Sprintf("%s:groups=%s,resources=%s,verbs=%s",
 		kubebuilderPrefix,
 		rule.Group,
@@ -105,12 +117,34 @@ func (rule *Rule) addVerb(verb string) {
 }
 
 // groupResourceEqual determines if the group and resource are equal given an
-// input rule.
+// input rule.  Rules for different namespaces are never equal.
 func (rule *Rule) groupResourceEqual(compared *Rule) bool {
-	if rule.Group == compared.Group && rule.Resource == compared.Resource {
+	if rule.Group == compared.Group && rule.Resource == compared.Resource && rule.Namespace == compared.Namespace {
+		return true
+	}
+
+	return false
+}
+
+// HasWildcard determines if a rule grants access to all groups, resources, verbs or non-resource
+// urls by way of a wildcard.
+func (rule *Rule) HasWildcard() bool {
+	if rule.Group == "*" || strings.HasPrefix(rule.Resource, "*") {
 		return true
 	}
 
+	for _, verb := range rule.Verbs {
+		if verb == "*" {
+			return true
+		}
+	}
+
+	for _, url := range rule.URLs {
+		if strings.HasSuffix(url, "*") {
+			return true
+		}
+	}
+
 	return false
 }
 
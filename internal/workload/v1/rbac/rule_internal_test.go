@@ -51,6 +51,16 @@ func TestRule_ToMarker(t *testing.T) {
 			rule: NewTestNonResourceRule(),
 			want: "// +kubebuilder:rbac:verbs=get;patch,urls=/metrics",
 		},
+		{
+			name: "ensure namespaced resource rbac marker returns as expected",
+			rule: &Rule{
+				Group:     "apps",
+				Resource:  "deployments",
+				Namespace: "system",
+				Verbs:     []string{"get", "patch"},
+			},
+			want: "// +kubebuilder:rbac:groups=apps,namespace=system,resources=deployments,verbs=get;patch",
+		},
 	}
 
 	for _, tt := range tests {
@@ -444,10 +454,11 @@ func TestRule_groupResourceEqual(t *testing.T) {
 	t.Parallel()
 
 	type fields struct {
-		Group    string
-		Resource string
-		URLs     []string
-		Verbs    []string
+		Group     string
+		Resource  string
+		Namespace string
+		URLs      []string
+		Verbs     []string
 	}
 
 	type args struct {
@@ -504,6 +515,18 @@ func TestRule_groupResourceEqual(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name: "ensure rule with not equal namespace returns false",
+			fields: fields{
+				Group:     "core",
+				Resource:  "exampleresources",
+				Namespace: "system",
+			},
+			args: args{
+				compared: NewTestRule(),
+			},
+			want: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -511,10 +534,11 @@ func TestRule_groupResourceEqual(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			rule := &Rule{
-				Group:    tt.fields.Group,
-				Resource: tt.fields.Resource,
-				URLs:     tt.fields.URLs,
-				Verbs:    tt.fields.Verbs,
+				Group:     tt.fields.Group,
+				Resource:  tt.fields.Resource,
+				Namespace: tt.fields.Namespace,
+				URLs:      tt.fields.URLs,
+				Verbs:     tt.fields.Verbs,
 			}
 			if got := rule.groupResourceEqual(tt.args.compared); got != tt.want {
 				t.Errorf("Rule.groupResourceEqual() = %v, want %v", got, tt.want)
@@ -575,3 +599,49 @@ func TestRule_isResourceRule(t *testing.T) {
 		})
 	}
 }
+
+func TestRule_HasWildcard(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		rule *Rule
+		want bool
+	}{
+		{
+			name: "ensure rule without wildcard returns false",
+			rule: NewTestRule(),
+			want: false,
+		},
+		{
+			name: "ensure rule with wildcard group returns true",
+			rule: &Rule{Group: "*", Resource: "pods", Verbs: []string{"get"}},
+			want: true,
+		},
+		{
+			name: "ensure rule with wildcard resource returns true",
+			rule: &Rule{Group: "core", Resource: "*/status", Verbs: []string{"get"}},
+			want: true,
+		},
+		{
+			name: "ensure rule with wildcard verb returns true",
+			rule: &Rule{Group: "core", Resource: "pods", Verbs: []string{"*"}},
+			want: true,
+		},
+		{
+			name: "ensure rule with wildcard url returns true",
+			rule: &Rule{URLs: []string{"/logs/*"}, Verbs: []string{"get"}},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.rule.HasWildcard(); got != tt.want {
+				t.Errorf("Rule.HasWildcard() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -55,15 +55,20 @@ func (rules *Rules) addForWorkload(workload rbacWorkloadProcessor) {
 	rules.Add(workloadRule, statusRule)
 }
 
-// addForResource will add a particular rule given an unstructured manifest.
-func (rules *Rules) addForResource(manifest *unstructured.Unstructured, markerByVar map[string]*markers.FieldMarker) error {
+// addForResource will add a particular rule given an unstructured manifest and the verbs which
+// are allowed for the resource itself.
+func (rules *Rules) addForResource(
+	manifest *unstructured.Unstructured,
+	markerByVar map[string]*markers.FieldMarker,
+	verbs []string,
+) error {
 	kind := manifest.GetKind()
 
 	rules.Add(
 		&Rule{
 			Group:    getGroup(manifest.GroupVersionKind().Group),
 			Resource: getResource(kind),
-			Verbs:    defaultResourceVerbs(),
+			Verbs:    verbs,
 		},
 	)
 
@@ -93,6 +98,26 @@ func (rules *Rules) addForResource(manifest *unstructured.Unstructured, markerBy
 	return nil
 }
 
+// SetNamespace scopes each of the resource rules within a set of rules to a namespace, so that
+// they are granted by a role rather than a cluster role.  Non-resource rules, along with the rules
+// for the cluster scoped resources which are given, may only be granted by a cluster role and are
+// left unchanged.
+func (rules *Rules) SetNamespace(namespace string, clusterScoped *Rules) {
+	rs := *rules
+
+	for i := range rs {
+		if !rs[i].isResourceRule() {
+			continue
+		}
+
+		if clusterScoped != nil && clusterScoped.hasResourceRule(&rs[i]) {
+			continue
+		}
+
+		rs[i].Namespace = namespace
+	}
+}
+
 // hasResourceRule determines if a set of rules has a rule which contains
 // a specific group/resource combination.  A specific group/resource combination
 // is used to guarantee uniqueness on a set of rules.
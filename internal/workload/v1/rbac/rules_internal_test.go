@@ -55,6 +55,24 @@ func TestRules_hasResourceRule(t *testing.T) {
 	}
 }
 
+func TestRules_SetNamespace(t *testing.T) {
+	t.Parallel()
+
+	rules := NewTestRules()
+	rules.SetNamespace("system", nil)
+
+	assert.Equal(t, "system", (*rules)[0].Namespace)
+	assert.Equal(t, "", (*rules)[1].Namespace)
+
+	clusterScoped := &Rules{{Group: (*rules)[0].Group, Resource: (*rules)[0].Resource}}
+
+	rules = NewTestRules()
+	rules.SetNamespace("system", clusterScoped)
+
+	assert.Equal(t, "", (*rules)[0].Namespace)
+	assert.Equal(t, "", (*rules)[1].Namespace)
+}
+
 func TestRules_addForManifest(t *testing.T) {
 	t.Parallel()
 
@@ -284,7 +302,7 @@ func TestRules_addForManifest(t *testing.T) {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			if err := tt.rules.addForResource(tt.args.manifest, nil); (err != nil) != tt.wantErr {
+			if err := tt.rules.addForResource(tt.args.manifest, nil, defaultResourceVerbs()); (err != nil) != tt.wantErr {
 				t.Errorf("Rules.addForManifest() error = %v, wantErr %v", err, tt.wantErr)
 			}
 			assert.Equal(t, tt.want, tt.rules)
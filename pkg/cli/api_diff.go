@@ -0,0 +1,100 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nukleros/operator-builder/internal/plugins/workload"
+	"github.com/nukleros/operator-builder/internal/workload/v1/commands/subcommand"
+)
+
+var ErrAPIDiffCommand = errors.New("error executing `api-diff` command")
+
+const (
+	apiDiffName        = "api-diff"
+	apiDiffDescription = "Detect breaking changes to the APIs of a workload config"
+	apiDiffLong        = `Compare the API spec fields which are generated from a workload config with those
+which were generated from a previous generation of the workload config.  The previous
+generation is either the same workload config at a previous git revision, given with
+--revision, or the workload config of another version of the APIs, given with
+--previous-workload-config.
+
+Each change to a field is classified as one of:
+
+  additive       a field was added, which is breaking if the field is required
+  defaulted      the default of a field was added, changed or removed, which is
+                 breaking unless a default was added to a required field
+  narrowed type  the type of a field changed, which is always breaking
+  removed        a field was removed, which is always breaking
+  renamed        a field was renamed, which is always breaking
+
+Renamed fields are detected from the field mappings in the conversion section of the
+API, or otherwise from a removed and an added field with the same type and default.
+
+The command fails when an API has breaking changes without a change of version.
+Breaking changes between different versions of an API are reported, as they are
+expected to be handled by conversion.`
+	apiDiffExample = `  # detect breaking changes since the main branch
+  operator-builder api-diff --workload-config .workloadConfig/workload.yaml --revision main
+
+  # compare the APIs with those of a previous version
+  operator-builder api-diff --workload-config .workloadConfig/workload.yaml \
+    --previous-workload-config .workloadConfig/v1alpha1/workload.yaml`
+)
+
+func NewAPIDiffCmd() *cobra.Command {
+	options := &subcommand.APIDiffOptions{}
+
+	var configVars []string
+
+	cmd := &cobra.Command{
+		Use:     apiDiffName,
+		Short:   apiDiffDescription,
+		Long:    apiDiffLong,
+		Example: apiDiffExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.Output = cmd.OutOrStdout()
+
+			variables, err := workload.ParseConfigVars(configVars, nil)
+			if err != nil {
+				return fmt.Errorf("%w; %s", err, ErrAPIDiffCommand.Error())
+			}
+
+			options.Variables = variables
+
+			if err := subcommand.APIDiff(options); err != nil {
+				return fmt.Errorf("%w; %s", err, ErrAPIDiffCommand.Error())
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.WorkloadConfigPath, "workload-config", "w", "", "path to workload config file")
+	workload.AddConfigVarFlag(cmd.Flags(), &configVars)
+	cmd.Flags().StringVar(
+		&options.Revision,
+		"revision",
+		"",
+		"git revision of the workload config to compare with, such as a branch, tag or commit",
+	)
+	cmd.Flags().StringVar(
+		&options.PreviousWorkloadConfigPath,
+		"previous-workload-config",
+		"",
+		"path to the workload config of a previous version of the apis to compare with",
+	)
+
+	if err := cmd.MarkFlagRequired("workload-config"); err != nil {
+		panic(err)
+	}
+
+	cmd.MarkFlagsMutuallyExclusive("revision", "previous-workload-config")
+
+	return cmd
+}
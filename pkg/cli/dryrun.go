@@ -0,0 +1,53 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	kbcliv4 "sigs.k8s.io/kubebuilder/v4/pkg/cli"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+
+	"github.com/nukleros/operator-builder/internal/dryrun"
+)
+
+// dryRunCommand runs a command against an in-memory filesystem and writes a report of the files
+// which would have been changed once it has run.
+type dryRunCommand struct {
+	command command
+	fs      *dryrun.Filesystem
+	output  io.Writer
+}
+
+// NewDryRunWithV2 returns a command with the version 2 plugin which scaffolds to an in-memory
+// filesystem rather than to disk.
+func NewDryRunWithV2() (command, error) {
+	fs := dryrun.NewFilesystem()
+
+	c, err := NewWithV2(kbcliv4.WithFilesystem(machinery.Filesystem{FS: fs}))
+	if err != nil {
+		return nil, err
+	}
+
+	return &dryRunCommand{command: c, fs: fs, output: os.Stdout}, nil
+}
+
+func (c *dryRunCommand) Run() error {
+	if err := c.command.Run(); err != nil {
+		return err
+	}
+
+	report, err := c.fs.Report()
+	if err != nil {
+		return fmt.Errorf("unable to create dry run report, %w", err)
+	}
+
+	if err := report.Write(c.output); err != nil {
+		return fmt.Errorf("unable to write dry run report, %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nukleros/operator-builder/internal/plugins/workload"
+	"github.com/nukleros/operator-builder/internal/workload/v1/commands/subcommand"
+	"github.com/nukleros/operator-builder/internal/workload/v1/graph"
+)
+
+var ErrGraphCommand = errors.New("error executing `graph` command")
+
+const (
+	graphName        = "graph"
+	graphDescription = "Print the dependency and ownership graph of a workload config"
+	graphLong        = `Print the graph of the workloads within a workload config.  The graph includes
+the components of each collection, the dependencies of each component upon other
+components and upon external resources, and the child resources which each
+workload manages.  The graph may be printed as DOT, Mermaid or JSON.
+
+Dependency cycles between components are reported with the full path of each
+cycle, as a component within a cycle never becomes ready.  The command fails
+when a cycle is found, after the graph has been printed.`
+	graphExample = `  # render the graph as an image with graphviz
+  operator-builder graph --workload-config .workloadConfig/workload.yaml | dot -Tpng -o workloads.png
+
+  # print the graph as a mermaid flowchart
+  operator-builder graph --workload-config .workloadConfig/workload.yaml --format mermaid`
+)
+
+func NewGraphCmd() *cobra.Command {
+	options := &subcommand.GraphOptions{}
+
+	var configVars []string
+
+	cmd := &cobra.Command{
+		Use:     graphName,
+		Short:   graphDescription,
+		Long:    graphLong,
+		Example: graphExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.Output = cmd.OutOrStdout()
+
+			variables, err := workload.ParseConfigVars(configVars, nil)
+			if err != nil {
+				return fmt.Errorf("%w; %s", err, ErrGraphCommand.Error())
+			}
+
+			options.Variables = variables
+
+			if err := subcommand.Graph(options); err != nil {
+				return fmt.Errorf("%w; %s", err, ErrGraphCommand.Error())
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.WorkloadConfigPath, "workload-config", "w", "", "path to workload config file")
+	workload.AddConfigVarFlag(cmd.Flags(), &configVars)
+	cmd.Flags().StringVar(
+		&options.Format,
+		"format",
+		graph.FormatDOT,
+		fmt.Sprintf("format of the graph, one of [%s]", strings.Join(graph.Formats(), ", ")),
+	)
+
+	if err := cmd.MarkFlagRequired("workload-config"); err != nil {
+		panic(err)
+	}
+
+	return cmd
+}
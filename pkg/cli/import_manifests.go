@@ -0,0 +1,52 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nukleros/operator-builder/internal/workload/v1/commands/subcommand"
+)
+
+var ErrImportManifestsCommand = errors.New("error executing `import-manifests` command")
+
+const (
+	importManifestsName        = "import-manifests"
+	importManifestsDescription = "Import manifests exported from a running cluster as clean source manifests"
+	importManifestsLong        = `Import manifests exported from a running cluster, for example with
+'kubectl get -o yaml', as clean source manifests.  Server populated fields such as
+status, managed fields, resource versions, uids and server defaulted values are
+removed and each object is written to its own file in the output directory.`
+	importManifestsExample = `  # import all exported manifests into the manifests directory
+  operator-builder import-manifests export/*.yaml --output manifests`
+)
+
+func NewImportManifestsCmd() *cobra.Command {
+	options := &subcommand.ImportManifestsOptions{}
+
+	cmd := &cobra.Command{
+		Use:     importManifestsName + " FILE...",
+		Short:   importManifestsDescription,
+		Long:    importManifestsLong,
+		Example: importManifestsExample,
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.Files = args
+
+			if err := subcommand.ImportManifests(options); err != nil {
+				return fmt.Errorf("%w; %s", err, ErrImportManifestsCommand.Error())
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.OutputDir, "output", "o", ".", "directory to write the imported manifests to")
+	cmd.Flags().BoolVarP(&options.Force, "force", "f", false, "override the manifests if they already exist")
+
+	return cmd
+}
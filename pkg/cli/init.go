@@ -22,6 +22,7 @@ import (
 	pluginv4 "sigs.k8s.io/kubebuilder/v4/pkg/plugin"
 	kustomizecommonv2 "sigs.k8s.io/kubebuilder/v4/pkg/plugins/common/kustomize/v2"
 
+	"github.com/nukleros/operator-builder/internal/dryrun"
 	"github.com/nukleros/operator-builder/internal/plugins"
 	configv1 "github.com/nukleros/operator-builder/internal/plugins/config/v1"
 	licensev1 "github.com/nukleros/operator-builder/internal/plugins/license/v1"
@@ -48,6 +49,10 @@ func NewKubebuilderCLI(version workload.PluginVersion) (command, error) {
 	case workload.PluginVersionV1:
 		return NewWithV1()
 	case workload.PluginVersionV2:
+		if dryrun.Requested(os.Args[1:]) {
+			return NewDryRunWithV2()
+		}
+
 		return NewWithV2()
 	default:
 		return NewKubebuilderCLI(workload.DefaultPluginVersion)
@@ -93,6 +98,15 @@ func NewWithV1() (*kbcliv3.CLI, error) {
 		kbcliv3.WithDefaultProjectVersion(cfgv3old.Version),
 		kbcliv3.WithExtraCommands(NewUpdateCmd()),
 		kbcliv3.WithExtraCommands(NewInitConfigCmd()),
+		kbcliv3.WithExtraCommands(NewImportManifestsCmd()),
+		kbcliv3.WithExtraCommands(NewSuggestMarkersCmd()),
+		kbcliv3.WithExtraCommands(NewValidateConfigCmd()),
+		kbcliv3.WithExtraCommands(NewMigrateConfigCmd()),
+		kbcliv3.WithExtraCommands(NewGraphCmd()),
+		kbcliv3.WithExtraCommands(NewRBACCmd()),
+		kbcliv3.WithExtraCommands(NewRegenerateCmd()),
+		kbcliv3.WithExtraCommands(NewVerifyCmd()),
+		kbcliv3.WithExtraCommands(NewAPIDiffCmd()),
 		kbcliv3.WithCompletion(),
 	)
 	if err != nil {
@@ -102,7 +116,7 @@ func NewWithV1() (*kbcliv3.CLI, error) {
 	return c, nil
 }
 
-func NewWithV2() (*kbcliv4.CLI, error) {
+func NewWithV2(options ...kbcliv4.Option) (*kbcliv4.CLI, error) {
 	base, err := pluginv4.NewBundleWithOptions(
 		pluginv4.WithName(plugins.DefaultNameQualifier),
 		pluginv4.WithVersion(pluginv4.Version{Number: 2}),
@@ -116,7 +130,7 @@ func NewWithV2() (*kbcliv4.CLI, error) {
 		return nil, fmt.Errorf("unable to initialize kubebuilder plugin bundle with version 2 plugin, %w", err)
 	}
 
-	c, err := kbcliv4.New(
+	c, err := kbcliv4.New(append([]kbcliv4.Option{
 		kbcliv4.WithCommandName(commandName),
 		kbcliv4.WithVersion(version),
 		kbcliv4.WithPlugins(
@@ -128,8 +142,17 @@ func NewWithV2() (*kbcliv4.CLI, error) {
 		kbcliv4.WithDefaultPlugins(cfgv3.Version, base),
 		kbcliv4.WithDefaultProjectVersion(cfgv3.Version),
 		kbcliv4.WithExtraCommands(NewInitConfigCmd()),
+		kbcliv4.WithExtraCommands(NewImportManifestsCmd()),
+		kbcliv4.WithExtraCommands(NewSuggestMarkersCmd()),
+		kbcliv4.WithExtraCommands(NewValidateConfigCmd()),
+		kbcliv4.WithExtraCommands(NewMigrateConfigCmd()),
+		kbcliv4.WithExtraCommands(NewGraphCmd()),
+		kbcliv4.WithExtraCommands(NewRBACCmd()),
+		kbcliv4.WithExtraCommands(NewRegenerateCmd()),
+		kbcliv4.WithExtraCommands(NewVerifyCmd()),
+		kbcliv4.WithExtraCommands(NewAPIDiffCmd()),
 		kbcliv4.WithCompletion(),
-	)
+	}, options...)...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create command with version 2 plugin, %w", err)
 	}
@@ -117,6 +117,16 @@ func (i *initConfigSubCommand) addInitSubCommand(parentCommand *cobra.Command) e
 
 	parentCommand.AddCommand(subCommand)
 
+	// generating a config from a directory is only supported for collections and standalone workloads
+	if subCommand.Use != componentSubCommandName {
+		subCommand.Flags().StringVar(
+			&i.options.FromDir,
+			"from-dir",
+			"",
+			"generate the workload config from the manifests in an existing directory",
+		)
+	}
+
 	return i.addCommonFlags(subCommand)
 }
 
@@ -0,0 +1,51 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nukleros/operator-builder/internal/workload/v1/commands/subcommand"
+	"github.com/nukleros/operator-builder/internal/workload/v1/kinds"
+)
+
+var ErrMigrateConfigCommand = errors.New("error executing `migrate-config` command")
+
+const (
+	migrateConfigName        = "migrate-config"
+	migrateConfigDescription = "Migrate workload configs to the current workload config format"
+	migrateConfigExample     = `  # migrate a workload config and, for a collection, all of its component configs
+  operator-builder migrate-config --workload-config .workloadConfig/workload.yaml`
+)
+
+func NewMigrateConfigCmd() *cobra.Command {
+	options := &subcommand.MigrateConfigOptions{}
+
+	cmd := &cobra.Command{
+		Use:   migrateConfigName,
+		Short: migrateConfigDescription,
+		Long: fmt.Sprintf(`Migrate a workload config to the current workload config format, %s.
+The workload config, along with each of the component configs of a collection,
+is rewritten in place.  Comments within the configs are kept.`, kinds.APIVersionCurrent),
+		Example: migrateConfigExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := subcommand.MigrateConfig(options); err != nil {
+				return fmt.Errorf("%w; %s", err, ErrMigrateConfigCommand.Error())
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.WorkloadConfigPath, "workload-config", "w", "", "path to workload config file")
+
+	if err := cmd.MarkFlagRequired("workload-config"); err != nil {
+		panic(err)
+	}
+
+	return cmd
+}
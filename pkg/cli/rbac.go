@@ -0,0 +1,106 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nukleros/operator-builder/internal/plugins/workload"
+	"github.com/nukleros/operator-builder/internal/workload/v1/commands/subcommand"
+	"github.com/nukleros/operator-builder/internal/workload/v1/rbac"
+)
+
+var ErrRBACReportCommand = errors.New("error executing `rbac report` command")
+
+const (
+	rbacReportName        = "report"
+	rbacReportDescription = "Print the RBAC which is granted to the controllers of a workload config"
+	rbacReportLong        = `Print the rules which are granted to the controllers of the workloads within a
+workload config, as they would be generated by the create api command.  Each
+rule is printed along with its source, such as the API of the workload, a child
+resource or an extra rule, the manifest of the child resource and the
+kubebuilder rbac marker which is generated for it.
+
+Rules which grant access by way of a wildcard, such as those requested by
+child cluster roles, are flagged in the report and a warning is printed for
+each of them.
+
+With --minimal, the rules for the child resources only include the verbs
+which the generated controller uses, as they would be generated for a
+workload config with spec.rbac.minimal set.`
+	rbacReportExample = `  # print the rbac report as yaml
+  operator-builder rbac report --workload-config .workloadConfig/workload.yaml
+
+  # print the rbac report as a table with the minimal verbs for the child resources
+  operator-builder rbac report --workload-config .workloadConfig/workload.yaml --format table --minimal`
+)
+
+func NewRBACCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rbac",
+		Short: "Analyze the RBAC of the controllers of a workload config",
+		Long:  `Analyze the RBAC of the controllers of a workload config.`,
+	}
+
+	cmd.AddCommand(
+		NewRBACReportCmd(),
+	)
+
+	return cmd
+}
+
+func NewRBACReportCmd() *cobra.Command {
+	options := &subcommand.RBACReportOptions{}
+
+	var configVars []string
+
+	cmd := &cobra.Command{
+		Use:     rbacReportName,
+		Short:   rbacReportDescription,
+		Long:    rbacReportLong,
+		Example: rbacReportExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.Output = cmd.OutOrStdout()
+			options.ErrOutput = cmd.ErrOrStderr()
+
+			variables, err := workload.ParseConfigVars(configVars, nil)
+			if err != nil {
+				return fmt.Errorf("%w; %s", err, ErrRBACReportCommand.Error())
+			}
+
+			options.Variables = variables
+
+			if err := subcommand.RBACReport(options); err != nil {
+				return fmt.Errorf("%w; %s", err, ErrRBACReportCommand.Error())
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.WorkloadConfigPath, "workload-config", "w", "", "path to workload config file")
+	workload.AddConfigVarFlag(cmd.Flags(), &configVars)
+	cmd.Flags().StringVar(
+		&options.Format,
+		"format",
+		rbac.FormatYAML,
+		fmt.Sprintf("format of the report, one of [%s]", strings.Join(rbac.Formats(), ", ")),
+	)
+	cmd.Flags().BoolVar(
+		&options.Minimal,
+		"minimal",
+		false,
+		"only include the verbs which the generated controller uses in the rules for child resources",
+	)
+
+	if err := cmd.MarkFlagRequired("workload-config"); err != nil {
+		panic(err)
+	}
+
+	return cmd
+}
@@ -0,0 +1,84 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/kubebuilder/v4/pkg/config"
+	"sigs.k8s.io/kubebuilder/v4/pkg/config/store/yaml"
+	"sigs.k8s.io/kubebuilder/v4/pkg/machinery"
+
+	"github.com/nukleros/operator-builder/internal/plugins/workload"
+	"github.com/nukleros/operator-builder/internal/workload/v1/commands/subcommand"
+)
+
+var ErrRegenerateCommand = errors.New("error executing `regenerate` command")
+
+const (
+	regenerateName        = "regenerate"
+	regenerateDescription = "Regenerate every workload of a project from its workload config"
+	regenerateLong        = `Regenerate the API, controller and companion CLI of every workload and component
+within a project.  The workload config which is stored in the PROJECT file under
+plugins.operatorBuilder is parsed again, along with the variables which were given
+to the command which created the project, and each of its workloads is scaffolded
+in the same way as the ` + "`create api --resource --controller --force`" + ` command.
+
+Files which are owned by the user once they have been created, such as the mutate
+and dependency functions, are left as they are.`
+	regenerateExample = `  # regenerate every workload after changing the manifests or the workload config
+  operator-builder regenerate
+
+  # print the changes that regenerating would make without writing them
+  operator-builder regenerate --dry-run`
+)
+
+func NewRegenerateCmd() *cobra.Command {
+	options := &subcommand.RegenerateOptions{}
+
+	cmd := &cobra.Command{
+		Use:     regenerateName,
+		Short:   regenerateDescription,
+		Long:    regenerateLong,
+		Example: regenerateExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projectConfig, err := loadProjectConfig()
+			if err != nil {
+				return fmt.Errorf("%w; %s", err, ErrRegenerateCommand.Error())
+			}
+
+			options.ProjectConfig = projectConfig
+			options.Execute = func(args []string) error {
+				root := cmd.Root()
+				root.SetArgs(args)
+
+				//nolint:wrapcheck
+				return root.Execute()
+			}
+
+			if err := subcommand.Regenerate(options); err != nil {
+				return fmt.Errorf("%w; %s", err, ErrRegenerateCommand.Error())
+			}
+
+			return nil
+		},
+	}
+
+	workload.AddDryRunFlag(cmd.Flags(), &options.DryRun)
+
+	return cmd
+}
+
+// loadProjectConfig loads the project config from the PROJECT file in the current directory.
+func loadProjectConfig() (config.Config, error) {
+	store := yaml.New(machinery.Filesystem{FS: afero.NewOsFs()})
+	if err := store.Load(); err != nil {
+		return nil, fmt.Errorf("%w; unable to load project config", err)
+	}
+
+	return store.Config(), nil
+}
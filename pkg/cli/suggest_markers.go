@@ -0,0 +1,71 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nukleros/operator-builder/internal/plugins/workload"
+	"github.com/nukleros/operator-builder/internal/workload/v1/commands/subcommand"
+)
+
+var ErrSuggestMarkersCommand = errors.New("error executing `suggest-markers` command")
+
+const (
+	suggestMarkersName        = "suggest-markers"
+	suggestMarkersDescription = "Suggest field markers for common tunables in workload manifests"
+	suggestMarkersLong        = `Suggest field markers for common tunables in the manifests of a workload.
+Markers are suggested for container images, replicas, compute resource requests
+and limits, service types, ingress hosts, storage sizes and namespaces.  Values
+which already have a marker are skipped and values which appear in several
+manifests share a single marker.  By default the suggestions are written to
+stdout as a patch.`
+	suggestMarkersExample = `  # review the suggested markers as a patch
+  operator-builder suggest-markers --workload-config .workloadConfig/workload.yaml
+
+  # add the suggested markers to the manifests in place
+  operator-builder suggest-markers --workload-config .workloadConfig/workload.yaml --write`
+)
+
+func NewSuggestMarkersCmd() *cobra.Command {
+	options := &subcommand.SuggestMarkersOptions{}
+
+	var configVars []string
+
+	cmd := &cobra.Command{
+		Use:     suggestMarkersName,
+		Short:   suggestMarkersDescription,
+		Long:    suggestMarkersLong,
+		Example: suggestMarkersExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.Output = cmd.OutOrStdout()
+
+			variables, err := workload.ParseConfigVars(configVars, nil)
+			if err != nil {
+				return fmt.Errorf("%w; %s", err, ErrSuggestMarkersCommand.Error())
+			}
+
+			options.Variables = variables
+
+			if err := subcommand.SuggestMarkers(options); err != nil {
+				return fmt.Errorf("%w; %s", err, ErrSuggestMarkersCommand.Error())
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.WorkloadConfigPath, "workload-config", "w", "", "path to workload config file")
+	workload.AddConfigVarFlag(cmd.Flags(), &configVars)
+	cmd.Flags().BoolVar(&options.Write, "write", false, "add the suggested markers to the manifests in place")
+
+	if err := cmd.MarkFlagRequired("workload-config"); err != nil {
+		panic(err)
+	}
+
+	return cmd
+}
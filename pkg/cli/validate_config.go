@@ -0,0 +1,83 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/nukleros/operator-builder/internal/plugins/workload"
+	"github.com/nukleros/operator-builder/internal/workload/v1/config"
+)
+
+var (
+	ErrValidateConfigCommand       = errors.New("error executing `validate-config` command")
+	ErrValidateConfigMissingConfig = errors.New("the --workload-config flag is required unless --print-schema is given")
+)
+
+const (
+	validateConfigName        = "validate-config"
+	validateConfigDescription = "Validate a workload config and all of its component configs"
+	validateConfigLong        = `Validate a workload config and all of the component configs which it
+references, including those found with glob patterns in componentFiles.  All of
+the errors which are found are reported together.
+
+The JSON Schema for workload config files, which may be used by editors for
+validation and completion, is printed with the --print-schema flag.`
+	validateConfigExample = `  # validate a workload config bundle
+  operator-builder validate-config --workload-config .workloadConfig/workload.yaml
+
+  # save the JSON Schema for workload config files
+  operator-builder validate-config --print-schema > workload-config.schema.json`
+)
+
+func NewValidateConfigCmd() *cobra.Command {
+	var workloadConfigPath string
+
+	var printSchema bool
+
+	var configVars []string
+
+	cmd := &cobra.Command{
+		Use:     validateConfigName,
+		Short:   validateConfigDescription,
+		Long:    validateConfigLong,
+		Example: validateConfigExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if printSchema {
+				if _, err := cmd.OutOrStdout().Write(config.Schema); err != nil {
+					return fmt.Errorf("%w; %s", err, ErrValidateConfigCommand.Error())
+				}
+
+				return nil
+			}
+
+			if workloadConfigPath == "" {
+				return fmt.Errorf("%w; %s", ErrValidateConfigMissingConfig, ErrValidateConfigCommand.Error())
+			}
+
+			variables, err := workload.ParseConfigVars(configVars, nil)
+			if err != nil {
+				return fmt.Errorf("%w; %s", err, ErrValidateConfigCommand.Error())
+			}
+
+			if err := config.ValidateBundle(workloadConfigPath, variables); err != nil {
+				return fmt.Errorf("%w\n%s", err, ErrValidateConfigCommand.Error())
+			}
+
+			log.Infof("workload config %s is valid", workloadConfigPath)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&workloadConfigPath, "workload-config", "w", "", "path to workload config file")
+	workload.AddConfigVarFlag(cmd.Flags(), &configVars)
+	cmd.Flags().BoolVar(&printSchema, "print-schema", false, "print the JSON Schema for workload config files")
+
+	return cmd
+}
@@ -0,0 +1,59 @@
+// Copyright 2024 Nukleros
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nukleros/operator-builder/internal/workload/v1/commands/subcommand"
+)
+
+var ErrVerifyCommand = errors.New("error executing `verify` command")
+
+const (
+	verifyName        = "verify"
+	verifyDescription = "Verify that the generated code of a project is current with its inputs"
+	verifyLong        = `Verify that the generated code of a project is current with the workload config
+and manifests from which it is generated.  The hash of each input is recorded in the
+.operator-builder/inputs.lock file each time that the code is generated, and is
+compared with the hash of the input in the repository.
+
+The command fails, listing each of the inputs which have changed, when the code has
+not been regenerated since any of its inputs changed.  This is intended to be run in
+CI to ensure that the generated code is not out of sync with the manifests.`
+	verifyExample = `  # verify that the generated code is current
+  operator-builder verify`
+)
+
+func NewVerifyCmd() *cobra.Command {
+	options := &subcommand.VerifyOptions{}
+
+	cmd := &cobra.Command{
+		Use:     verifyName,
+		Short:   verifyDescription,
+		Long:    verifyLong,
+		Example: verifyExample,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.Output = cmd.OutOrStdout()
+
+			projectConfig, err := loadProjectConfig()
+			if err != nil {
+				return fmt.Errorf("%w; %s", err, ErrVerifyCommand.Error())
+			}
+
+			options.ProjectConfig = projectConfig
+
+			if err := subcommand.Verify(options); err != nil {
+				return fmt.Errorf("%w; %s", err, ErrVerifyCommand.Error())
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}